@@ -0,0 +1,47 @@
+package db
+
+import (
+	"embed"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationsTable отделяет версии миграций api от миграций остальных сервисов в общей базе
+const migrationsTable = "api_schema_migrations"
+
+func (db *DB) Migrate() error {
+	db.log.Info("running migration")
+	files, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		db.log.Error("failed to create migration source", "error", err)
+		return err
+	}
+	driver, err := pgx.WithInstance(db.conn.DB, &pgx.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		db.log.Error("failed to create driver", "error", err)
+		return err
+	}
+	m, err := migrate.NewWithInstance("iofs", files, "pgx", driver)
+	if err != nil {
+		db.log.Error("failed to init migration instance", "error", err)
+		return err
+	}
+	db.log.Info("applying migrations")
+	err = m.Up()
+	if err != nil {
+		if err != migrate.ErrNoChange {
+			db.log.Error("migration failed", "error", err)
+			return err
+		}
+		db.log.Info("no new migrations to apply")
+	} else {
+		db.log.Info("migrations applied successfully")
+	}
+	db.log.Debug("migration finished")
+	return nil
+}
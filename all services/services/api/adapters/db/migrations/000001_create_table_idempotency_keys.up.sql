@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys (
+	key TEXT PRIMARY KEY,
+	fingerprint BYTEA NOT NULL,
+	done BOOLEAN NOT NULL DEFAULT FALSE,
+	status INTEGER NOT NULL DEFAULT 0,
+	header JSONB,
+	body BYTEA,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
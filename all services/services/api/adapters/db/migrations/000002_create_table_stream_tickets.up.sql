@@ -0,0 +1,7 @@
+CREATE TABLE stream_tickets (
+	ticket_hash BYTEA PRIMARY KEY,
+	user_id BIGINT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX stream_tickets_expires_at_idx ON stream_tickets (expires_at);
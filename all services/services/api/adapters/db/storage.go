@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"seeforme/api/core"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+// cleanupInterval - как часто удалять просроченные ключи и билеты
+const cleanupInterval = time.Minute
+
+type DB struct {
+	log  *slog.Logger
+	conn *sqlx.DB
+}
+
+func New(log *slog.Logger, address string) (*DB, error) {
+	db, err := sqlx.Connect("pgx", address)
+	if err != nil {
+		log.Error("connection problem", "address", address, "error", err)
+		return nil, err
+	}
+
+	return &DB{
+		log:  log,
+		conn: db,
+	}, nil
+}
+
+func (d *DB) Reserve(ctx context.Context, key string, fingerprint []byte, lock time.Duration) (core.IdempotentResponse, bool, error) {
+	// просроченная запись занимается заново, как свободный ключ
+	reserve := `
+		INSERT INTO idempotency_keys (key, fingerprint, expires_at) VALUES ($1, $2, NOW() + $3 * INTERVAL '1 millisecond')
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, done = FALSE, status = 0, header = NULL, body = NULL, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < NOW()`
+	// ключ может освободиться между вставкой и чтением, тогда пробуем занять его ещё раз
+	for range 3 {
+		result, err := d.conn.ExecContext(ctx, reserve, key, fingerprint, lock.Milliseconds())
+		if err != nil {
+			d.log.Error("failed to reserve idempotency key", "error", err)
+			return core.IdempotentResponse{}, false, err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 1 {
+			return core.IdempotentResponse{}, true, nil
+		}
+
+		var row struct {
+			Fingerprint []byte `db:"fingerprint"`
+			Done        bool   `db:"done"`
+			Status      int    `db:"status"`
+			Header      []byte `db:"header"`
+			Body        []byte `db:"body"`
+		}
+		query := `SELECT fingerprint, done, status, header, body FROM idempotency_keys WHERE key = $1 AND expires_at >= NOW()`
+		err = d.conn.GetContext(ctx, &row, query, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			d.log.Error("failed to get idempotency key", "error", err)
+			return core.IdempotentResponse{}, false, err
+		}
+
+		saved := core.IdempotentResponse{Fingerprint: row.Fingerprint, Done: row.Done, Status: row.Status, Body: row.Body}
+		if row.Header != nil {
+			if err := json.Unmarshal(row.Header, &saved.Header); err != nil {
+				d.log.Error("failed to decode saved headers", "error", err)
+				return core.IdempotentResponse{}, false, err
+			}
+		}
+		return saved, false, nil
+	}
+
+	return core.IdempotentResponse{}, false, errors.New("idempotency key keeps changing")
+}
+
+func (d *DB) Complete(ctx context.Context, key string, response core.IdempotentResponse, ttl time.Duration) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE idempotency_keys SET done = TRUE, status = $2, header = $3, body = $4, expires_at = NOW() + $5 * INTERVAL '1 millisecond'
+		WHERE key = $1`
+	if _, err := d.conn.ExecContext(ctx, query, key, response.Status, header, response.Body, ttl.Milliseconds()); err != nil {
+		d.log.Error("failed to save idempotent response", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) Release(ctx context.Context, key string) error {
+	if _, err := d.conn.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE key = $1 AND done = FALSE`, key); err != nil {
+		d.log.Error("failed to release idempotency key", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// SaveStreamTicket хранит только хэш билета: по содержимому базы к потоку не подключиться
+func (d *DB) SaveStreamTicket(ctx context.Context, ticket string, userID int64, ttl time.Duration) error {
+	hash := sha256.Sum256([]byte(ticket))
+	query := `INSERT INTO stream_tickets (ticket_hash, user_id, expires_at) VALUES ($1, $2, NOW() + $3 * INTERVAL '1 millisecond')`
+	if _, err := d.conn.ExecContext(ctx, query, hash[:], userID, ttl.Milliseconds()); err != nil {
+		d.log.Error("failed to save stream ticket", "user", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) RedeemStreamTicket(ctx context.Context, ticket string) (int64, bool, error) {
+	hash := sha256.Sum256([]byte(ticket))
+	var userID int64
+	query := `DELETE FROM stream_tickets WHERE ticket_hash = $1 AND expires_at >= NOW() RETURNING user_id`
+	err := d.conn.GetContext(ctx, &userID, query, hash[:])
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		d.log.Error("failed to redeem stream ticket", "error", err)
+		return 0, false, err
+	}
+
+	return userID, true, nil
+}
+
+// Run удаляет просроченные ключи и билеты, пока не отменён ctx
+func (d *DB) Run(ctx context.Context) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.conn.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE expires_at < NOW()`); err != nil {
+				d.log.Error("failed to delete expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package help
+
+import (
+	"context"
+	"log/slog"
+
+	"seeforme/api/core"
+	helppb "seeforme/proto/help"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client helppb.HelpClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: helppb.NewHelpClient(conn),
+	}, nil
+}
+
+func (c *Client) CreateHelpRequest(ctx context.Context, requesterID int64, question string) (int64, error) {
+	response, err := c.client.CreateHelpRequest(ctx, &helppb.CreateHelpRequestRequest{
+		RequesterId: requesterID,
+		Question:    question,
+	})
+	if err != nil {
+		c.log.Error("failed to create help request", "error", err)
+		return 0, err
+	}
+	return response.GetId(), nil
+}
+
+func (c *Client) CancelHelpRequest(ctx context.Context, id, requesterID int64) error {
+	_, err := c.client.CancelHelpRequest(ctx, &helppb.CancelHelpRequestRequest{
+		Id:          id,
+		RequesterId: requesterID,
+	})
+	if err != nil {
+		c.log.Error("failed to cancel help request", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) EscalateHelpRequest(ctx context.Context, id, requesterID int64) error {
+	_, err := c.client.EscalateHelpRequest(ctx, &helppb.EscalateHelpRequestRequest{
+		Id:          id,
+		RequesterId: requesterID,
+	})
+	if err != nil {
+		c.log.Error("failed to escalate help request", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) AcceptHelpRequest(ctx context.Context, id, volunteerID int64) error {
+	_, err := c.client.AcceptHelpRequest(ctx, &helppb.AcceptHelpRequestRequest{
+		Id:          id,
+		VolunteerId: volunteerID,
+	})
+	if err != nil {
+		c.log.Error("failed to accept help request", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) SubmitRating(ctx context.Context, rating core.Rating) error {
+	_, err := c.client.SubmitRating(ctx, &helppb.SubmitRatingRequest{
+		HelpRequestId: rating.HelpRequestID,
+		RaterId:       rating.RaterID,
+		Score:         int32(rating.Score),
+		Tags:          rating.Tags,
+		Comment:       rating.Comment,
+	})
+	if err != nil {
+		c.log.Error("failed to submit rating", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) GetVolunteerStats(ctx context.Context, volunteerID int64) (core.VolunteerStats, error) {
+	response, err := c.client.GetVolunteerStats(ctx, &helppb.GetVolunteerStatsRequest{VolunteerId: volunteerID})
+	if err != nil {
+		c.log.Error("failed to get volunteer stats", "error", err)
+		return core.VolunteerStats{}, err
+	}
+
+	stats := core.VolunteerStats{
+		CallsToday: int(response.GetCallsToday()),
+		OnCall:     response.GetOnCall(),
+		Limit:      response.GetLimit(),
+	}
+	if response.GetLastCallEndedAt() != nil {
+		ended := response.GetLastCallEndedAt().AsTime()
+		stats.LastCallEndedAt = &ended
+	}
+	return stats, nil
+}
+
+func (c *Client) GetDispatchLog(ctx context.Context, id int64) ([]core.MatchScore, error) {
+	response, err := c.client.GetDispatchLog(ctx, &helppb.GetDispatchLogRequest{HelpRequestId: id})
+	if err != nil {
+		c.log.Error("failed to get dispatch log", "error", err)
+		return nil, err
+	}
+
+	scores := make([]core.MatchScore, 0, len(response.GetScores()))
+	for _, s := range response.GetScores() {
+		score := core.MatchScore{
+			Wave:        int(s.GetWave()),
+			VolunteerID: s.GetVolunteerId(),
+			Score:       s.GetScore(),
+			Offered:     s.GetOffered(),
+			Excluded:    s.GetExcluded(),
+			Factors:     []core.ScoreFactor{},
+			CreatedAt:   s.GetCreatedAt().AsTime(),
+		}
+		for _, f := range s.GetFactors() {
+			score.Factors = append(score.Factors, core.ScoreFactor{
+				Name:   f.GetName(),
+				Value:  f.GetValue(),
+				Weight: f.GetWeight(),
+				Reason: f.GetReason(),
+			})
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
@@ -0,0 +1,62 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"seeforme/api/core"
+	"seeforme/pkg/events"
+	"seeforme/pkg/kafkaretry"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var answerEvents = map[string]string{
+	AnswerAccept:     core.EventVolunteerFound,
+	AnswerConnecting: core.EventVolunteerConnecting,
+	AnswerCancel:     core.EventRequestCancelled,
+	AnswerExpired:    core.EventRequestExpired,
+}
+
+// Consumer читает ответы волонтёров и передаёт их автору запроса.
+// Повторы и DLQ - в kafkaretry.
+type Consumer struct {
+	*kafkaretry.Consumer
+	events *core.EventHub
+	log    *slog.Logger
+}
+
+func NewConsumer(cfg kafkaretry.Config, events *core.EventHub, log *slog.Logger) *Consumer {
+	c := &Consumer{
+		events: events,
+		log:    log,
+	}
+	c.Consumer = kafkaretry.NewConsumer(cfg, c.handle, log)
+	return c
+}
+
+func (c *Consumer) handle(_ context.Context, message kafka.Message) error {
+	envelope, err := events.Unmarshal(message.Value)
+	if err != nil {
+		return kafkaretry.Permanent(fmt.Errorf("decode help response: %w", err))
+	}
+	response := envelope.GetHelpResponded()
+	if response == nil {
+		return kafkaretry.Permanent(fmt.Errorf("unexpected event %s in help responses", envelope.GetType()))
+	}
+
+	eventType, ok := answerEvents[response.GetAnswer()]
+	if !ok || response.GetRequesterId() == 0 {
+		return kafkaretry.Permanent(fmt.Errorf("unknown help response %q for request %d", response.GetAnswer(), response.GetHelpRequestId()))
+	}
+
+	delivered := c.events.Publish(response.GetRequesterId(), core.Event{
+		Type:          eventType,
+		HelpRequestID: response.GetHelpRequestId(),
+		VolunteerID:   response.GetVolunteerId(),
+		CreatedAt:     envelope.GetTimestamp().AsTime(),
+	})
+	c.log.Debug("help response routed", "help_request", response.GetHelpRequestId(), "answer", response.GetAnswer(), "delivered", delivered)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"seeforme/api/core"
+	notifypb "seeforme/proto/notify"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client notifypb.NotifyClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: notifypb.NewNotifyClient(conn),
+	}, nil
+}
+
+func toDevice(d *notifypb.Device) core.Device {
+	return core.Device{
+		ID:         d.GetId(),
+		Token:      d.GetToken(),
+		Platform:   d.GetPlatform(),
+		AppVersion: d.GetAppVersion(),
+		CreatedAt:  d.GetCreatedAt().AsTime(),
+		UpdatedAt:  d.GetUpdatedAt().AsTime(),
+	}
+}
+
+func (c *Client) RegisterDevice(ctx context.Context, userID int64, device core.Device) (core.Device, error) {
+	response, err := c.client.RegisterDevice(ctx, &notifypb.RegisterDeviceRequest{
+		UserId:     userID,
+		Token:      device.Token,
+		Platform:   device.Platform,
+		AppVersion: device.AppVersion,
+	})
+	if err != nil {
+		c.log.Error("failed to register device", "error", err)
+		return core.Device{}, err
+	}
+	return toDevice(response), nil
+}
+
+func (c *Client) UnregisterDevice(ctx context.Context, userID, id int64) error {
+	if _, err := c.client.UnregisterDevice(ctx, &notifypb.UnregisterDeviceRequest{UserId: userID, Id: id}); err != nil {
+		c.log.Error("failed to unregister device", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) ListDevices(ctx context.Context, userID int64) ([]core.Device, error) {
+	response, err := c.client.ListDevices(ctx, &notifypb.ListDevicesRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to list devices", "error", err)
+		return nil, err
+	}
+
+	devices := make([]core.Device, 0, len(response.GetDevices()))
+	for _, d := range response.GetDevices() {
+		devices = append(devices, toDevice(d))
+	}
+	return devices, nil
+}
+
+func (c *Client) GetPreferences(ctx context.Context, userID int64) (core.NotificationPreferences, error) {
+	response, err := c.client.GetPreferences(ctx, &notifypb.GetPreferencesRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to get notification preferences", "error", err)
+		return core.NotificationPreferences{}, err
+	}
+	return core.NotificationPreferences{
+		Channels: response.GetChannels(),
+		Phone:    response.GetPhone(),
+		Email:    response.GetEmail(),
+	}, nil
+}
+
+func (c *Client) SetPreferences(ctx context.Context, userID int64, preferences core.NotificationPreferences) error {
+	_, err := c.client.SetPreferences(ctx, &notifypb.SetPreferencesRequest{
+		UserId: userID,
+		Preferences: &notifypb.Preferences{
+			Channels: preferences.Channels,
+			Phone:    preferences.Phone,
+			Email:    preferences.Email,
+		},
+	})
+	if err != nil {
+		c.log.Error("failed to set notification preferences", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) AckNotification(ctx context.Context, userID, id int64, status string) error {
+	_, err := c.client.AckNotification(ctx, &notifypb.AckNotificationRequest{UserId: userID, Id: id, Status: status})
+	if err != nil {
+		c.log.Error("failed to acknowledge notification", "error", err)
+		return err
+	}
+	return nil
+}
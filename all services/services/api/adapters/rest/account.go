@@ -0,0 +1,202 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"time"
+)
+
+func NewDeleteAccountHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		if err := userservice.DeleteAccount(r.Context(), userID); err != nil {
+			log.Error("failed to delete account", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func NewExportMyDataHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		data, err := userservice.ExportMyData(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to export user data", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="seeforme-data.json"`)
+		if _, err := w.Write(data); err != nil {
+			log.Error("failed to write response", "error", err)
+		}
+	}
+}
+
+func NewChangePasswordHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		email := r.FormValue("email")
+		oldPassword := r.FormValue("old_password")
+		newPassword := r.FormValue("new_password")
+
+		if email == "" || oldPassword == "" || newPassword == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := userservice.ChangePassword(r.Context(), email, oldPassword, newPassword); err != nil {
+			log.Error("failed to change password", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewUpdateProfileHandler принимает JSON {"languages": ["ru"], "timezone": "Europe/Moscow"}
+func NewUpdateProfileHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		var profile core.Profile
+		if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+			log.Error("failed to decode profile", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := userservice.UpdateProfile(r.Context(), userID, profile); err != nil {
+			log.Error("failed to update profile", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewGetAvailabilityHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		availability, err := userservice.GetAvailability(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get availability", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, availability)
+	}
+}
+
+// NewSetAvailabilityHandler заменяет окна целиком:
+// {"windows": [{"weekday": 1, "start": "09:00", "end": "18:00"}]}
+func NewSetAvailabilityHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		var availability core.Availability
+		if err := json.NewDecoder(r.Body).Decode(&availability); err != nil {
+			log.Error("failed to decode availability", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := userservice.SetAvailability(r.Context(), userID, availability.Windows); err != nil {
+			log.Error("failed to set availability", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewSetDoNotDisturbHandler принимает JSON {"until": "2024-01-01T08:00:00Z"}
+func NewSetDoNotDisturbHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		var request struct {
+			Until time.Time `json:"until"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || !request.Until.After(time.Now()) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := userservice.SetDoNotDisturb(r.Context(), userID, &request.Until); err != nil {
+			log.Error("failed to set do not disturb", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewClearDoNotDisturbHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		if err := userservice.SetDoNotDisturb(r.Context(), userID, nil); err != nil {
+			log.Error("failed to clear do not disturb", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,224 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeError переводит ошибку gRPC в HTTP-статус
+func writeError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.InvalidArgument:
+		code = http.StatusBadRequest
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		code = http.StatusConflict
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.ResourceExhausted:
+		code = http.StatusTooManyRequests
+	}
+	w.WriteHeader(code)
+	fmt.Fprint(w, status.Convert(err).Message())
+}
+
+func pathID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, core.ErrbadArguments.Error())
+		return 0, false
+	}
+	return id, true
+}
+
+func writeJSON(log *slog.Logger, w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error("failed to encode response", "error", err)
+	}
+}
+
+func NewAdminListUsersHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+
+		users, total, err := admin.ListUsers(r.Context(), core.UserFilter{
+			Query:  query.Get("query"),
+			Role:   query.Get("role"),
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			log.Error("failed to list users", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, map[string]interface{}{
+			"users":  users,
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		})
+	}
+}
+
+func NewAdminGetUserHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		user, err := admin.GetUser(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get user", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, user)
+	}
+}
+
+func NewAdminSuspendHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		// duration в формате Go ("72h"); без него блокировка бессрочная
+		var until *time.Time
+		if duration := r.FormValue("duration"); duration != "" {
+			d, err := time.ParseDuration(duration)
+			if err != nil || d <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, core.ErrbadArguments.Error())
+				return
+			}
+			t := time.Now().Add(d)
+			until = &t
+		}
+
+		if err := admin.SuspendUser(r.Context(), userID, r.FormValue("reason"), until); err != nil {
+			log.Error("failed to suspend user", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewAdminUnsuspendHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := admin.UnsuspendUser(r.Context(), userID); err != nil {
+			log.Error("failed to unsuspend user", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewAdminResetPasswordHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		password, err := admin.ForcePasswordReset(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to reset password", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, map[string]interface{}{
+			"temporaryPassword": password,
+		})
+	}
+}
+
+func NewAdminChangeRoleHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		role := r.FormValue("role")
+		if role == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := admin.ChangeRole(r.Context(), userID, role); err != nil {
+			log.Error("failed to change role", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewAdminDispatchLogHandler показывает, кому и почему предлагался запрос в каждой волне
+func NewAdminDispatchLogHandler(log *slog.Logger, helpservice core.Help) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		helpRequestID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		scores, err := helpservice.GetDispatchLog(r.Context(), helpRequestID)
+		if err != nil {
+			log.Error("failed to get dispatch log", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, map[string]interface{}{"scores": scores})
+	}
+}
+
+// NewAdminVolunteerStatsHandler показывает нагрузку волонтёра и действующие лимиты
+func NewAdminVolunteerStatsHandler(log *slog.Logger, helpservice core.Help) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		volunteerID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		stats, err := helpservice.GetVolunteerStats(r.Context(), volunteerID)
+		if err != nil {
+			log.Error("failed to get volunteer stats", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, stats)
+	}
+}
@@ -22,8 +22,7 @@ func NewLoginHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
 		role, token, err := userservice.Login(r.Context(), email, password)
 		if err != nil {
 			log.Error("failed to login user", "error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			fmt.Fprint(w, err.Error())
+			writeError(w, err)
 			return
 		}
 
@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"strconv"
+)
+
+func NewListBlockedHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		blocked, err := userservice.ListBlocked(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to list blocked users", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, map[string]interface{}{
+			"users": blocked,
+		})
+	}
+}
+
+func NewBlockUserHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		blockedUserID, err := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+		if err != nil || blockedUserID <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := userservice.BlockUser(r.Context(), userID, blockedUserID); err != nil {
+			log.Error("failed to block user", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func NewUnblockUserHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		blockedUserID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := userservice.UnblockUser(r.Context(), userID, blockedUserID); err != nil {
+			log.Error("failed to unblock user", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+)
+
+func NewListDevicesHandler(log *slog.Logger, notifyservice core.Notify) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		devices, err := notifyservice.ListDevices(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to list devices", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, map[string]interface{}{"devices": devices})
+	}
+}
+
+// NewRegisterDeviceHandler принимает JSON {"token": "...", "platform": "android", "appVersion": "1.4.0"}.
+// Приложение вызывает его при входе и каждый раз, когда FCM выдаёт новый токен.
+func NewRegisterDeviceHandler(log *slog.Logger, notifyservice core.Notify) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		var device core.Device
+		if err := json.NewDecoder(r.Body).Decode(&device); err != nil {
+			log.Error("failed to decode device", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		device, err := notifyservice.RegisterDevice(r.Context(), userID, device)
+		if err != nil {
+			log.Error("failed to register device", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, device)
+	}
+}
+
+func NewUnregisterDeviceHandler(log *slog.Logger, notifyservice core.Notify) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		deviceID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := notifyservice.UnregisterDevice(r.Context(), userID, deviceID); err != nil {
+			log.Error("failed to unregister device", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"time"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+// ticketBytes - длина случайной части билета на поток событий
+const ticketBytes = 32
+
+// NewStreamTicketHandler выдаёт одноразовый билет на /v1/events для EventSource в браузере,
+// который не умеет выставлять заголовки. Билет живёт ttl и гасится при подключении.
+func NewStreamTicketHandler(log *slog.Logger, tickets core.StreamTickets, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		raw := make([]byte, ticketBytes)
+		if _, err := rand.Read(raw); err != nil {
+			log.Error("failed to generate stream ticket", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		ticket := base64.RawURLEncoding.EncodeToString(raw)
+		if err := tickets.SaveStreamTicket(r.Context(), ticket, userID, ttl); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(log, w, streamTicketResponse{Ticket: ticket, ExpiresIn: int64(ttl.Seconds())})
+	}
+}
+
+type streamTicketResponse struct {
+	Ticket    string `json:"ticket"`
+	ExpiresIn int64  `json:"expiresIn"`
+}
+
+// NewStreamAuthMiddleware пускает в поток событий по билету из параметра ticket,
+// а без билета - по JWT в заголовке через auth. Билет убирается из адреса до обработчика,
+// JWT в адресе (access_token) не принимается и тоже убирается.
+func NewStreamAuthMiddleware(log *slog.Logger, tickets core.StreamTickets, auth func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withToken := auth(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			ticket := query.Get("ticket")
+			if query.Has("ticket") || query.Has("access_token") {
+				query.Del("ticket")
+				query.Del("access_token")
+				r = r.Clone(r.Context())
+				r.URL.RawQuery = query.Encode()
+				r.RequestURI = r.URL.RequestURI()
+			}
+			if ticket == "" {
+				withToken.ServeHTTP(w, r)
+				return
+			}
+
+			userID, ok, err := tickets.RedeemStreamTicket(r.Context(), ticket)
+			if err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			if !ok {
+				log.Debug("stream ticket is unknown or expired")
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, core.ErrUnauthorized.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userIDKey, userID)))
+		})
+	}
+}
+
+// NewEventsHandler отдаёт события пользователя как Server-Sent Events,
+// пока клиент не закроет соединение. Комментарий-heartbeat не даёт прокси закрыть его по таймауту.
+func NewEventsHandler(log *slog.Logger, events *core.EventHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			log.Error("streaming is not supported by response writer")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		ch, unsubscribe := events.Subscribe(userID)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		log.Debug("event stream opened", "user", userID)
+		defer log.Debug("event stream closed", "user", userID)
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event := <-ch:
+				data, err := json.Marshal(event)
+				if err != nil {
+					log.Error("failed to encode event", "error", err)
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+					return
+				}
+				flusher.Flush()
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeStreamTickets хранит билеты в памяти; сроки не проверяются
+type fakeStreamTickets struct {
+	mu      sync.Mutex
+	tickets map[string]int64
+}
+
+func (s *fakeStreamTickets) SaveStreamTicket(ctx context.Context, ticket string, userID int64, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tickets[ticket] = userID
+	return nil
+}
+
+func (s *fakeStreamTickets) RedeemStreamTicket(ctx context.Context, ticket string) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	userID, ok := s.tickets[ticket]
+	delete(s.tickets, ticket)
+	return userID, ok, nil
+}
+
+// fakeAuth пускает с заголовком "Bearer user-1" как пользователя 1
+func fakeAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer user-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userIDKey, int64(1))))
+	})
+}
+
+type streamTest struct {
+	tickets *fakeStreamTickets
+	issue   http.Handler
+	stream  http.Handler
+
+	// user и uri - с чем запрос дошёл до потока событий
+	user int64
+	uri  string
+}
+
+func newStreamTest() *streamTest {
+	st := &streamTest{tickets: &fakeStreamTickets{tickets: map[string]int64{}}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	st.issue = fakeAuth(NewStreamTicketHandler(log, st.tickets, 30*time.Second))
+	st.stream = NewStreamAuthMiddleware(log, st.tickets, fakeAuth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		st.user, _ = UserIDFromContext(r.Context())
+		st.uri = r.RequestURI
+	}))
+	return st
+}
+
+func (st *streamTest) ticket(t *testing.T) string {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/v1/events/ticket", nil)
+	r.Header.Set("Authorization", "Bearer user-1")
+	w := httptest.NewRecorder()
+	st.issue.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ticket status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var response streamTicketResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("decode ticket: %v", err)
+	}
+	if response.Ticket == "" || response.ExpiresIn != 30 {
+		t.Fatalf("ticket response = %+v", response)
+	}
+	return response.Ticket
+}
+
+func (st *streamTest) open(target, authorization string) int {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	st.stream.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestStreamTicketIsSingleUse(t *testing.T) {
+	st := newStreamTest()
+	ticket := st.ticket(t)
+
+	if code := st.open("/v1/events?ticket="+ticket, ""); code != http.StatusOK || st.user != 1 {
+		t.Fatalf("first use = %d for user %d, want %d for user 1", code, st.user, http.StatusOK)
+	}
+	if code := st.open("/v1/events?ticket="+ticket, ""); code != http.StatusUnauthorized {
+		t.Fatalf("second use = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestStreamTicketIsRemovedFromURL(t *testing.T) {
+	st := newStreamTest()
+	ticket := st.ticket(t)
+
+	st.open("/v1/events?lang=ru&ticket="+ticket, "")
+	if strings.Contains(st.uri, ticket) || st.uri != "/v1/events?lang=ru" {
+		t.Fatalf("stream got uri %q, want /v1/events?lang=ru", st.uri)
+	}
+}
+
+func TestStreamRejectsTokenInURL(t *testing.T) {
+	st := newStreamTest()
+
+	if code := st.open("/v1/events?access_token=user-1", ""); code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	// с заголовком запрос проходит, но токен из адреса дальше не уходит
+	if code := st.open("/v1/events?access_token=user-1", "Bearer user-1"); code != http.StatusOK || st.uri != "/v1/events" {
+		t.Fatalf("status = %d with uri %q, want %d with /v1/events", code, st.uri, http.StatusOK)
+	}
+}
+
+func TestStreamRejectsUnknownTicket(t *testing.T) {
+	st := newStreamTest()
+
+	// неизвестный билет не подменяется заголовком
+	if code := st.open("/v1/events?ticket=forged", "Bearer user-1"); code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
@@ -4,8 +4,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"seeforme/api/adapters/kafka"
-	"strings"
+	"seeforme/api/core"
 )
 
 type HelpRequest struct {
@@ -13,14 +12,16 @@ type HelpRequest struct {
 }
 
 type HelpHandler struct {
-	log        *slog.Logger
-	kafkaClient *kafka.Client
+	log         *slog.Logger
+	helpservice core.Help
 }
 
-func NewHelpHandler(log *slog.Logger, kafkaClient *kafka.Client) http.Handler {
+// NewHelpHandler сохраняет запрос в сервисе help. Событие в Kafka
+// отправляет outbox сервиса help, поэтому сбой Kafka не ломает запрос пользователя.
+func NewHelpHandler(log *slog.Logger, helpservice core.Help) http.Handler {
 	return &HelpHandler{
-		log:        log,
-		kafkaClient: kafkaClient,
+		log:         log,
+		helpservice: helpservice,
 	}
 }
 
@@ -32,35 +33,99 @@ func (h *HelpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 
-	//изменить !!!!!!
-	authHeader := r.Header.Get("Authorization")
-	userID := "1" // По умолчанию используем ID 1
-	if authHeader != "" {
-		parts := strings.Split(authHeader, " ")
-		if len(parts) == 2 && parts[0] == "Bearer" {
-			userID = parts[1] 
-		}
-	}
-
-	helpRequest := kafka.NewHelpRequest(userID, req.Question)
-	data, err := helpRequest.ToJSON()
-	if err != nil {
-		h.log.Error("failed to marshal help request", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	requesterID, ok := UserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	if err := h.kafkaClient.SendMessage(r.Context(), userID, data); err != nil {
-		h.log.Error("failed to send message to kafka", "error", err)
+	helpRequestID, err := h.helpservice.CreateHelpRequest(r.Context(), requesterID, req.Question)
+	if err != nil {
+		h.log.Error("failed to save help request", "error", err)
 		http.Error(w, "Failed to process help request", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusAccepted)
-	json.NewEncoder(w).Encode(map[string]string{
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":      helpRequestID,
 		"status":  "success",
 		"message": "Your help request has been received and will be processed shortly",
 	})
-} 
\ No newline at end of file
+}
+
+// NewCancelHelpHandler отменяет свой запрос, пока его никто не принял.
+// Волонтёры и другие клиенты автора узнают об отмене через Kafka.
+func NewCancelHelpHandler(log *slog.Logger, helpservice core.Help) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requesterID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		helpRequestID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := helpservice.CancelHelpRequest(r.Context(), helpRequestID, requesterID); err != nil {
+			log.Error("failed to cancel help request", "id", helpRequestID, "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewAcceptHelpHandler - волонтёр принимает предложенный ему запрос.
+// Остальным волонтёрам уходит отзыв предложения, автору - volunteer_found.
+func NewAcceptHelpHandler(log *slog.Logger, helpservice core.Help) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		volunteerID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		helpRequestID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := helpservice.AcceptHelpRequest(r.Context(), helpRequestID, volunteerID); err != nil {
+			log.Error("failed to accept help request", "id", helpRequestID, "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewEscalateHelpHandler - автор помечает запрос срочным: следующая волна уходит сразу
+// и захватывает волонтёров вне их окон доступности
+func NewEscalateHelpHandler(log *slog.Logger, helpservice core.Help) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requesterID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		helpRequestID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := helpservice.EscalateHelpRequest(r.Context(), helpRequestID, requesterID); err != nil {
+			log.Error("failed to escalate help request", "id", helpRequestID, "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"time"
+)
+
+const (
+	defaultWaitTimeout = 25 * time.Second
+	maxWaitTimeout     = 60 * time.Second
+)
+
+// NewWaitHelpResponseHandler держит запрос, пока волонтёр не ответит на запрос помощи
+// или не истечёт timeout. На таймаут отвечает 204, клиент может повторить запрос.
+func NewWaitHelpResponseHandler(log *slog.Logger, events *core.EventHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		helpRequestID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		timeout := defaultWaitTimeout
+		if value := r.URL.Query().Get("timeout"); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil || parsed <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, core.ErrbadArguments.Error())
+				return
+			}
+			timeout = min(parsed, maxWaitTimeout)
+		}
+
+		ch, unsubscribe := events.Subscribe(userID)
+		defer unsubscribe()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		for {
+			select {
+			case event := <-ch:
+				if event.HelpRequestID != helpRequestID {
+					continue
+				}
+				writeJSON(log, w, event)
+				return
+			case <-timer.C:
+				w.WriteHeader(http.StatusNoContent)
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package rest
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"time"
+)
+
+// NewICEServersHandler отдаёт STUN и TURN серверы с временными учётными данными для звонка
+func NewICEServersHandler(log *slog.Logger, ice core.ICEConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		// учётные данные индивидуальны и быстро истекают, кэшировать их нельзя
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(log, w, ice.Servers(userID, time.Now()))
+	}
+}
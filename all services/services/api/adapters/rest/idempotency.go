@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"strconv"
+	"time"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBodySize   = 1 << 20
+)
+
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// NewIdempotencyMiddleware повторяет сохранённый ответ, если изменяющий запрос
+// пришёл повторно с тем же Idempotency-Key. Ключ действует в пределах пользователя, метода и пути,
+// поэтому middleware ставится только за NewAuthMiddleware. Пока запрос выполняется, ключ занят на lock;
+// ответы 5xx не сохраняются, чтобы клиент мог повторить запрос.
+func NewIdempotencyMiddleware(log *slog.Logger, store core.IdempotencyStore, ttl, lock time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("Idempotency-Key")
+			userID, ok := UserIDFromContext(r.Context())
+			if key == "" || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, "Idempotency-Key is too long")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+					fmt.Fprintf(w, "request body with Idempotency-Key must not exceed %d bytes", maxIdempotentBodySize)
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			storeKey := strconv.FormatInt(userID, 10) + " " + r.Method + " " + r.URL.Path + " " + key
+			fingerprint := sha256.Sum256(body)
+
+			saved, reserved, err := store.Reserve(r.Context(), storeKey, fingerprint[:], lock)
+			if err != nil {
+				log.Error("failed to reserve idempotency key", "error", err)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprint(w, "failed to check Idempotency-Key")
+				return
+			}
+			if !reserved {
+				switch {
+				case !bytes.Equal(saved.Fingerprint, fingerprint[:]):
+					w.WriteHeader(http.StatusUnprocessableEntity)
+					fmt.Fprint(w, "Idempotency-Key was used with a different request")
+				case !saved.Done:
+					w.WriteHeader(http.StatusConflict)
+					fmt.Fprint(w, "request with this Idempotency-Key is in progress")
+				default:
+					log.Debug("replaying idempotent response", "path", r.URL.Path)
+					for name, values := range saved.Header {
+						w.Header()[name] = values
+					}
+					w.Header().Set("Idempotent-Replayed", "true")
+					w.WriteHeader(saved.Status)
+					w.Write(saved.Body)
+				}
+				return
+			}
+
+			recorder := &recordingWriter{ResponseWriter: w}
+			next.ServeHTTP(recorder, r)
+
+			// клиент мог уйти, а ответ всё равно нужно сохранить
+			ctx := context.WithoutCancel(r.Context())
+			if recorder.status == 0 || recorder.status >= http.StatusInternalServerError {
+				if err := store.Release(ctx, storeKey); err != nil {
+					log.Error("failed to release idempotency key", "error", err)
+				}
+				return
+			}
+			response := core.IdempotentResponse{
+				Fingerprint: fingerprint[:],
+				Done:        true,
+				Status:      recorder.status,
+				Header:      w.Header().Clone(),
+				Body:        recorder.body.Bytes(),
+			}
+			if err := store.Complete(ctx, storeKey, response, ttl); err != nil {
+				log.Error("failed to save idempotent response", "error", err)
+			}
+		})
+	}
+}
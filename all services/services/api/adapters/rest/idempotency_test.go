@@ -0,0 +1,199 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"seeforme/api/core"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeIdempotencyStore - общее хранилище ключей в памяти; сроки не проверяются
+type fakeIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]core.IdempotentResponse
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{responses: map[string]core.IdempotentResponse{}}
+}
+
+func (s *fakeIdempotencyStore) Reserve(ctx context.Context, key string, fingerprint []byte, lock time.Duration) (core.IdempotentResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if saved, ok := s.responses[key]; ok {
+		return saved, false, nil
+	}
+	s.responses[key] = core.IdempotentResponse{Fingerprint: fingerprint}
+	return core.IdempotentResponse{}, true, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(ctx context.Context, key string, response core.IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = response
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.responses[key].Done {
+		delete(s.responses, key)
+	}
+	return nil
+}
+
+type idempotencyTest struct {
+	calls   atomic.Int32
+	handler http.Handler
+}
+
+// newIdempotencyTest - за middleware обработчик, который создаёт запрос и отвечает его номером
+func newIdempotencyTest(handle func(w http.ResponseWriter, r *http.Request)) *idempotencyTest {
+	it := &idempotencyTest{}
+	if handle == nil {
+		handle = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "/v1/help/1")
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id":1}`)
+		}
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	middleware := NewIdempotencyMiddleware(log, newFakeIdempotencyStore(), time.Hour, time.Minute)
+	it.handler = middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		it.calls.Add(1)
+		handle(w, r)
+	}))
+	return it
+}
+
+func (it *idempotencyTest) do(userID int64, key, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/help", strings.NewReader(body))
+	if key != "" {
+		r.Header.Set("Idempotency-Key", key)
+	}
+	if userID != 0 {
+		r = r.WithContext(context.WithValue(r.Context(), userIDKey, userID))
+	}
+	w := httptest.NewRecorder()
+	it.handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	it := newIdempotencyTest(nil)
+
+	first := it.do(1, "k1", `{"question":"?"}`)
+	second := it.do(1, "k1", `{"question":"?"}`)
+
+	if it.calls.Load() != 1 {
+		t.Fatalf("handler called %d times, want 1", it.calls.Load())
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("replayed %d %q, want %d %q", second.Code, second.Body, first.Code, first.Body)
+	}
+	if second.Header().Get("Location") != "/v1/help/1" || second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replayed headers = %v", second.Header())
+	}
+	if first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("first response marked as replayed")
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedWithDifferentBody(t *testing.T) {
+	it := newIdempotencyTest(nil)
+
+	it.do(1, "k1", `{"question":"first"}`)
+	w := it.do(1, "k1", `{"question":"second"}`)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+	if it.calls.Load() != 1 {
+		t.Fatalf("handler called %d times, want 1", it.calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsConcurrentRequest(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	it := newIdempotencyTest(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- it.do(1, "k1", "body") }()
+	<-started
+
+	if w := it.do(1, "k1", "body"); w.Code != http.StatusConflict {
+		t.Fatalf("status of concurrent request = %d, want %d", w.Code, http.StatusConflict)
+	}
+	close(release)
+	if w := <-done; w.Code != http.StatusCreated {
+		t.Fatalf("status of first request = %d, want %d", w.Code, http.StatusCreated)
+	}
+	if w := it.do(1, "k1", "body"); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("request after completion = %d %v, want replay", w.Code, w.Header())
+	}
+	if it.calls.Load() != 1 {
+		t.Fatalf("handler called %d times, want 1", it.calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsOversizedBody(t *testing.T) {
+	it := newIdempotencyTest(nil)
+
+	w := it.do(1, "k1", strings.Repeat("a", maxIdempotentBodySize+1))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	if it.calls.Load() != 0 {
+		t.Fatalf("handler called %d times, want 0", it.calls.Load())
+	}
+}
+
+func TestIdempotencyDoesNotCacheServerErrors(t *testing.T) {
+	var failed atomic.Bool
+	it := newIdempotencyTest(func(w http.ResponseWriter, r *http.Request) {
+		if failed.CompareAndSwap(false, true) {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	if w := it.do(1, "k1", "body"); w.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadGateway)
+	}
+	if w := it.do(1, "k1", "body"); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retry after 5xx = %d %v, want a new response", w.Code, w.Header())
+	}
+	if it.calls.Load() != 2 {
+		t.Fatalf("handler called %d times, want 2", it.calls.Load())
+	}
+}
+
+func TestIdempotencyKeysAreScopedToUser(t *testing.T) {
+	it := newIdempotencyTest(nil)
+
+	it.do(1, "k1", "body")
+	if w := it.do(2, "k1", "body"); w.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("another user got a replayed response")
+	}
+	// без ключа и без пользователя запросы не запоминаются
+	it.do(1, "", "body")
+	it.do(0, "k1", "body")
+
+	if it.calls.Load() != 4 {
+		t.Fatalf("handler called %d times, want 4", it.calls.Load())
+	}
+}
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+)
+
+func NewGetNotificationPreferencesHandler(log *slog.Logger, notifyservice core.Notify) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		preferences, err := notifyservice.GetPreferences(r.Context(), userID)
+		if err != nil {
+			log.Error("failed to get notification preferences", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, preferences)
+	}
+}
+
+// NewSetNotificationPreferencesHandler принимает JSON
+// {"channels": ["push", "sms"], "phone": "+79990000000", "email": "me@example.com"}
+func NewSetNotificationPreferencesHandler(log *slog.Logger, notifyservice core.Notify) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		var preferences core.NotificationPreferences
+		if err := json.NewDecoder(r.Body).Decode(&preferences); err != nil {
+			log.Error("failed to decode notification preferences", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := notifyservice.SetPreferences(r.Context(), userID, preferences); err != nil {
+			log.Error("failed to set notification preferences", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewAckNotificationHandler принимает подтверждение от приложения: {"status": "delivered"} или {"status": "opened"}.
+// Без тела запроса уведомление считается доставленным.
+func NewAckNotificationHandler(log *slog.Logger, notifyservice core.Notify) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		notificationID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		ack := struct {
+			Status string `json:"status"`
+		}{Status: "delivered"}
+		if err := json.NewDecoder(r.Body).Decode(&ack); err != nil && !errors.Is(err, io.EOF) {
+			log.Error("failed to decode notification ack", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := notifyservice.AckNotification(r.Context(), userID, notificationID, ack.Status); err != nil {
+			log.Error("failed to acknowledge notification", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
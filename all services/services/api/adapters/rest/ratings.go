@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+)
+
+func NewSubmitRatingHandler(log *slog.Logger, helpservice core.Help) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		helpRequestID, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		var rating core.Rating
+		if err := json.NewDecoder(r.Body).Decode(&rating); err != nil {
+			log.Error("failed to decode rating", "error", err)
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+		rating.HelpRequestID = helpRequestID
+		rating.RaterID = userID
+
+		if err := helpservice.SubmitRating(r.Context(), rating); err != nil {
+			log.Error("failed to submit rating", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"seeforme/api/core"
+	"strconv"
+)
+
+type ReportRequest struct {
+	UserID        int64  `json:"userId"`
+	HelpRequestID int64  `json:"helpRequestId"`
+	Reason        string `json:"reason"`
+	Comment       string `json:"comment"`
+}
+
+func NewCreateReportHandler(log *slog.Logger, userservice core.User) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		var req ReportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || (req.UserID <= 0 && req.HelpRequestID <= 0) {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		id, err := userservice.CreateReport(r.Context(), core.Report{
+			ReporterID:     userID,
+			ReportedUserID: req.UserID,
+			HelpRequestID:  req.HelpRequestID,
+			Reason:         req.Reason,
+			Comment:        req.Comment,
+		})
+		if err != nil {
+			log.Error("failed to create report", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(log, w, map[string]interface{}{
+			"id": id,
+		})
+	}
+}
+
+func NewAdminListReportsHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		reportedUserID, _ := strconv.ParseInt(query.Get("user_id"), 10, 64)
+
+		reports, total, err := admin.ListReports(r.Context(), core.ReportFilter{
+			Status:         query.Get("status"),
+			ReportedUserID: reportedUserID,
+			Limit:          limit,
+			Offset:         offset,
+		})
+		if err != nil {
+			log.Error("failed to list reports", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, map[string]interface{}{
+			"reports": reports,
+			"total":   total,
+			"limit":   limit,
+			"offset":  offset,
+		})
+	}
+}
+
+func NewAdminGetReportHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		report, err := admin.GetReport(r.Context(), id)
+		if err != nil {
+			log.Error("failed to get report", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(log, w, report)
+	}
+}
+
+func NewAdminUpdateReportHandler(log *slog.Logger, admin core.Admin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adminID, ok := UserIDFromContext(r.Context())
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, core.ErrUnauthorized.Error())
+			return
+		}
+
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+
+		status := r.FormValue("status")
+		if status == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, core.ErrbadArguments.Error())
+			return
+		}
+
+		if err := admin.UpdateReportStatus(r.Context(), id, status, adminID, r.FormValue("note")); err != nil {
+			log.Error("failed to update report", "error", err)
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -2,12 +2,18 @@ package user
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"seeforme/api/core"
 	userpb "seeforme/proto/user"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Client struct {
@@ -70,4 +76,321 @@ func (c *Client) GetStatistics(ctx context.Context) (int64, int64, error) {
 		return 0, 0, err
 	}
 	return response.GetVolunteersCount(), response.GetBlindCount(), nil
-}
\ No newline at end of file
+}
+
+func (c *Client) DeleteAccount(ctx context.Context, userID int64) error {
+	_, err := c.client.DeleteAccount(ctx, &userpb.DeleteAccountRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to delete account", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) ExportMyData(ctx context.Context, userID int64) ([]byte, error) {
+	response, err := c.client.ExportMyData(ctx, &userpb.ExportMyDataRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to export user data", "error", err)
+		return nil, err
+	}
+	return response.GetData(), nil
+}
+
+func (c *Client) ChangePassword(ctx context.Context, email string, oldPassword string, newPassword string) error {
+	_, err := c.client.ChangePassword(ctx, &userpb.ChangePasswordRequest{
+		Email:       email,
+		OldPassword: oldPassword,
+		NewPassword: newPassword,
+	})
+	if err != nil {
+		c.log.Error("failed to change password", "error", err)
+		return err
+	}
+	return nil
+}
+
+func toUserInfo(user *userpb.UserInfo) core.UserInfo {
+	info := core.UserInfo{
+		ID:                    user.GetId(),
+		Email:                 user.GetEmail(),
+		Role:                  user.GetRole(),
+		Suspended:             user.GetSuspended(),
+		SuspensionReason:      user.GetSuspensionReason(),
+		PasswordResetRequired: user.GetPasswordResetRequired(),
+		RatingAverage:         user.GetRatingAverage(),
+		RatingCount:           user.GetRatingCount(),
+		Languages:             user.GetLanguages(),
+		Timezone:              user.GetTimezone(),
+		CreatedAt:             user.GetCreatedAt().AsTime(),
+	}
+	if user.GetSuspendedUntil() != nil {
+		until := user.GetSuspendedUntil().AsTime()
+		info.SuspendedUntil = &until
+	}
+	return info
+}
+
+func (c *Client) ListUsers(ctx context.Context, filter core.UserFilter) ([]core.UserInfo, int64, error) {
+	response, err := c.client.ListUsers(ctx, &userpb.ListUsersRequest{
+		Query:  filter.Query,
+		Role:   filter.Role,
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+	})
+	if err != nil {
+		c.log.Error("failed to list users", "error", err)
+		return nil, 0, err
+	}
+
+	users := make([]core.UserInfo, 0, len(response.GetUsers()))
+	for _, user := range response.GetUsers() {
+		users = append(users, toUserInfo(user))
+	}
+	return users, response.GetTotal(), nil
+}
+
+func (c *Client) GetUser(ctx context.Context, userID int64) (core.UserInfo, error) {
+	response, err := c.client.GetUser(ctx, &userpb.GetUserRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to get user", "error", err)
+		return core.UserInfo{}, err
+	}
+	return toUserInfo(response), nil
+}
+
+func (c *Client) SuspendUser(ctx context.Context, userID int64, reason string, until *time.Time) error {
+	request := &userpb.SuspendUserRequest{UserId: userID, Reason: reason}
+	if until != nil {
+		request.Until = timestamppb.New(*until)
+	}
+	_, err := c.client.SuspendUser(ctx, request)
+	if err != nil {
+		c.log.Error("failed to suspend user", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) UnsuspendUser(ctx context.Context, userID int64) error {
+	_, err := c.client.UnsuspendUser(ctx, &userpb.UnsuspendUserRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to unsuspend user", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) ForcePasswordReset(ctx context.Context, userID int64) (string, error) {
+	response, err := c.client.ForcePasswordReset(ctx, &userpb.ForcePasswordResetRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to reset password", "error", err)
+		return "", err
+	}
+	return response.GetTemporaryPassword(), nil
+}
+
+func (c *Client) ChangeRole(ctx context.Context, userID int64, role string) error {
+	_, err := c.client.ChangeRole(ctx, &userpb.ChangeRoleRequest{UserId: userID, Role: role})
+	if err != nil {
+		c.log.Error("failed to change role", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) BlockUser(ctx context.Context, userID int64, blockedUserID int64) error {
+	_, err := c.client.BlockUser(ctx, &userpb.BlockUserRequest{UserId: userID, BlockedUserId: blockedUserID})
+	if err != nil {
+		c.log.Error("failed to block user", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) UnblockUser(ctx context.Context, userID int64, blockedUserID int64) error {
+	_, err := c.client.UnblockUser(ctx, &userpb.UnblockUserRequest{UserId: userID, BlockedUserId: blockedUserID})
+	if err != nil {
+		c.log.Error("failed to unblock user", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) ListBlocked(ctx context.Context, userID int64) ([]core.BlockedUser, error) {
+	response, err := c.client.ListBlocked(ctx, &userpb.ListBlockedRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to list blocked users", "error", err)
+		return nil, err
+	}
+
+	blocked := make([]core.BlockedUser, 0, len(response.GetUsers()))
+	for _, b := range response.GetUsers() {
+		blocked = append(blocked, core.BlockedUser{
+			UserID:    b.GetUserId(),
+			CreatedAt: b.GetCreatedAt().AsTime(),
+		})
+	}
+	return blocked, nil
+}
+
+func toReport(report *userpb.Report) core.Report {
+	return core.Report{
+		ID:             report.GetId(),
+		ReporterID:     report.GetReporterId(),
+		ReportedUserID: report.GetReportedUserId(),
+		HelpRequestID:  report.GetHelpRequestId(),
+		Reason:         report.GetReason(),
+		Comment:        report.GetComment(),
+		Status:         report.GetStatus(),
+		HandledBy:      report.GetHandledBy(),
+		ResolutionNote: report.GetResolutionNote(),
+		CreatedAt:      report.GetCreatedAt().AsTime(),
+		UpdatedAt:      report.GetUpdatedAt().AsTime(),
+	}
+}
+
+func (c *Client) CreateReport(ctx context.Context, report core.Report) (int64, error) {
+	response, err := c.client.CreateReport(ctx, &userpb.CreateReportRequest{
+		ReporterId:     report.ReporterID,
+		ReportedUserId: report.ReportedUserID,
+		HelpRequestId:  report.HelpRequestID,
+		Reason:         report.Reason,
+		Comment:        report.Comment,
+	})
+	if err != nil {
+		c.log.Error("failed to create report", "error", err)
+		return 0, err
+	}
+	return response.GetId(), nil
+}
+
+func (c *Client) ListReports(ctx context.Context, filter core.ReportFilter) ([]core.Report, int64, error) {
+	response, err := c.client.ListReports(ctx, &userpb.ListReportsRequest{
+		Status:         filter.Status,
+		ReportedUserId: filter.ReportedUserID,
+		Limit:          int32(filter.Limit),
+		Offset:         int32(filter.Offset),
+	})
+	if err != nil {
+		c.log.Error("failed to list reports", "error", err)
+		return nil, 0, err
+	}
+
+	reports := make([]core.Report, 0, len(response.GetReports()))
+	for _, report := range response.GetReports() {
+		reports = append(reports, toReport(report))
+	}
+	return reports, response.GetTotal(), nil
+}
+
+func (c *Client) GetReport(ctx context.Context, id int64) (core.Report, error) {
+	response, err := c.client.GetReport(ctx, &userpb.GetReportRequest{Id: id})
+	if err != nil {
+		c.log.Error("failed to get report", "error", err)
+		return core.Report{}, err
+	}
+	return toReport(response), nil
+}
+
+func (c *Client) UpdateReportStatus(ctx context.Context, id int64, status string, adminID int64, note string) error {
+	_, err := c.client.UpdateReportStatus(ctx, &userpb.UpdateReportStatusRequest{
+		Id:      id,
+		Status:  status,
+		AdminId: adminID,
+		Note:    note,
+	})
+	if err != nil {
+		c.log.Error("failed to update report", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) UpdateProfile(ctx context.Context, userID int64, profile core.Profile) error {
+	_, err := c.client.UpdateProfile(ctx, &userpb.UpdateProfileRequest{
+		UserId:    userID,
+		Languages: profile.Languages,
+		Timezone:  profile.Timezone,
+	})
+	if err != nil {
+		c.log.Error("failed to update profile", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) GetAvailability(ctx context.Context, userID int64) (core.Availability, error) {
+	response, err := c.client.GetAvailability(ctx, &userpb.GetAvailabilityRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to get availability", "error", err)
+		return core.Availability{}, err
+	}
+
+	availability := core.Availability{Windows: []core.AvailabilityWindow{}}
+	for _, w := range response.GetWindows() {
+		availability.Windows = append(availability.Windows, core.AvailabilityWindow{
+			Weekday: int(w.GetWeekday()),
+			Start:   formatMinute(w.GetStartMinute()),
+			End:     formatMinute(w.GetEndMinute()),
+		})
+	}
+	if response.GetDoNotDisturbUntil() != nil {
+		until := response.GetDoNotDisturbUntil().AsTime()
+		availability.DoNotDisturbUntil = &until
+	}
+	return availability, nil
+}
+
+func (c *Client) SetAvailability(ctx context.Context, userID int64, windows []core.AvailabilityWindow) error {
+	request := &userpb.SetAvailabilityRequest{UserId: userID}
+	for _, w := range windows {
+		start, ok := parseMinute(w.Start)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "invalid window start")
+		}
+		end, ok := parseMinute(w.End)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "invalid window end")
+		}
+		request.Windows = append(request.Windows, &userpb.AvailabilityWindow{
+			Weekday:     int32(w.Weekday),
+			StartMinute: start,
+			EndMinute:   end,
+		})
+	}
+
+	if _, err := c.client.SetAvailability(ctx, request); err != nil {
+		c.log.Error("failed to set availability", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) SetDoNotDisturb(ctx context.Context, userID int64, until *time.Time) error {
+	request := &userpb.SetDoNotDisturbRequest{UserId: userID}
+	if until != nil {
+		request.Until = timestamppb.New(*until)
+	}
+
+	if _, err := c.client.SetDoNotDisturb(ctx, request); err != nil {
+		c.log.Error("failed to set do not disturb", "error", err)
+		return err
+	}
+	return nil
+}
+
+func formatMinute(minute int32) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// parseMinute разбирает "15:04" в минуты от начала дня, "24:00" допустимо как конец дня
+func parseMinute(value string) (int32, bool) {
+	var hour, minute int32
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil {
+		return 0, false
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour*60+minute > 24*60 {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
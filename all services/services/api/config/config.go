@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"os"
 	"strings"
 	"time"
 
@@ -13,16 +14,47 @@ type HTTPConfig struct {
 	Timeout time.Duration `yaml:"timeout" env:"API_TIMEOUT" env-default:"5s"`
 }
 
+// KafkaConfig - подписчики SSE живут в памяти реплики, поэтому каждая реплика читает
+// все ответы в своей группе GroupID-InstanceID. InstanceID по умолчанию - имя хоста.
 type KafkaConfig struct {
-	Brokers     []string `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092"`
-	HelpTopic   string   `yaml:"help_topic" env:"KAFKA_HELP_TOPIC" env-default:"help-request"`
+	Brokers       []string        `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092"`
+	ResponseTopic string          `yaml:"response_topic" env:"KAFKA_RESPONSE_TOPIC" env-default:"help-response"`
+	GroupID       string          `yaml:"group_id" env:"KAFKA_GROUP_ID" env-default:"api"`
+	InstanceID    string          `yaml:"instance_id" env:"INSTANCE_ID"`
+	RetryDelays   []time.Duration `yaml:"retry_delays" env:"KAFKA_RETRY_DELAYS" env-default:"10s,1m,10m"`
+}
+
+// IdempotencyConfig - ответы хранятся в общей базе, повтор находит их на любой реплике.
+// LockTTL - сколько ключ занят выполняющимся запросом, должен быть больше таймаута запроса.
+type IdempotencyConfig struct {
+	TTL     time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	LockTTL time.Duration `yaml:"lock_ttl" env:"IDEMPOTENCY_LOCK_TTL" env-default:"1m"`
+}
+
+// EventsConfig - поток событий SSE. TicketTTL - сколько одноразовый билет ждёт подключения.
+type EventsConfig struct {
+	TicketTTL time.Duration `yaml:"ticket_ttl" env:"STREAM_TICKET_TTL" env-default:"30s"`
+}
+
+// ICEConfig - серверы для WebRTC. TURNSecret - static-auth-secret из настроек coturn.
+type ICEConfig struct {
+	STUNURLs   []string      `yaml:"stun_urls" env:"STUN_URLS" env-default:"stun:stun.l.google.com:19302"`
+	TURNURLs   []string      `yaml:"turn_urls" env:"TURN_URLS"`
+	TURNSecret string        `yaml:"turn_secret" env:"TURN_SECRET"`
+	TTL        time.Duration `yaml:"ttl" env:"TURN_TTL" env-default:"1h"`
 }
 
 type Config struct {
-	LogLevel          string     `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
-	HTTPConfig        HTTPConfig `yaml:"api_server"`
-	UserAddress       string     `yaml:"user_address" env:"USER_ADDRESS" env-default:"words:81"`
-	KafkaConfig       KafkaConfig `yaml:"kafka"`
+	LogLevel          string            `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	HTTPConfig        HTTPConfig        `yaml:"api_server"`
+	UserAddress       string            `yaml:"user_address" env:"USER_ADDRESS" env-default:"words:81"`
+	HelpAddress       string            `yaml:"help_address" env:"HELP_ADDRESS" env-default:"localhost:83"`
+	NotifyAddress     string            `yaml:"notify_address" env:"NOTIFY_ADDRESS" env-default:"localhost:84"`
+	DBAddress         string            `yaml:"db_address" env:"DB_ADDRESS" env-default:"localhost:82"`
+	KafkaConfig       KafkaConfig       `yaml:"kafka"`
+	IdempotencyConfig IdempotencyConfig `yaml:"idempotency"`
+	EventsConfig      EventsConfig      `yaml:"events"`
+	ICEConfig         ICEConfig         `yaml:"ice"`
 }
 
 func MustLoad(configPath string) Config {
@@ -37,6 +69,22 @@ func MustLoad(configPath string) Config {
 	if len(cfg.KafkaConfig.Brokers) == 1 && strings.Contains(cfg.KafkaConfig.Brokers[0], ",") {
 		cfg.KafkaConfig.Brokers = strings.Split(cfg.KafkaConfig.Brokers[0], ",")
 	}
+	if len(cfg.ICEConfig.TURNURLs) == 1 && strings.Contains(cfg.ICEConfig.TURNURLs[0], ",") {
+		cfg.ICEConfig.TURNURLs = strings.Split(cfg.ICEConfig.TURNURLs[0], ",")
+	}
+	if cfg.KafkaConfig.InstanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Fatalf("cannot get hostname for kafka instance id: %s", err)
+		}
+		cfg.KafkaConfig.InstanceID = hostname
+	}
+	if cfg.ICEConfig.TTL <= 0 {
+		log.Fatalf("ice ttl must be positive")
+	}
+	if cfg.EventsConfig.TicketTTL <= 0 {
+		log.Fatalf("stream ticket ttl must be positive")
+	}
 
 	return cfg
-}
\ No newline at end of file
+}
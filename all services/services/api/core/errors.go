@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrbadArguments = errors.New("bad arguments")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
 )
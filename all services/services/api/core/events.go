@@ -0,0 +1,72 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Типы событий жизненного цикла запроса помощи, которые получает его автор
+const (
+	EventVolunteerFound      = "volunteer_found"
+	EventVolunteerConnecting = "volunteer_connecting"
+	EventRequestCancelled    = "request_cancelled"
+	EventRequestExpired      = "request_expired"
+)
+
+type Event struct {
+	Type          string    `json:"type"`
+	HelpRequestID int64     `json:"helpRequestId"`
+	VolunteerID   int64     `json:"volunteerId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// eventBuffer - сколько событий может ждать медленного подписчика, дальше они теряются
+const eventBuffer = 16
+
+// EventHub раздаёт события подписчикам конкретного пользователя
+// в пределах одного экземпляра api
+type EventHub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[chan Event]struct{}
+}
+
+func NewEventHub() *EventHub {
+	return &EventHub{subscribers: make(map[int64]map[chan Event]struct{})}
+}
+
+// Subscribe возвращает канал событий пользователя и функцию отписки
+func (h *EventHub) Subscribe(userID int64) (<-chan Event, func()) {
+	ch := make(chan Event, eventBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan Event]struct{})
+	}
+	h.subscribers[userID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[userID], ch)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+}
+
+// Publish возвращает число подписчиков, получивших событие
+func (h *EventHub) Publish(userID int64, event Event) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delivered := 0
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- event:
+			delivered++
+		default:
+		}
+	}
+	return delivered
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"strconv"
+	"time"
+)
+
+// ICEConfig - STUN и TURN серверы для WebRTC. TURN-серверы (coturn с use-auth-secret)
+// принимают временные учётные данные, подписанные общим секретом, см. TURN REST API.
+type ICEConfig struct {
+	STUNURLs []string
+	TURNURLs []string
+	Secret   string
+	TTL      time.Duration
+}
+
+// ICEServer - элемент RTCConfiguration.iceServers
+type ICEServer struct {
+	URLs       []string `json:"urls"`
+	Username   string   `json:"username,omitempty"`
+	Credential string   `json:"credential,omitempty"`
+}
+
+type ICEServers struct {
+	ICEServers []ICEServer `json:"iceServers"`
+	// TTL - через сколько секунд учётные данные TURN перестанут действовать
+	TTL int64 `json:"ttl"`
+}
+
+// Servers выдаёт серверы для пользователя. Имя пользователя TURN - "<истечение в unix>:<id>",
+// пароль - base64(HMAC-SHA1(secret, имя)). Без секрета или адресов TURN отдаются только STUN.
+func (c ICEConfig) Servers(userID int64, now time.Time) ICEServers {
+	servers := ICEServers{ICEServers: []ICEServer{}}
+	if len(c.STUNURLs) > 0 {
+		servers.ICEServers = append(servers.ICEServers, ICEServer{URLs: c.STUNURLs})
+	}
+	if len(c.TURNURLs) == 0 || c.Secret == "" {
+		return servers
+	}
+
+	username := strconv.FormatInt(now.Add(c.TTL).Unix(), 10) + ":" + strconv.FormatInt(userID, 10)
+	mac := hmac.New(sha1.New, []byte(c.Secret))
+	mac.Write([]byte(username))
+
+	servers.ICEServers = append(servers.ICEServers, ICEServer{
+		URLs:       c.TURNURLs,
+		Username:   username,
+		Credential: base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+	})
+	servers.TTL = int64(c.TTL.Seconds())
+	return servers
+}
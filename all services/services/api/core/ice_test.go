@@ -0,0 +1,50 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestICEServersSignsTURNCredentials(t *testing.T) {
+	config := ICEConfig{
+		STUNURLs: []string{"stun:stun.example.com:3478"},
+		TURNURLs: []string{"turn:turn.example.com:3478", "turns:turn.example.com:5349"},
+		Secret:   "north-secret",
+		TTL:      time.Hour,
+	}
+
+	// пароль посчитан независимо: base64(HMAC-SHA1("north-secret", "1740834000:42"))
+	want := ICEServers{
+		ICEServers: []ICEServer{
+			{URLs: config.STUNURLs},
+			{URLs: config.TURNURLs, Username: "1740834000:42", Credential: "hRspYpCo8ORhJRbPJRJ68GsUcYs="},
+		},
+		TTL: 3600,
+	}
+	if got := config.Servers(42, start); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Servers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestICEServersWithoutTURNSecret(t *testing.T) {
+	stun := ICEServer{URLs: []string{"stun:stun.example.com:3478"}}
+	tests := []struct {
+		name   string
+		config ICEConfig
+		want   []ICEServer
+	}{
+		{"no secret", ICEConfig{STUNURLs: stun.URLs, TURNURLs: []string{"turn:turn.example.com"}, TTL: time.Hour}, []ICEServer{stun}},
+		{"no turn urls", ICEConfig{STUNURLs: stun.URLs, Secret: "north-secret", TTL: time.Hour}, []ICEServer{stun}},
+		{"nothing configured", ICEConfig{TTL: time.Hour}, []ICEServer{}},
+	}
+
+	for _, tt := range tests {
+		got := tt.config.Servers(42, start)
+		if !reflect.DeepEqual(got, ICEServers{ICEServers: tt.want}) {
+			t.Errorf("%s: Servers() = %+v, want only %+v without ttl", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package core
+
+import "time"
+
+const RoleAdmin = "admin"
+
+type UserInfo struct {
+	ID                    int64      `json:"id"`
+	Email                 string     `json:"email"`
+	Role                  string     `json:"role"`
+	Suspended             bool       `json:"suspended"`
+	SuspensionReason      string     `json:"suspensionReason,omitempty"`
+	SuspendedUntil        *time.Time `json:"suspendedUntil,omitempty"`
+	PasswordResetRequired bool       `json:"passwordResetRequired"`
+	RatingAverage         float64    `json:"ratingAverage"`
+	RatingCount           int64      `json:"ratingCount"`
+	Languages             []string   `json:"languages,omitempty"`
+	Timezone              string     `json:"timezone,omitempty"`
+	CreatedAt             time.Time  `json:"createdAt"`
+}
+
+// Profile - языки и часовой пояс, по которым подбираются волонтёры
+type Profile struct {
+	Languages []string `json:"languages"`
+	Timezone  string   `json:"timezone"`
+}
+
+// Device - устройство для push-уведомлений; токен FCM приложение присылает при регистрации
+type Device struct {
+	ID         int64     `json:"id"`
+	Token      string    `json:"token"`
+	Platform   string    `json:"platform"`
+	AppVersion string    `json:"appVersion"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// NotificationPreferences - в каком порядке пробовать каналы: "push", "sms", "email"
+type NotificationPreferences struct {
+	Channels []string `json:"channels"`
+	Phone    string   `json:"phone,omitempty"`
+	Email    string   `json:"email,omitempty"`
+}
+
+// AvailabilityWindow - еженедельное окно в часовом поясе пользователя, время в формате "15:04".
+// Конец "24:00" означает конец дня.
+type AvailabilityWindow struct {
+	Weekday int    `json:"weekday"` // 0 - воскресенье
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+type Availability struct {
+	Windows           []AvailabilityWindow `json:"windows"`
+	DoNotDisturbUntil *time.Time           `json:"doNotDisturbUntil,omitempty"`
+}
+
+type ScoreFactor struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	Reason string  `json:"reason"`
+}
+
+// MatchScore - оценка кандидата в одной из волн рассылки запроса
+type MatchScore struct {
+	Wave        int           `json:"wave"`
+	VolunteerID int64         `json:"volunteerId"`
+	Score       float64       `json:"score"`
+	Offered     bool          `json:"offered"`
+	Excluded    bool          `json:"excluded"`
+	Factors     []ScoreFactor `json:"factors"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
+// VolunteerStats - нагрузка волонтёра; Limit - почему ему сейчас не предлагают запросы
+type VolunteerStats struct {
+	CallsToday      int        `json:"callsToday"`
+	LastCallEndedAt *time.Time `json:"lastCallEndedAt,omitempty"`
+	OnCall          bool       `json:"onCall"`
+	Limit           string     `json:"limit,omitempty"`
+}
+
+type Rating struct {
+	HelpRequestID int64    `json:"-"`
+	RaterID       int64    `json:"-"`
+	Score         int      `json:"score"`
+	Tags          []string `json:"tags"`
+	Comment       string   `json:"comment"`
+}
+
+type BlockedUser struct {
+	UserID    int64     `json:"userId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type UserFilter struct {
+	Query  string
+	Role   string
+	Limit  int
+	Offset int
+}
+
+type Report struct {
+	ID             int64     `json:"id"`
+	ReporterID     int64     `json:"reporterId"`
+	ReportedUserID int64     `json:"userId"`
+	HelpRequestID  int64     `json:"helpRequestId,omitempty"`
+	Reason         string    `json:"reason"`
+	Comment        string    `json:"comment,omitempty"`
+	Status         string    `json:"status"`
+	HandledBy      int64     `json:"handledBy,omitempty"`
+	ResolutionNote string    `json:"resolutionNote,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type ReportFilter struct {
+	Status         string
+	ReportedUserID int64
+	Limit          int
+	Offset         int
+}
+
+// IdempotentResponse - ответ на запрос с Idempotency-Key. Пока запрос выполняется, Done = false.
+type IdempotentResponse struct {
+	// Fingerprint - хэш тела запроса: с тем же ключом, но другим телом запрос отклоняется
+	Fingerprint []byte
+	Done        bool
+	Status      int
+	Header      map[string][]string
+	Body        []byte
+}
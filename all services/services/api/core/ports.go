@@ -1,10 +1,74 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type User interface {
 	Login(ctx context.Context, email string, password string) (bool, string, error)
 	Register(ctx context.Context, email string, password string, role bool) (int64, error)
 	CheckJWT(ctx context.Context, userID int64, token string) error
 	GetStatistics(ctx context.Context) (int64, int64, error)
-}
\ No newline at end of file
+	DeleteAccount(ctx context.Context, userID int64) error
+	ExportMyData(ctx context.Context, userID int64) ([]byte, error)
+	ChangePassword(ctx context.Context, email string, oldPassword string, newPassword string) error
+	BlockUser(ctx context.Context, userID int64, blockedUserID int64) error
+	UnblockUser(ctx context.Context, userID int64, blockedUserID int64) error
+	ListBlocked(ctx context.Context, userID int64) ([]BlockedUser, error)
+	CreateReport(ctx context.Context, report Report) (int64, error)
+	UpdateProfile(ctx context.Context, userID int64, profile Profile) error
+	GetAvailability(ctx context.Context, userID int64) (Availability, error)
+	SetAvailability(ctx context.Context, userID int64, windows []AvailabilityWindow) error
+	SetDoNotDisturb(ctx context.Context, userID int64, until *time.Time) error
+}
+
+type Admin interface {
+	ListUsers(ctx context.Context, filter UserFilter) ([]UserInfo, int64, error)
+	GetUser(ctx context.Context, userID int64) (UserInfo, error)
+	SuspendUser(ctx context.Context, userID int64, reason string, until *time.Time) error
+	UnsuspendUser(ctx context.Context, userID int64) error
+	ForcePasswordReset(ctx context.Context, userID int64) (string, error)
+	ChangeRole(ctx context.Context, userID int64, role string) error
+	ListReports(ctx context.Context, filter ReportFilter) ([]Report, int64, error)
+	GetReport(ctx context.Context, id int64) (Report, error)
+	UpdateReportStatus(ctx context.Context, id int64, status string, adminID int64, note string) error
+}
+
+type Help interface {
+	CreateHelpRequest(ctx context.Context, requesterID int64, question string) (int64, error)
+	CancelHelpRequest(ctx context.Context, id, requesterID int64) error
+	AcceptHelpRequest(ctx context.Context, id, volunteerID int64) error
+	EscalateHelpRequest(ctx context.Context, id, requesterID int64) error
+	GetDispatchLog(ctx context.Context, id int64) ([]MatchScore, error)
+	GetVolunteerStats(ctx context.Context, volunteerID int64) (VolunteerStats, error)
+	SubmitRating(ctx context.Context, rating Rating) error
+}
+
+type Notify interface {
+	RegisterDevice(ctx context.Context, userID int64, device Device) (Device, error)
+	UnregisterDevice(ctx context.Context, userID, id int64) error
+	ListDevices(ctx context.Context, userID int64) ([]Device, error)
+	GetPreferences(ctx context.Context, userID int64) (NotificationPreferences, error)
+	SetPreferences(ctx context.Context, userID int64, preferences NotificationPreferences) error
+	AckNotification(ctx context.Context, userID, id int64, status string) error
+}
+
+// IdempotencyStore - ответы на запросы с Idempotency-Key в хранилище, общем для всех реплик api
+type IdempotencyStore interface {
+	// Reserve занимает свободный или просроченный ключ на время lock и возвращает true.
+	// Если ключ уже занят, возвращает сохранённую по нему запись и false.
+	Reserve(ctx context.Context, key string, fingerprint []byte, lock time.Duration) (IdempotentResponse, bool, error)
+	// Complete сохраняет ответ на занятый ключ на время ttl
+	Complete(ctx context.Context, key string, response IdempotentResponse, ttl time.Duration) error
+	// Release освобождает ключ, чтобы запрос можно было повторить
+	Release(ctx context.Context, key string) error
+}
+
+// StreamTickets - одноразовые билеты на поток событий. EventSource в браузере не умеет
+// выставлять заголовки, а JWT в адресе попал бы в логи прокси.
+type StreamTickets interface {
+	SaveStreamTicket(ctx context.Context, ticket string, userID int64, ttl time.Duration) error
+	// RedeemStreamTicket гасит действующий билет и возвращает его владельца; false - билета нет или он истёк
+	RedeemStreamTicket(ctx context.Context, ticket string) (int64, bool, error)
+}
@@ -5,13 +5,22 @@ import (
 	"errors"
 	"flag"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"seeforme/api/adapters/db"
+	"seeforme/api/adapters/help"
 	"seeforme/api/adapters/kafka"
+	"seeforme/api/adapters/notify"
 	"seeforme/api/adapters/rest"
 	"seeforme/api/adapters/user"
 	"seeforme/api/config"
+	"seeforme/api/core"
+	eventspkg "seeforme/pkg/events"
+	"seeforme/pkg/kafkaretry"
+
+	kafkago "github.com/segmentio/kafka-go"
 )
 
 func main() {
@@ -31,29 +40,138 @@ func main() {
 		os.Exit(1)
 	}
 
-	kafkaClient, err := kafka.NewClient(cfg.KafkaConfig.Brokers, cfg.KafkaConfig.HelpTopic, log)
+	helpservice, err := help.NewClient(cfg.HelpAddress, log)
+	if err != nil {
+		log.Error("failed to init help adapter", "error", err)
+		os.Exit(1)
+	}
+
+	notifyservice, err := notify.NewClient(cfg.NotifyAddress, log)
+	if err != nil {
+		log.Error("failed to init notify adapter", "error", err)
+		os.Exit(1)
+	}
+
+	storage, err := db.New(log, cfg.DBAddress)
 	if err != nil {
-		log.Error("failed to init kafka client", "error", err)
+		log.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+
+	if err := storage.Migrate(); err != nil {
+		log.Error("failed to migrate db", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go storage.Run(ctx)
+
+	events := core.NewEventHub()
+
+	consumer := kafka.NewConsumer(kafkaretry.Config{
+		Brokers: cfg.KafkaConfig.Brokers,
+		Topic:   cfg.KafkaConfig.ResponseTopic,
+		// своя группа у каждой реплики: ответ должен дойти до той, где открыт SSE автора запроса.
+		// Новой группе история не нужна - читаем только свежие ответы.
+		GroupID:     cfg.KafkaConfig.GroupID + "-" + cfg.KafkaConfig.InstanceID,
+		StartOffset: kafkago.LastOffset,
+		Delays:      cfg.KafkaConfig.RetryDelays,
+		// retry-топики общие для реплик: повтор чужого сбоя отсекается по id сообщения
+		TopicGroup: cfg.KafkaConfig.GroupID,
+
+		MessageIDHeader: eventspkg.HeaderMessageID,
+	}, events, log)
+	if err := consumer.CheckTopics(ctx); err != nil {
+		log.Error("failed to check kafka topics", "error", err)
 		os.Exit(1)
 	}
-	defer kafkaClient.Close()
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		consumer.Run(ctx)
+	}()
+	defer func() {
+		stop()
+		<-consumerDone
+		if err := consumer.Close(); err != nil {
+			log.Error("failed to close kafka consumer", "error", err)
+		}
+	}()
+
+	auth := rest.NewAuthMiddleware(log, userservice)
+
+	ice := core.ICEConfig{
+		STUNURLs: cfg.ICEConfig.STUNURLs,
+		TURNURLs: cfg.ICEConfig.TURNURLs,
+		Secret:   cfg.ICEConfig.TURNSecret,
+		TTL:      cfg.ICEConfig.TTL,
+	}
+	if len(ice.TURNURLs) == 0 || ice.Secret == "" {
+		log.Warn("TURN is not configured, clients behind symmetric NAT will not be able to call")
+	}
+
+	// Idempotency-Key действует только на изменяющих запросах авторизованного пользователя:
+	// ответы /login и /register с токенами не должны храниться и повторяться
+	idempotent := rest.NewIdempotencyMiddleware(log, storage, cfg.IdempotencyConfig.TTL, cfg.IdempotencyConfig.LockTTL)
+	mutating := func(h http.Handler) http.Handler { return auth(idempotent(h)) }
 
 	mux := http.NewServeMux()
 	mux.Handle("POST /login", rest.NewLoginHandler(log, userservice))
 	mux.Handle("POST /register", rest.NewRegisterHandler(log, userservice))
 	mux.Handle("POST /checkjwt", rest.NewCheckJWTHandler(log, userservice))
-	mux.Handle("POST /help", rest.NewHelpHandler(log, kafkaClient))
+	mux.Handle("POST /help", mutating(rest.NewHelpHandler(log, helpservice)))
+	mux.Handle("POST /v1/events/ticket", auth(rest.NewStreamTicketHandler(log, storage, cfg.EventsConfig.TicketTTL)))
+	mux.Handle("GET /v1/events", rest.NewStreamAuthMiddleware(log, storage, auth)(rest.NewEventsHandler(log, events)))
+	mux.Handle("DELETE /v1/help/{id}", mutating(rest.NewCancelHelpHandler(log, helpservice)))
+	mux.Handle("POST /v1/help/{id}/accept", mutating(rest.NewAcceptHelpHandler(log, helpservice)))
+	mux.Handle("POST /v1/help/{id}/escalate", mutating(rest.NewEscalateHelpHandler(log, helpservice)))
+	mux.Handle("GET /v1/help/{id}/response", auth(rest.NewWaitHelpResponseHandler(log, events)))
+	mux.Handle("POST /v1/help/{id}/rating", mutating(rest.NewSubmitRatingHandler(log, helpservice)))
 	mux.Handle("GET /statistics", rest.NewGetStatisticsHandler(log, userservice))
+	mux.Handle("DELETE /v1/account", mutating(rest.NewDeleteAccountHandler(log, userservice)))
+	mux.Handle("GET /v1/account/export", auth(rest.NewExportMyDataHandler(log, userservice)))
+	mux.Handle("POST /v1/account/password", rest.NewChangePasswordHandler(log, userservice))
+	mux.Handle("PUT /v1/account/profile", mutating(rest.NewUpdateProfileHandler(log, userservice)))
+	mux.Handle("GET /v1/account/availability", auth(rest.NewGetAvailabilityHandler(log, userservice)))
+	mux.Handle("PUT /v1/account/availability", mutating(rest.NewSetAvailabilityHandler(log, userservice)))
+	mux.Handle("PUT /v1/account/dnd", mutating(rest.NewSetDoNotDisturbHandler(log, userservice)))
+	mux.Handle("DELETE /v1/account/dnd", mutating(rest.NewClearDoNotDisturbHandler(log, userservice)))
+	mux.Handle("GET /v1/devices", auth(rest.NewListDevicesHandler(log, notifyservice)))
+	mux.Handle("POST /v1/devices", mutating(rest.NewRegisterDeviceHandler(log, notifyservice)))
+	mux.Handle("DELETE /v1/devices/{id}", mutating(rest.NewUnregisterDeviceHandler(log, notifyservice)))
+	mux.Handle("GET /v1/notifications/preferences", auth(rest.NewGetNotificationPreferencesHandler(log, notifyservice)))
+	mux.Handle("PUT /v1/notifications/preferences", mutating(rest.NewSetNotificationPreferencesHandler(log, notifyservice)))
+	mux.Handle("POST /v1/notifications/{id}/ack", mutating(rest.NewAckNotificationHandler(log, notifyservice)))
+	mux.Handle("GET /v1/ice-servers", auth(rest.NewICEServersHandler(log, ice)))
+	mux.Handle("GET /v1/blocks", auth(rest.NewListBlockedHandler(log, userservice)))
+	mux.Handle("POST /v1/blocks", mutating(rest.NewBlockUserHandler(log, userservice)))
+	mux.Handle("DELETE /v1/blocks/{id}", mutating(rest.NewUnblockUserHandler(log, userservice)))
+	mux.Handle("POST /v1/reports", mutating(rest.NewCreateReportHandler(log, userservice)))
+
+	admin := func(h http.Handler) http.Handler { return auth(rest.NewAdminMiddleware(log)(h)) }
+	adminMutating := func(h http.Handler) http.Handler { return admin(idempotent(h)) }
+	mux.Handle("GET /v1/admin/users", admin(rest.NewAdminListUsersHandler(log, userservice)))
+	mux.Handle("GET /v1/admin/users/{id}", admin(rest.NewAdminGetUserHandler(log, userservice)))
+	mux.Handle("POST /v1/admin/users/{id}/suspend", adminMutating(rest.NewAdminSuspendHandler(log, userservice)))
+	mux.Handle("POST /v1/admin/users/{id}/unsuspend", adminMutating(rest.NewAdminUnsuspendHandler(log, userservice)))
+	mux.Handle("POST /v1/admin/users/{id}/reset-password", adminMutating(rest.NewAdminResetPasswordHandler(log, userservice)))
+	mux.Handle("PUT /v1/admin/users/{id}/role", adminMutating(rest.NewAdminChangeRoleHandler(log, userservice)))
+	mux.Handle("GET /v1/admin/reports", admin(rest.NewAdminListReportsHandler(log, userservice)))
+	mux.Handle("GET /v1/admin/reports/{id}", admin(rest.NewAdminGetReportHandler(log, userservice)))
+	mux.Handle("PUT /v1/admin/reports/{id}/status", adminMutating(rest.NewAdminUpdateReportHandler(log, userservice)))
+	mux.Handle("GET /v1/admin/help/{id}/dispatch", admin(rest.NewAdminDispatchLogHandler(log, helpservice)))
+	mux.Handle("GET /v1/admin/volunteers/{id}/stats", admin(rest.NewAdminVolunteerStatsHandler(log, helpservice)))
 
 	server := http.Server{
-		Addr:    cfg.HTTPConfig.Address,
+		Addr:        cfg.HTTPConfig.Address,
 		ReadTimeout: cfg.HTTPConfig.Timeout,
 		Handler:     mux,
+		// потоки событий живут долго: отменяем их контекст при остановке, иначе Shutdown их ждёт
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
-	defer stop()
-
 	go func() {
 		<-ctx.Done()
 		log.Debug("shutting down server")
@@ -0,0 +1,49 @@
+// dlqreplay возвращает сообщения из dead-letter топика группы в её первый retry-топик.
+//
+//	go run ./dlqreplay -brokers kafka:29092 -topic help-response -consumer-group api -limit 100
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"seeforme/pkg/kafkaretry"
+	"strings"
+	"time"
+)
+
+func main() {
+	var (
+		brokers string
+		opts    kafkaretry.ReplayOptions
+	)
+	flag.StringVar(&brokers, "brokers", "localhost:9092", "comma separated kafka brokers")
+	flag.StringVar(&opts.Topic, "topic", "", "original topic whose dead letter topic is replayed")
+	flag.StringVar(&opts.ConsumerGroup, "consumer-group", "", "consumer group whose dead letter topic is replayed")
+	flag.StringVar(&opts.GroupID, "group", "dlq-replay", "consumer group that remembers replayed messages")
+	flag.IntVar(&opts.Limit, "limit", 0, "maximum number of messages to replay, 0 - all")
+	flag.BoolVar(&opts.DryRun, "dry-run", false, "only print messages, do not replay or commit them")
+	flag.DurationVar(&opts.Idle, "idle", 5*time.Second, "stop after no new messages arrived for this long")
+	flag.Parse()
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if opts.Topic == "" || opts.ConsumerGroup == "" {
+		log.Error("topic and consumer group are required")
+		os.Exit(2)
+	}
+	opts.Brokers = strings.Split(brokers, ",")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	replayed, err := kafkaretry.Replay(ctx, opts, log)
+	if err != nil {
+		log.Error("replay failed", "replayed", replayed, "error", err)
+		os.Exit(1)
+	}
+
+	log.Info("replay finished", "topic", kafkaretry.DeadLetterTopic(opts.Topic, opts.ConsumerGroup), "replayed", replayed, "dry_run", opts.DryRun)
+}
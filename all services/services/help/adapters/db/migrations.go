@@ -0,0 +1,82 @@
+package db
+
+import (
+	"embed"
+	"errors"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationsTable отделяет версии миграций help от миграций user в общей базе
+const migrationsTable = "help_schema_migrations"
+
+// remapVersion - миграция, которая переводит id волонтёров по volunteer_ids сервиса пользователей
+const remapVersion = 15
+
+var errVolunteerIDsMissing = errors.New("user service has not saved volunteer id mapping yet")
+
+func (db *DB) Migrate() error {
+	db.log.Info("running migration")
+	files, err := iofs.New(migrationFiles, "migrations")
+	if err != nil {
+		db.log.Error("failed to create migration source", "error", err)
+		return err
+	}
+	driver, err := pgx.WithInstance(db.conn.DB, &pgx.Config{MigrationsTable: migrationsTable})
+	if err != nil {
+		db.log.Error("failed to create driver", "error", err)
+		return err
+	}
+	m, err := migrate.NewWithInstance("iofs", files, "pgx", driver)
+	if err != nil {
+		db.log.Error("failed to init migration instance", "error", err)
+		return err
+	}
+	if err := db.checkVolunteerIDs(m); err != nil {
+		db.log.Error("cannot remap volunteer ids yet", "error", err)
+		return err
+	}
+	db.log.Info("applying migrations")
+	err = m.Up()
+	if err != nil {
+		if err != migrate.ErrNoChange {
+			db.log.Error("migration failed", "error", err)
+			return err
+		}
+		db.log.Info("no new migrations to apply")
+	} else {
+		db.log.Info("migrations applied successfully")
+	}
+	db.log.Debug("migration finished")
+	return nil
+}
+
+// checkVolunteerIDs не даёт применить remapVersion, пока сервис пользователей не объединил
+// blind и volunteer и не сохранил соответствие id: иначе перевод id пропустился бы навсегда.
+// Сервис помощи останавливается и повторяет проверку после перезапуска.
+func (db *DB) checkVolunteerIDs(m *migrate.Migrate) error {
+	version, _, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return err
+	}
+	if err == nil && version >= remapVersion {
+		return nil
+	}
+
+	// в новой базе пользователей ещё нет, и переводить нечего
+	var ready bool
+	query := `SELECT to_regclass('volunteer_ids') IS NOT NULL OR (to_regclass('users') IS NULL AND to_regclass('volunteer') IS NULL)`
+	if err := db.conn.Get(&ready, query); err != nil {
+		return err
+	}
+	if !ready {
+		return errVolunteerIDsMissing
+	}
+
+	return nil
+}
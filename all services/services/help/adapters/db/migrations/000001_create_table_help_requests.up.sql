@@ -0,0 +1,9 @@
+CREATE TABLE help_requests (
+	id BIGSERIAL PRIMARY KEY,
+	requester_id BIGINT NOT NULL,
+	question TEXT NOT NULL DEFAULT '',
+	status VARCHAR(32) NOT NULL DEFAULT 'pending',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX help_requests_requester_id_idx ON help_requests (requester_id);
@@ -0,0 +1,10 @@
+CREATE TABLE calls (
+	id BIGSERIAL PRIMARY KEY,
+	help_request_id BIGINT NOT NULL REFERENCES help_requests (id) ON DELETE CASCADE,
+	blind_id BIGINT NOT NULL,
+	volunteer_id BIGINT,
+	started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	ended_at TIMESTAMPTZ
+);
+
+CREATE INDEX calls_volunteer_id_idx ON calls (volunteer_id);
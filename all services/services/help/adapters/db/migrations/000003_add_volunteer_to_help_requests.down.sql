@@ -0,0 +1 @@
+ALTER TABLE help_requests DROP COLUMN IF EXISTS volunteer_id;
@@ -0,0 +1 @@
+ALTER TABLE help_requests ADD COLUMN volunteer_id BIGINT;
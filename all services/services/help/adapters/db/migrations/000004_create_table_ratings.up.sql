@@ -0,0 +1,14 @@
+CREATE TABLE ratings (
+	id BIGSERIAL PRIMARY KEY,
+	help_request_id BIGINT NOT NULL REFERENCES help_requests (id) ON DELETE CASCADE,
+	rater_id BIGINT NOT NULL,
+	ratee_id BIGINT NOT NULL,
+	score SMALLINT NOT NULL CHECK (score BETWEEN 1 AND 5),
+	tags TEXT NOT NULL DEFAULT '',
+	comment TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	UNIQUE (help_request_id, rater_id)
+);
+
+CREATE INDEX ratings_rater_id_idx ON ratings (rater_id);
+CREATE INDEX ratings_ratee_id_idx ON ratings (ratee_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS help_requests_pending_created_at_idx;
@@ -0,0 +1 @@
+CREATE INDEX help_requests_pending_created_at_idx ON help_requests (created_at) WHERE status = 'pending';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS help_offers;
+DROP INDEX IF EXISTS help_requests_next_wave_at_idx;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS next_wave_at;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS wave;
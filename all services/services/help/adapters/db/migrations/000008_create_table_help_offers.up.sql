@@ -0,0 +1,17 @@
+ALTER TABLE help_requests ADD COLUMN wave INT NOT NULL DEFAULT 0;
+ALTER TABLE help_requests ADD COLUMN next_wave_at TIMESTAMPTZ DEFAULT NOW();
+
+-- запросы, созданные до рассылки волнами, уже разосланы всем через топик FCM
+UPDATE help_requests SET next_wave_at = NULL;
+
+CREATE INDEX help_requests_next_wave_at_idx ON help_requests (next_wave_at) WHERE status = 'pending';
+
+CREATE TABLE help_offers (
+	help_request_id BIGINT NOT NULL REFERENCES help_requests (id) ON DELETE CASCADE,
+	volunteer_id BIGINT NOT NULL,
+	wave INT NOT NULL,
+	offered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (help_request_id, volunteer_id)
+);
+
+CREATE INDEX help_offers_volunteer_id_idx ON help_offers (volunteer_id);
@@ -0,0 +1,14 @@
+CREATE TABLE match_scores (
+	id BIGSERIAL PRIMARY KEY,
+	help_request_id BIGINT NOT NULL REFERENCES help_requests (id) ON DELETE CASCADE,
+	wave INT NOT NULL,
+	volunteer_id BIGINT NOT NULL,
+	score DOUBLE PRECISION NOT NULL,
+	offered BOOLEAN NOT NULL DEFAULT FALSE,
+	excluded BOOLEAN NOT NULL DEFAULT FALSE,
+	factors JSONB NOT NULL DEFAULT '[]',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX match_scores_help_request_id_idx ON match_scores (help_request_id);
+CREATE INDEX match_scores_volunteer_id_idx ON match_scores (volunteer_id);
@@ -0,0 +1 @@
+ALTER TABLE help_requests ADD COLUMN urgent BOOLEAN NOT NULL DEFAULT FALSE;
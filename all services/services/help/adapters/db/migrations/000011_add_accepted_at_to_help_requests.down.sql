@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS calls_volunteer_id_started_at_idx;
+DROP INDEX IF EXISTS help_requests_volunteer_id_idx;
+
+ALTER TABLE help_requests DROP COLUMN IF EXISTS accepted_at;
@@ -0,0 +1,4 @@
+ALTER TABLE help_requests ADD COLUMN accepted_at TIMESTAMPTZ;
+
+CREATE INDEX help_requests_volunteer_id_idx ON help_requests (volunteer_id);
+CREATE INDEX calls_volunteer_id_started_at_idx ON calls (volunteer_id, started_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS help_requests_ack_deadline_idx;
+
+ALTER TABLE help_requests DROP COLUMN IF EXISTS ack_deadline;
+
+ALTER TABLE help_offers DROP COLUMN IF EXISTS opened_at;
+ALTER TABLE help_offers DROP COLUMN IF EXISTS acked_at;
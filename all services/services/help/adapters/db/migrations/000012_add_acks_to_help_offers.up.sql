@@ -0,0 +1,7 @@
+ALTER TABLE help_offers ADD COLUMN acked_at TIMESTAMPTZ;
+ALTER TABLE help_offers ADD COLUMN opened_at TIMESTAMPTZ;
+
+-- если до ack_deadline никто из волны не подтвердил доставку, следующая волна уходит раньше
+ALTER TABLE help_requests ADD COLUMN ack_deadline TIMESTAMPTZ;
+
+CREATE INDEX help_requests_ack_deadline_idx ON help_requests (ack_deadline) WHERE status = 'pending';
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS outbox_pending_key_idx ON outbox (key, id) WHERE sent_at IS NULL;
@@ -0,0 +1,13 @@
+-- волонтёры, чей рейтинг в сервисе пользователей нужно пересчитать по сохранённым оценкам.
+-- version растёт с каждой новой оценкой: строка удаляется, только если за время синхронизации новых не было
+CREATE TABLE rating_syncs (
+	volunteer_id BIGINT PRIMARY KEY,
+	version BIGINT NOT NULL DEFAULT 1,
+	queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	locked_until TIMESTAMPTZ
+);
+
+-- до этой миграции рейтинг копился прибавлением и мог разойтись с оценками, пересчитываем всех
+INSERT INTO rating_syncs (volunteer_id)
+SELECT DISTINCT r.ratee_id FROM ratings r
+JOIN help_requests h ON h.id = r.help_request_id AND h.volunteer_id = r.ratee_id;
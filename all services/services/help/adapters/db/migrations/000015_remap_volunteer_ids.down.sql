@@ -0,0 +1,21 @@
+DO $$
+BEGIN
+	IF to_regclass('volunteer_ids') IS NULL THEN
+		RETURN;
+	END IF;
+
+	UPDATE ratings r SET ratee_id = m.old_id
+	FROM help_requests h, volunteer_ids m
+	WHERE h.id = r.help_request_id AND r.ratee_id = h.volunteer_id AND m.new_id = r.ratee_id;
+
+	UPDATE ratings r SET rater_id = m.old_id
+	FROM help_requests h, volunteer_ids m
+	WHERE h.id = r.help_request_id AND r.rater_id = h.volunteer_id AND m.new_id = r.rater_id;
+
+	UPDATE help_offers o SET volunteer_id = -m.old_id FROM volunteer_ids m WHERE m.new_id = o.volunteer_id;
+	UPDATE help_offers SET volunteer_id = -volunteer_id WHERE volunteer_id < 0;
+
+	UPDATE match_scores s SET volunteer_id = m.old_id FROM volunteer_ids m WHERE m.new_id = s.volunteer_id;
+	UPDATE calls c SET volunteer_id = m.old_id FROM volunteer_ids m WHERE m.new_id = c.volunteer_id;
+	UPDATE help_requests h SET volunteer_id = m.old_id FROM volunteer_ids m WHERE m.new_id = h.volunteer_id;
+END $$;
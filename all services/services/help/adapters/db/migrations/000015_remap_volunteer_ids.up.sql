@@ -0,0 +1,33 @@
+-- Сервис пользователей при объединении blind и volunteer выдал волонтёрам новые id,
+-- соответствие старым лежит в его таблице volunteer_ids. Переводим на новые id волонтёров здесь.
+-- Без volunteer_ids переводить нечего: сервис пользователей ещё не запускался.
+DO $$
+BEGIN
+	IF to_regclass('volunteer_ids') IS NULL THEN
+		RETURN;
+	END IF;
+
+	-- волонтёр в оценке - тот, кто принял запрос, поэтому оценки переводим до help_requests
+	UPDATE ratings r SET ratee_id = m.new_id
+	FROM help_requests h, volunteer_ids m
+	WHERE h.id = r.help_request_id AND r.ratee_id = h.volunteer_id AND m.old_id = r.ratee_id;
+
+	UPDATE ratings r SET rater_id = m.new_id
+	FROM help_requests h, volunteer_ids m
+	WHERE h.id = r.help_request_id AND r.rater_id = h.volunteer_id AND m.old_id = r.rater_id;
+
+	-- новый id волонтёра может совпасть со старым id другого волонтёра той же волны:
+	-- чтобы не нарушить первичный ключ, переводим через отрицательные id
+	UPDATE help_offers o SET volunteer_id = -m.new_id FROM volunteer_ids m WHERE m.old_id = o.volunteer_id;
+	UPDATE help_offers SET volunteer_id = -volunteer_id WHERE volunteer_id < 0;
+
+	UPDATE match_scores s SET volunteer_id = m.new_id FROM volunteer_ids m WHERE m.old_id = s.volunteer_id;
+	UPDATE calls c SET volunteer_id = m.new_id FROM volunteer_ids m WHERE m.old_id = c.volunteer_id;
+	UPDATE help_requests h SET volunteer_id = m.new_id FROM volunteer_ids m WHERE m.old_id = h.volunteer_id;
+
+	-- рейтинг уходил в сервис пользователей под старыми id, пересчитываем всех заново
+	DELETE FROM rating_syncs;
+	INSERT INTO rating_syncs (volunteer_id)
+	SELECT DISTINCT r.ratee_id FROM ratings r
+	JOIN help_requests h ON h.id = r.help_request_id AND h.volunteer_id = r.ratee_id;
+END $$;
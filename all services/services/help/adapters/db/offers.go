@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"seeforme/help/core"
+	"slices"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ClaimDueWaves откладывает выбранные запросы на lease: если рассылка упадёт, волна повторится.
+// Запрос берётся и до next_wave_at, если истёк ack_deadline, а доставку текущей волны никто не подтвердил.
+func (d *DB) ClaimDueWaves(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]core.HelpRequest, error) {
+	query := `
+		UPDATE help_requests SET next_wave_at = $1::timestamptz + $3 * INTERVAL '1 millisecond', ack_deadline = NULL
+		WHERE id IN (
+			SELECT id FROM help_requests r
+			WHERE status = $4 AND (
+				next_wave_at <= $1
+				OR ack_deadline <= $1 AND NOT EXISTS (
+					SELECT 1 FROM help_offers o
+					WHERE o.help_request_id = r.id AND o.wave = r.wave AND o.acked_at IS NOT NULL
+				)
+			)
+			ORDER BY LEAST(next_wave_at, ack_deadline)
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + helpRequestColumns
+
+	requests := []core.HelpRequest{}
+	if err := d.conn.SelectContext(ctx, &requests, query, now, limit, lease.Milliseconds(), core.StatusPending); err != nil {
+		d.log.Error("failed to claim help requests for dispatch", "error", err)
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (d *DB) GetOfferedVolunteers(ctx context.Context, helpRequestID int64) ([]int64, error) {
+	ids := []int64{}
+	query := `SELECT volunteer_id FROM help_offers WHERE help_request_id = $1`
+	if err := d.conn.SelectContext(ctx, &ids, query, helpRequestID); err != nil {
+		d.log.Error("failed to get offers", "help_request", helpRequestID, "error", err)
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (d *DB) SaveOffers(ctx context.Context, request core.HelpRequest, volunteerIDs []int64, matches []core.Match, nextWaveAt time.Time, ackDeadline *time.Time, event func(core.HelpRequest, []int64) (core.OutboxMessage, error)) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	wave := request.Wave
+	if len(volunteerIDs) > 0 {
+		wave++
+	}
+
+	// номер волны защищает от повторной рассылки, если lease истёк, пока мы работали
+	query := `UPDATE help_requests SET wave = $3, next_wave_at = $4, ack_deadline = $6 WHERE id = $1 AND wave = $2 AND status = $5`
+	result, err := tx.ExecContext(ctx, query, request.ID, request.Wave, wave, nextWaveAt, core.StatusPending, ackDeadline)
+	if err != nil {
+		d.log.Error("failed to schedule next wave", "id", request.ID, "error", err)
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return core.ErrNotPending
+	}
+
+	if err := saveMatchScores(ctx, tx, request.ID, request.Wave+1, volunteerIDs, matches); err != nil {
+		d.log.Error("failed to save match scores", "id", request.ID, "error", err)
+		return err
+	}
+
+	if len(volunteerIDs) == 0 {
+		return tx.Commit()
+	}
+
+	for _, volunteerID := range volunteerIDs {
+		query := `INSERT INTO help_offers (help_request_id, volunteer_id, wave) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, request.ID, volunteerID, wave); err != nil {
+			d.log.Error("failed to save offer", "id", request.ID, "volunteer", volunteerID, "error", err)
+			return err
+		}
+	}
+
+	request.Wave = wave
+	message, err := event(request, volunteerIDs)
+	if err != nil {
+		d.log.Error("failed to build offer event", "id", request.ID, "error", err)
+		return err
+	}
+	if err := saveOutbox(ctx, tx, message); err != nil {
+		d.log.Error("failed to save outbox message", "id", request.ID, "error", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveMatchScores(ctx context.Context, tx *sqlx.Tx, helpRequestID int64, wave int, offered []int64, matches []core.Match) error {
+	query := `
+		INSERT INTO match_scores (help_request_id, wave, volunteer_id, score, offered, excluded, factors)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	for _, match := range matches {
+		factors, err := json.Marshal(match.Factors)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, query, helpRequestID, wave, match.VolunteerID, match.Score,
+			slices.Contains(offered, match.VolunteerID), match.Excluded, factors)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DB) GetVolunteerStats(ctx context.Context, volunteerIDs []int64, assignedSince, callsSince, acceptedSince time.Time) (map[int64]core.VolunteerStats, error) {
+	var rows []struct {
+		VolunteerID int64 `db:"volunteer_id"`
+		core.VolunteerStats
+	}
+	query := `
+		SELECT v.id AS volunteer_id,
+			(SELECT COUNT(*) FROM help_requests WHERE volunteer_id = v.id AND created_at >= $2) AS recent_assignments,
+			(SELECT COUNT(*) FROM calls WHERE volunteer_id = v.id AND started_at >= $3) AS calls_today,
+			(SELECT MAX(ended_at) FROM calls WHERE volunteer_id = v.id) AS last_call_ended_at,
+			EXISTS (
+				SELECT 1 FROM help_requests WHERE volunteer_id = v.id AND status = $5 AND accepted_at >= $4
+			) AS on_call
+		FROM UNNEST($1::BIGINT[]) AS v (id)`
+	err := d.conn.SelectContext(ctx, &rows, query, volunteerIDs, assignedSince, callsSince, acceptedSince, core.StatusAccepted)
+	if err != nil {
+		d.log.Error("failed to get volunteer stats", "error", err)
+		return nil, err
+	}
+
+	stats := make(map[int64]core.VolunteerStats, len(rows))
+	for _, row := range rows {
+		stats[row.VolunteerID] = row.VolunteerStats
+	}
+	return stats, nil
+}
+
+func (d *DB) GetMatchScores(ctx context.Context, helpRequestID int64) ([]core.MatchScore, error) {
+	var rows []struct {
+		core.MatchScore
+		Factors []byte `db:"factors"`
+	}
+	query := `
+		SELECT help_request_id, wave, volunteer_id, score, offered, excluded, factors, created_at
+		FROM match_scores WHERE help_request_id = $1 ORDER BY id`
+	if err := d.conn.SelectContext(ctx, &rows, query, helpRequestID); err != nil {
+		d.log.Error("failed to get match scores", "help_request", helpRequestID, "error", err)
+		return nil, err
+	}
+
+	scores := make([]core.MatchScore, 0, len(rows))
+	for _, row := range rows {
+		score := row.MatchScore
+		if err := json.Unmarshal(row.Factors, &score.Factors); err != nil {
+			d.log.Error("failed to decode score factors", "help_request", helpRequestID, "error", err)
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+	return scores, nil
+}
+
+func (d *DB) EscalateHelpRequest(ctx context.Context, id int64) error {
+	query := `UPDATE help_requests SET urgent = TRUE, next_wave_at = NOW() WHERE id = $1 AND status = $2`
+	result, err := d.conn.ExecContext(ctx, query, id, core.StatusPending)
+	if err != nil {
+		d.log.Error("failed to escalate help request", "id", id, "error", err)
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return core.ErrNotPending
+	}
+
+	return nil
+}
+
+func (d *DB) HasOffer(ctx context.Context, helpRequestID, volunteerID int64) (bool, error) {
+	var offered bool
+	query := `SELECT EXISTS (SELECT 1 FROM help_offers WHERE help_request_id = $1 AND volunteer_id = $2)`
+	if err := d.conn.GetContext(ctx, &offered, query, helpRequestID, volunteerID); err != nil {
+		d.log.Error("failed to check offer", "help_request", helpRequestID, "volunteer", volunteerID, "error", err)
+		return false, err
+	}
+
+	return offered, nil
+}
+
+func (d *DB) AcknowledgeOffer(ctx context.Context, helpRequestID, volunteerID int64, opened bool) error {
+	query := `
+		UPDATE help_offers SET acked_at = COALESCE(acked_at, NOW()),
+			opened_at = CASE WHEN $3 THEN COALESCE(opened_at, NOW()) ELSE opened_at END
+		WHERE help_request_id = $1 AND volunteer_id = $2`
+	result, err := d.conn.ExecContext(ctx, query, helpRequestID, volunteerID, opened)
+	if err != nil {
+		d.log.Error("failed to acknowledge offer", "help_request", helpRequestID, "volunteer", volunteerID, "error", err)
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return core.ErrNotOffered
+	}
+
+	return nil
+}
+
+func (d *DB) AcceptHelpRequest(ctx context.Context, id, volunteerID int64, events func(core.HelpRequest) ([]core.OutboxMessage, error)) (core.HelpRequest, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return core.HelpRequest{}, err
+	}
+	defer tx.Rollback()
+
+	var request core.HelpRequest
+	query := `
+		UPDATE help_requests SET status = $3, volunteer_id = $2, next_wave_at = NULL, accepted_at = NOW()
+		WHERE id = $1 AND status = $4
+		RETURNING ` + helpRequestColumns
+	if err := tx.GetContext(ctx, &request, query, id, volunteerID, core.StatusAccepted, core.StatusPending); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.HelpRequest{}, core.ErrNotPending
+		}
+		d.log.Error("failed to accept help request", "id", id, "error", err)
+		return core.HelpRequest{}, err
+	}
+
+	if err := saveEvents(ctx, tx, request, events); err != nil {
+		d.log.Error("failed to save outbox messages", "id", id, "error", err)
+		return core.HelpRequest{}, err
+	}
+
+	return request, tx.Commit()
+}
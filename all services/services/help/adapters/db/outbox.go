@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"seeforme/help/core"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func saveOutbox(ctx context.Context, tx *sqlx.Tx, message core.OutboxMessage) error {
+	query := `INSERT INTO outbox (message_id, topic, key, payload) VALUES ($1, $2, $3, $4)`
+	_, err := tx.ExecContext(ctx, query, message.MessageID, message.Topic, message.Key, message.Payload)
+	return err
+}
+
+func (d *DB) SaveEvent(ctx context.Context, message core.OutboxMessage) error {
+	query := `INSERT INTO outbox (message_id, topic, key, payload) VALUES ($1, $2, $3, $4)`
+	if _, err := d.conn.ExecContext(ctx, query, message.MessageID, message.Topic, message.Key, message.Payload); err != nil {
+		d.log.Error("failed to save outbox message", "topic", message.Topic, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// ClaimOutbox забирает готовые к отправке сообщения на время lease.
+// SKIP LOCKED и locked_until позволяют запускать несколько relay одновременно.
+// Сообщение не забирается, пока более раннее сообщение с тем же ключом ждёт повтора
+// или отправляется другим relay: так сохраняется порядок внутри ключа.
+func (d *DB) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]core.OutboxMessage, error) {
+	query := `
+		UPDATE outbox SET locked_until = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT o.id FROM outbox o
+			WHERE o.sent_at IS NULL AND o.next_attempt_at <= NOW() AND (o.locked_until IS NULL OR o.locked_until < NOW())
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox earlier
+					WHERE earlier.key = o.key AND earlier.id < o.id AND earlier.sent_at IS NULL
+						AND (earlier.next_attempt_at > NOW() OR earlier.locked_until >= NOW())
+				)
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, message_id, topic, key, payload, attempts`
+
+	messages := []core.OutboxMessage{}
+	if err := d.conn.SelectContext(ctx, &messages, query, limit, lease.Milliseconds()); err != nil {
+		d.log.Error("failed to claim outbox messages", "error", err)
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (d *DB) MarkOutboxSent(ctx context.Context, id int64) error {
+	query := `UPDATE outbox SET sent_at = NOW(), locked_until = NULL WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id); err != nil {
+		d.log.Error("failed to mark outbox message sent", "id", id, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) MarkOutboxFailed(ctx context.Context, id int64, nextAttempt time.Time, reason string) error {
+	query := `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $3, next_attempt_at = $2, locked_until = NULL
+		WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, nextAttempt, reason); err != nil {
+		d.log.Error("failed to mark outbox message failed", "id", id, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseOutbox снимает lease, не засчитывая попытку
+func (d *DB) ReleaseOutbox(ctx context.Context, id int64) error {
+	if _, err := d.conn.ExecContext(ctx, `UPDATE outbox SET locked_until = NULL WHERE id = $1`, id); err != nil {
+		d.log.Error("failed to release outbox message", "id", id, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) DeleteSentOutbox(ctx context.Context, before time.Time) error {
+	if _, err := d.conn.ExecContext(ctx, `DELETE FROM outbox WHERE sent_at < $1`, before); err != nil {
+		d.log.Error("failed to delete sent outbox messages", "error", err)
+		return err
+	}
+
+	return nil
+}
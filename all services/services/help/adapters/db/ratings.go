@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"seeforme/help/core"
+	"time"
+)
+
+const (
+	queueRatingSyncQuery = `INSERT INTO rating_syncs (volunteer_id)`
+	onRatingSyncConflict = `
+		ON CONFLICT (volunteer_id) DO UPDATE SET version = rating_syncs.version + 1, queued_at = NOW()`
+)
+
+// ClaimRatingSyncs забирает волонтёров из очереди пересчёта на время lease
+// и сразу считает их рейтинг по сохранённым оценкам
+func (d *DB) ClaimRatingSyncs(ctx context.Context, limit int, lease time.Duration) ([]core.RatingSync, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE rating_syncs SET locked_until = NOW() + $2 * INTERVAL '1 millisecond'
+			WHERE volunteer_id IN (
+				SELECT volunteer_id FROM rating_syncs
+				WHERE locked_until IS NULL OR locked_until < NOW()
+				ORDER BY queued_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING volunteer_id, version
+		)
+		SELECT c.volunteer_id, c.version,
+			COALESCE(SUM(r.score), 0) AS rating_sum, COUNT(r.id) AS rating_count
+		FROM claimed c
+		LEFT JOIN ratings r ON r.ratee_id = c.volunteer_id
+			AND EXISTS (SELECT 1 FROM help_requests h WHERE h.id = r.help_request_id AND h.volunteer_id = r.ratee_id)
+		GROUP BY c.volunteer_id, c.version`
+
+	syncs := []core.RatingSync{}
+	if err := d.conn.SelectContext(ctx, &syncs, query, limit, lease.Milliseconds()); err != nil {
+		d.log.Error("failed to claim rating syncs", "error", err)
+		return nil, err
+	}
+
+	return syncs, nil
+}
+
+// CompleteRatingSync убирает волонтёра из очереди, если после version новых оценок не было.
+// Иначе снимает lease, и рейтинг пересчитается ещё раз.
+func (d *DB) CompleteRatingSync(ctx context.Context, volunteerID, version int64) error {
+	query := `DELETE FROM rating_syncs WHERE volunteer_id = $1 AND version = $2`
+	result, err := d.conn.ExecContext(ctx, query, volunteerID, version)
+	if err != nil {
+		d.log.Error("failed to complete rating sync", "volunteer", volunteerID, "error", err)
+		return err
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows > 0 {
+		return nil
+	}
+
+	if _, err := d.conn.ExecContext(ctx, `UPDATE rating_syncs SET locked_until = NULL WHERE volunteer_id = $1`, volunteerID); err != nil {
+		d.log.Error("failed to release rating sync", "volunteer", volunteerID, "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"seeforme/help/core"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+const helpRequestColumns = `id, requester_id, question, status, created_at, volunteer_id, wave, urgent`
+
+// uniqueViolation - код ошибки postgres при нарушении уникальности
+const uniqueViolation = "23505"
+
+type DB struct {
+	log  *slog.Logger
+	conn *sqlx.DB
+}
+
+func New(log *slog.Logger, address string) (*DB, error) {
+	db, err := sqlx.Connect("pgx", address)
+	if err != nil {
+		log.Error("connection problem", "address", address, "error", err)
+		return nil, err
+	}
+
+	return &DB{
+		log:  log,
+		conn: db,
+	}, nil
+}
+
+func (d *DB) SaveHelpRequest(ctx context.Context, request core.HelpRequest, event func(core.HelpRequest) (core.OutboxMessage, error)) (int64, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO help_requests (requester_id, question, status) VALUES ($1, $2, $3) RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, query, request.RequesterID, request.Question, request.Status).Scan(&request.ID, &request.CreatedAt)
+	if err != nil {
+		d.log.Error("failed to save help request", "error", err)
+		return 0, core.ErrSaveHelpRequest
+	}
+
+	message, err := event(request)
+	if err != nil {
+		d.log.Error("failed to build help request event", "id", request.ID, "error", err)
+		return 0, core.ErrSaveHelpRequest
+	}
+	if err := saveOutbox(ctx, tx, message); err != nil {
+		d.log.Error("failed to save outbox message", "id", request.ID, "error", err)
+		return 0, core.ErrSaveHelpRequest
+	}
+
+	return request.ID, tx.Commit()
+}
+
+func (d *DB) GetHelpRequestsByUser(ctx context.Context, userID int64) ([]core.HelpRequest, error) {
+	var requests []core.HelpRequest
+	query := `SELECT ` + helpRequestColumns + ` FROM help_requests WHERE requester_id = $1 OR volunteer_id = $1 ORDER BY created_at`
+	if err := d.conn.SelectContext(ctx, &requests, query, userID); err != nil {
+		d.log.Error("failed to get help requests", "user", userID, "error", err)
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+func (d *DB) GetCallsByUser(ctx context.Context, userID int64) ([]core.Call, error) {
+	var calls []core.Call
+	query := `
+		SELECT id, help_request_id, blind_id, volunteer_id, started_at, ended_at
+		FROM calls WHERE blind_id = $1 OR volunteer_id = $1 ORDER BY started_at`
+	if err := d.conn.SelectContext(ctx, &calls, query, userID); err != nil {
+		d.log.Error("failed to get calls", "user", userID, "error", err)
+		return nil, err
+	}
+
+	return calls, nil
+}
+
+// DeleteUserData удаляет запросы пользователя вместе со звонками по ним,
+// а в чужих запросах и звонках обезличивает его участие
+func (d *DB) DeleteUserData(ctx context.Context, userID int64) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// оценки пользователя и оценки по его запросам удаляются, рейтинг этих волонтёров пересчитывается
+	query := queueRatingSyncQuery + `
+		SELECT DISTINCT r.ratee_id FROM ratings r
+		JOIN help_requests h ON h.id = r.help_request_id AND h.volunteer_id = r.ratee_id
+		WHERE r.ratee_id <> $1 AND (r.rater_id = $1 OR h.requester_id = $1)` + onRatingSyncConflict
+	if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+		d.log.Error("failed to queue rating syncs", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rating_syncs WHERE volunteer_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete rating sync", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM help_requests WHERE requester_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete help requests", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE calls SET volunteer_id = NULL WHERE volunteer_id = $1`, userID); err != nil {
+		d.log.Error("failed to anonymize calls", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE help_requests SET volunteer_id = NULL WHERE volunteer_id = $1`, userID); err != nil {
+		d.log.Error("failed to anonymize help requests", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM ratings WHERE rater_id = $1 OR ratee_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete ratings", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM help_offers WHERE volunteer_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete offers", "user", userID, "error", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM match_scores WHERE volunteer_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete match scores", "user", userID, "error", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (d *DB) GetHelpRequest(ctx context.Context, id int64) (core.HelpRequest, error) {
+	var request core.HelpRequest
+	query := `SELECT ` + helpRequestColumns + ` FROM help_requests WHERE id = $1`
+	if err := d.conn.GetContext(ctx, &request, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.HelpRequest{}, core.ErrNotFound
+		}
+		d.log.Error("failed to get help request", "id", id, "error", err)
+		return core.HelpRequest{}, err
+	}
+
+	return request, nil
+}
+
+func (d *DB) SaveCall(ctx context.Context, call core.Call) (int64, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	query := `
+		INSERT INTO calls (help_request_id, blind_id, volunteer_id, started_at, ended_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err = tx.QueryRowContext(ctx, query, call.HelpRequestID, call.BlindID, call.VolunteerID, call.StartedAt, call.EndedAt).Scan(&id)
+	if err != nil {
+		d.log.Error("failed to save call", "error", err)
+		return 0, err
+	}
+
+	// закрыть можно только принятый запрос: отменённый, истёкший или уже закрытый мог смениться после проверки
+	query = `UPDATE help_requests SET status = $2 WHERE id = $1 AND status = $3 AND volunteer_id = $4`
+	result, err := tx.ExecContext(ctx, query, call.HelpRequestID, core.StatusCompleted, core.StatusAccepted, call.VolunteerID)
+	if err != nil {
+		d.log.Error("failed to complete help request", "id", call.HelpRequestID, "error", err)
+		return 0, err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return 0, core.ErrNotAccepted
+	}
+
+	return id, tx.Commit()
+}
+
+func (d *DB) SaveRating(ctx context.Context, rating core.Rating, syncRatee bool) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO ratings (help_request_id, rater_id, ratee_id, score, tags, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = tx.ExecContext(ctx, query, rating.HelpRequestID, rating.RaterID, rating.RateeID,
+		rating.Score, strings.Join(rating.Tags, ","), rating.Comment)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return core.ErrAlreadyRated
+		}
+		d.log.Error("failed to save rating", "error", err)
+		return err
+	}
+
+	if syncRatee {
+		if _, err := tx.ExecContext(ctx, queueRatingSyncQuery+` VALUES ($1)`+onRatingSyncConflict, rating.RateeID); err != nil {
+			d.log.Error("failed to queue rating sync", "volunteer", rating.RateeID, "error", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (d *DB) GetRatingsByUser(ctx context.Context, userID int64) ([]core.Rating, error) {
+	var rows []struct {
+		core.Rating
+		Tags string `db:"tags"`
+	}
+	query := `
+		SELECT id, help_request_id, rater_id, ratee_id, score, tags, comment, created_at
+		FROM ratings WHERE rater_id = $1 OR ratee_id = $1 ORDER BY created_at`
+	if err := d.conn.SelectContext(ctx, &rows, query, userID); err != nil {
+		d.log.Error("failed to get ratings", "user", userID, "error", err)
+		return nil, err
+	}
+
+	ratings := make([]core.Rating, 0, len(rows))
+	for _, row := range rows {
+		rating := row.Rating
+		if row.Tags != "" {
+			rating.Tags = strings.Split(row.Tags, ",")
+		}
+		ratings = append(ratings, rating)
+	}
+
+	return ratings, nil
+}
+
+func (d *DB) CloseHelpRequest(ctx context.Context, id int64, status string, events func(core.HelpRequest) ([]core.OutboxMessage, error)) (core.HelpRequest, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return core.HelpRequest{}, err
+	}
+	defer tx.Rollback()
+
+	var request core.HelpRequest
+	query := `
+		UPDATE help_requests SET status = $2
+		WHERE id = $1 AND status = $3
+		RETURNING ` + helpRequestColumns
+	if err := tx.GetContext(ctx, &request, query, id, status, core.StatusPending); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.HelpRequest{}, core.ErrNotPending
+		}
+		d.log.Error("failed to close help request", "id", id, "error", err)
+		return core.HelpRequest{}, err
+	}
+
+	if err := saveEvents(ctx, tx, request, events); err != nil {
+		d.log.Error("failed to save outbox messages", "id", id, "error", err)
+		return core.HelpRequest{}, err
+	}
+
+	return request, tx.Commit()
+}
+
+func (d *DB) ExpireHelpRequests(ctx context.Context, before time.Time, limit int, events func(core.HelpRequest) ([]core.OutboxMessage, error)) ([]core.HelpRequest, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	requests := []core.HelpRequest{}
+	query := `
+		UPDATE help_requests SET status = $3
+		WHERE id IN (
+			SELECT id FROM help_requests
+			WHERE status = $4 AND created_at < $1
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + helpRequestColumns
+	if err := tx.SelectContext(ctx, &requests, query, before, limit, core.StatusExpired, core.StatusPending); err != nil {
+		d.log.Error("failed to expire help requests", "error", err)
+		return nil, err
+	}
+
+	for _, request := range requests {
+		if err := saveEvents(ctx, tx, request, events); err != nil {
+			d.log.Error("failed to save outbox messages", "id", request.ID, "error", err)
+			return nil, err
+		}
+	}
+
+	return requests, tx.Commit()
+}
+
+func saveEvents(ctx context.Context, tx *sqlx.Tx, request core.HelpRequest, events func(core.HelpRequest) ([]core.OutboxMessage, error)) error {
+	messages, err := events(request)
+	if err != nil {
+		return err
+	}
+	for _, message := range messages {
+		if err := saveOutbox(ctx, tx, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,302 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"seeforme/help/core"
+	helppb "seeforme/proto/help"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Server struct {
+	helppb.UnimplementedHelpServer
+	helpService core.HelpService
+}
+
+func NewServer(service core.HelpService) *Server {
+	return &Server{
+		helpService: service,
+	}
+}
+
+func toHelpRequest(r core.HelpRequest) *helppb.HelpRequest {
+	request := &helppb.HelpRequest{
+		Id:          r.ID,
+		RequesterId: r.RequesterID,
+		Question:    r.Question,
+		Status:      r.Status,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+	}
+	if r.VolunteerID != nil {
+		request.VolunteerId = *r.VolunteerID
+	}
+	request.Urgent = r.Urgent
+	return request
+}
+
+func (s *Server) CreateHelpRequest(ctx context.Context, req *helppb.CreateHelpRequestRequest) (*helppb.CreateHelpRequestResponse, error) {
+	id, err := s.helpService.CreateHelpRequest(ctx, req.GetRequesterId(), req.GetQuestion())
+	if err != nil {
+		if errors.Is(err, core.ErrBadArguments) {
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		}
+		return nil, status.Error(codes.Internal, "failed to create help request")
+	}
+
+	return &helppb.CreateHelpRequestResponse{Id: id}, nil
+}
+
+func (s *Server) GetHelpRequest(ctx context.Context, req *helppb.GetHelpRequestRequest) (*helppb.HelpRequest, error) {
+	request, err := s.helpService.GetHelpRequest(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "help request not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get help request")
+	}
+
+	return toHelpRequest(request), nil
+}
+
+func (s *Server) CancelHelpRequest(ctx context.Context, req *helppb.CancelHelpRequestRequest) (*emptypb.Empty, error) {
+	if err := s.helpService.CancelHelpRequest(ctx, req.GetId(), req.GetRequesterId()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "help request not found")
+		case errors.Is(err, core.ErrNotRequester):
+			return nil, status.Error(codes.PermissionDenied, "user is not the requester")
+		case errors.Is(err, core.ErrNotPending):
+			return nil, status.Error(codes.FailedPrecondition, "help request is not pending")
+		}
+		return nil, status.Error(codes.Internal, "failed to cancel help request")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) EscalateHelpRequest(ctx context.Context, req *helppb.EscalateHelpRequestRequest) (*emptypb.Empty, error) {
+	if err := s.helpService.EscalateHelpRequest(ctx, req.GetId(), req.GetRequesterId()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "help request not found")
+		case errors.Is(err, core.ErrNotRequester):
+			return nil, status.Error(codes.PermissionDenied, "user is not the requester")
+		case errors.Is(err, core.ErrNotPending):
+			return nil, status.Error(codes.FailedPrecondition, "help request is not pending")
+		}
+		return nil, status.Error(codes.Internal, "failed to escalate help request")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) AcceptHelpRequest(ctx context.Context, req *helppb.AcceptHelpRequestRequest) (*emptypb.Empty, error) {
+	if err := s.helpService.AcceptHelpRequest(ctx, req.GetId(), req.GetVolunteerId()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "help request not found")
+		case errors.Is(err, core.ErrNotOffered):
+			return nil, status.Error(codes.PermissionDenied, "help request was not offered to the volunteer")
+		case errors.Is(err, core.ErrVolunteerLimit):
+			return nil, status.Error(codes.ResourceExhausted, "volunteer has reached a call limit")
+		case errors.Is(err, core.ErrNotPending):
+			return nil, status.Error(codes.FailedPrecondition, "help request is not pending")
+		}
+		return nil, status.Error(codes.Internal, "failed to accept help request")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) AcknowledgeOffer(ctx context.Context, req *helppb.AcknowledgeOfferRequest) (*emptypb.Empty, error) {
+	if err := s.helpService.AcknowledgeOffer(ctx, req.GetHelpRequestId(), req.GetVolunteerId(), req.GetOpened()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrBadArguments):
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		case errors.Is(err, core.ErrNotOffered):
+			return nil, status.Error(codes.NotFound, "help request was not offered to the volunteer")
+		}
+		return nil, status.Error(codes.Internal, "failed to acknowledge offer")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) VolunteerConnecting(ctx context.Context, req *helppb.VolunteerConnectingRequest) (*emptypb.Empty, error) {
+	if err := s.helpService.VolunteerConnecting(ctx, req.GetHelpRequestId(), req.GetVolunteerId()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "help request not found")
+		case errors.Is(err, core.ErrNotAccepted):
+			return nil, status.Error(codes.FailedPrecondition, "help request is not accepted")
+		case errors.Is(err, core.ErrNotParticipant):
+			return nil, status.Error(codes.PermissionDenied, "volunteer did not accept this help request")
+		}
+		return nil, status.Error(codes.Internal, "failed to save volunteer connecting event")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetVolunteerStats(ctx context.Context, req *helppb.GetVolunteerStatsRequest) (*helppb.VolunteerStats, error) {
+	stats, limit, err := s.helpService.GetVolunteerStats(ctx, req.GetVolunteerId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get volunteer stats")
+	}
+
+	response := &helppb.VolunteerStats{
+		CallsToday: int32(stats.CallsToday),
+		OnCall:     stats.OnCall,
+		Limit:      limit,
+	}
+	if stats.LastCallEndedAt != nil {
+		response.LastCallEndedAt = timestamppb.New(*stats.LastCallEndedAt)
+	}
+	return response, nil
+}
+
+func (s *Server) GetDispatchLog(ctx context.Context, req *helppb.GetDispatchLogRequest) (*helppb.GetDispatchLogResponse, error) {
+	scores, err := s.helpService.GetDispatchLog(ctx, req.GetHelpRequestId())
+	if err != nil {
+		if errors.Is(err, core.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "help request not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to get dispatch log")
+	}
+
+	response := &helppb.GetDispatchLogResponse{}
+	for _, s := range scores {
+		score := &helppb.MatchScore{
+			Wave:        int32(s.Wave),
+			VolunteerId: s.VolunteerID,
+			Score:       s.Score,
+			Offered:     s.Offered,
+			Excluded:    s.Excluded,
+			CreatedAt:   timestamppb.New(s.CreatedAt),
+		}
+		for _, f := range s.Factors {
+			score.Factors = append(score.Factors, &helppb.ScoreFactor{
+				Name:   f.Name,
+				Value:  f.Value,
+				Weight: f.Weight,
+				Reason: f.Reason,
+			})
+		}
+		response.Scores = append(response.Scores, score)
+	}
+
+	return response, nil
+}
+
+func (s *Server) GetUserHistory(ctx context.Context, req *helppb.GetUserHistoryRequest) (*helppb.GetUserHistoryResponse, error) {
+	requests, calls, ratings, err := s.helpService.GetUserHistory(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get user history")
+	}
+
+	response := &helppb.GetUserHistoryResponse{}
+	for _, r := range requests {
+		response.HelpRequests = append(response.HelpRequests, toHelpRequest(r))
+	}
+	for _, c := range calls {
+		call := &helppb.Call{
+			Id:            c.ID,
+			HelpRequestId: c.HelpRequestID,
+			BlindId:       c.BlindID,
+			StartedAt:     timestamppb.New(c.StartedAt),
+		}
+		if c.VolunteerID != nil {
+			call.VolunteerId = *c.VolunteerID
+		}
+		if c.EndedAt != nil {
+			call.EndedAt = timestamppb.New(*c.EndedAt)
+		}
+		response.Calls = append(response.Calls, call)
+	}
+	for _, r := range ratings {
+		response.Ratings = append(response.Ratings, &helppb.Rating{
+			Id:            r.ID,
+			HelpRequestId: r.HelpRequestID,
+			RaterId:       r.RaterID,
+			RateeId:       r.RateeID,
+			Score:         int32(r.Score),
+			Tags:          r.Tags,
+			Comment:       r.Comment,
+			CreatedAt:     timestamppb.New(r.CreatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *Server) DeleteUserData(ctx context.Context, req *helppb.DeleteUserDataRequest) (*emptypb.Empty, error) {
+	if err := s.helpService.DeleteUserData(ctx, req.GetUserId()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete user data")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) RecordCall(ctx context.Context, req *helppb.RecordCallRequest) (*helppb.RecordCallResponse, error) {
+	if req.GetStartedAt() == nil || req.GetEndedAt() == nil || req.GetVolunteerId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "bad arguments")
+	}
+	volunteerID := req.GetVolunteerId()
+	endedAt := req.GetEndedAt().AsTime()
+
+	id, err := s.helpService.RecordCall(ctx, core.Call{
+		HelpRequestID: req.GetHelpRequestId(),
+		VolunteerID:   &volunteerID,
+		StartedAt:     req.GetStartedAt().AsTime(),
+		EndedAt:       &endedAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "help request not found")
+		case errors.Is(err, core.ErrBadArguments):
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		case errors.Is(err, core.ErrCancelled):
+			return nil, status.Error(codes.FailedPrecondition, "help request is cancelled")
+		case errors.Is(err, core.ErrNotAccepted):
+			return nil, status.Error(codes.FailedPrecondition, "help request is not accepted")
+		case errors.Is(err, core.ErrNotParticipant):
+			return nil, status.Error(codes.PermissionDenied, "volunteer did not accept this help request")
+		}
+		return nil, status.Error(codes.Internal, "failed to record call")
+	}
+
+	return &helppb.RecordCallResponse{Id: id}, nil
+}
+
+func (s *Server) SubmitRating(ctx context.Context, req *helppb.SubmitRatingRequest) (*emptypb.Empty, error) {
+	err := s.helpService.SubmitRating(ctx, core.Rating{
+		HelpRequestID: req.GetHelpRequestId(),
+		RaterID:       req.GetRaterId(),
+		Score:         int(req.GetScore()),
+		Tags:          req.GetTags(),
+		Comment:       req.GetComment(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, core.ErrBadArguments):
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		case errors.Is(err, core.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "help request not found")
+		case errors.Is(err, core.ErrNotCompleted):
+			return nil, status.Error(codes.FailedPrecondition, "help request is not completed")
+		case errors.Is(err, core.ErrNotParticipant):
+			return nil, status.Error(codes.PermissionDenied, "user did not take part in the call")
+		case errors.Is(err, core.ErrAlreadyRated):
+			return nil, status.Error(codes.AlreadyExists, "help request already rated")
+		}
+		return nil, status.Error(codes.Internal, "failed to submit rating")
+	}
+
+	return &emptypb.Empty{}, nil
+}
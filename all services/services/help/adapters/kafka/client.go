@@ -0,0 +1,186 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"seeforme/help/core"
+	"seeforme/pkg/events"
+	eventspb "seeforme/proto/events"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Client отправляет сообщения из outbox. Топик берётся из самого сообщения.
+type Client struct {
+	writer        *kafka.Writer
+	helpTopic     string
+	responseTopic string
+	log           *slog.Logger
+}
+
+// closedAnswers - ответ автору запроса при его закрытии, см. HelpResponded.answer
+var closedAnswers = map[string]string{
+	core.StatusAccepted:  "accept",
+	core.StatusCancelled: "cancel",
+	core.StatusExpired:   "expired",
+}
+
+const answerConnecting = "connecting"
+
+func NewClient(brokers []string, helpTopic, responseTopic string, log *slog.Logger) (*Client, error) {
+	var addresses []string
+	for _, broker := range brokers {
+		if !strings.Contains(broker, ":") {
+			broker = broker + ":9092"
+		}
+		addresses = append(addresses, broker)
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(addresses...),
+		Balancer:     &kafka.Hash{},
+		BatchTimeout: 10 * time.Millisecond,
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	return &Client{
+		writer:        writer,
+		helpTopic:     helpTopic,
+		responseTopic: responseTopic,
+		log:           log,
+	}, nil
+}
+
+func (c *Client) HelpRequestCreated(request core.HelpRequest) (core.OutboxMessage, error) {
+	envelope, data, err := events.Marshal(&eventspb.HelpRequested{
+		HelpRequestId:    request.ID,
+		RequestCreatorId: request.RequesterID,
+		Question:         request.Question,
+		CreatedAt:        timestamppb.New(request.CreatedAt),
+	})
+	if err != nil {
+		return core.OutboxMessage{}, err
+	}
+
+	return core.OutboxMessage{
+		MessageID: envelope.GetId(),
+		Topic:     c.helpTopic,
+		Key:       strconv.FormatInt(request.RequesterID, 10),
+		Payload:   data,
+	}, nil
+}
+
+func (c *Client) HelpRequestOffered(request core.HelpRequest, volunteerIDs []int64) (core.OutboxMessage, error) {
+	envelope, data, err := events.Marshal(&eventspb.HelpOffered{
+		HelpRequestId: request.ID,
+		RequesterId:   request.RequesterID,
+		Question:      request.Question,
+		VolunteerIds:  volunteerIDs,
+		Wave:          int32(request.Wave),
+	})
+	if err != nil {
+		return core.OutboxMessage{}, err
+	}
+
+	return core.OutboxMessage{
+		MessageID: envelope.GetId(),
+		Topic:     c.helpTopic,
+		Key:       strconv.FormatInt(request.RequesterID, 10),
+		Payload:   data,
+	}, nil
+}
+
+// HelpRequestClosed пишет help.withdrawn в топик запросов, чтобы отозвать уведомления волонтёров,
+// и help.responded в топик ответов, чтобы об этом узнали клиенты автора
+func (c *Client) HelpRequestClosed(request core.HelpRequest) ([]core.OutboxMessage, error) {
+	answer, ok := closedAnswers[request.Status]
+	if !ok {
+		return nil, fmt.Errorf("help request %d is not closed: %s", request.ID, request.Status)
+	}
+	key := strconv.FormatInt(request.RequesterID, 10)
+
+	withdrawn, data, err := events.Marshal(&eventspb.HelpWithdrawn{
+		HelpRequestId: request.ID,
+		RequesterId:   request.RequesterID,
+		Reason:        request.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	messages := []core.OutboxMessage{{
+		MessageID: withdrawn.GetId(),
+		Topic:     c.helpTopic,
+		Key:       key,
+		Payload:   data,
+	}}
+
+	response := &eventspb.HelpResponded{
+		HelpRequestId: request.ID,
+		RequesterId:   request.RequesterID,
+		Answer:        answer,
+	}
+	if request.VolunteerID != nil {
+		response.VolunteerId = *request.VolunteerID
+	}
+	responded, data, err := events.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	messages = append(messages, core.OutboxMessage{
+		MessageID: responded.GetId(),
+		Topic:     c.responseTopic,
+		Key:       key,
+		Payload:   data,
+	})
+
+	return messages, nil
+}
+
+func (c *Client) VolunteerConnecting(request core.HelpRequest) (core.OutboxMessage, error) {
+	response := &eventspb.HelpResponded{
+		HelpRequestId: request.ID,
+		RequesterId:   request.RequesterID,
+		Answer:        answerConnecting,
+	}
+	if request.VolunteerID != nil {
+		response.VolunteerId = *request.VolunteerID
+	}
+	envelope, data, err := events.Marshal(response)
+	if err != nil {
+		return core.OutboxMessage{}, err
+	}
+
+	return core.OutboxMessage{
+		MessageID: envelope.GetId(),
+		Topic:     c.responseTopic,
+		Key:       strconv.FormatInt(request.RequesterID, 10),
+		Payload:   data,
+	}, nil
+}
+
+func (c *Client) Publish(ctx context.Context, message core.OutboxMessage) error {
+	err := c.writer.WriteMessages(ctx, kafka.Message{
+		Topic: message.Topic,
+		Key:   []byte(message.Key),
+		Value: message.Payload,
+		Time:  time.Now(),
+		Headers: []kafka.Header{
+			{Key: events.HeaderMessageID, Value: []byte(message.MessageID)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.log.Debug("message sent to kafka", "topic", message.Topic, "key", message.Key)
+	return nil
+}
+
+func (c *Client) Close() error {
+	return c.writer.Close()
+}
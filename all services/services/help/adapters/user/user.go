@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+
+	"seeforme/help/core"
+	userpb "seeforme/proto/user"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client userpb.UserClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: userpb.NewUserClient(conn),
+	}, nil
+}
+
+func (c *Client) SetVolunteerRating(ctx context.Context, volunteerID int64, sum, count int64) error {
+	_, err := c.client.SetVolunteerRating(ctx, &userpb.SetVolunteerRatingRequest{
+		UserId:      volunteerID,
+		RatingSum:   sum,
+		RatingCount: count,
+	})
+	if err != nil {
+		c.log.Error("failed to set volunteer rating", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) ListVolunteers(ctx context.Context, filter core.VolunteerFilter) ([]core.Volunteer, error) {
+	response, err := c.client.ListVolunteers(ctx, &userpb.ListVolunteersRequest{
+		RequesterId:   filter.RequesterID,
+		ExcludeIds:    filter.ExcludeIDs,
+		Limit:         int32(filter.Limit),
+		AvailableOnly: true,
+		Urgent:        filter.Urgent,
+		Languages:     filter.Languages,
+	})
+	if err != nil {
+		c.log.Error("failed to list volunteers", "error", err)
+		return nil, err
+	}
+
+	blocked := idSet(response.GetBlockedIds())
+	offSchedule := idSet(response.GetOffScheduleIds())
+	doNotDisturb := idSet(response.GetDoNotDisturbIds())
+
+	volunteers := make([]core.Volunteer, 0, len(response.GetVolunteers()))
+	for _, v := range response.GetVolunteers() {
+		volunteers = append(volunteers, core.Volunteer{
+			Profile:       toProfile(v),
+			RatingAverage: v.GetRatingAverage(),
+			RatingCount:   v.GetRatingCount(),
+			Blocked:       blocked[v.GetId()],
+			OffSchedule:   offSchedule[v.GetId()],
+			DoNotDisturb:  doNotDisturb[v.GetId()],
+		})
+	}
+	return volunteers, nil
+}
+
+func (c *Client) GetProfile(ctx context.Context, userID int64) (core.Profile, error) {
+	user, err := c.client.GetUser(ctx, &userpb.GetUserRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to get user", "error", err)
+		return core.Profile{}, err
+	}
+	return toProfile(user), nil
+}
+
+func idSet(ids []int64) map[int64]bool {
+	set := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func toProfile(user *userpb.UserInfo) core.Profile {
+	return core.Profile{
+		ID:        user.GetId(),
+		Languages: user.GetLanguages(),
+		Timezone:  user.GetTimezone(),
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+type Kafka struct {
+	Brokers       []string `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092"`
+	HelpTopic     string   `yaml:"help_topic" env:"KAFKA_HELP_TOPIC" env-default:"help-request"`
+	ResponseTopic string   `yaml:"response_topic" env:"KAFKA_RESPONSE_TOPIC" env-default:"help-response"`
+}
+
+type Outbox struct {
+	Interval   time.Duration `yaml:"interval" env:"OUTBOX_INTERVAL" env-default:"1s"`
+	BatchSize  int           `yaml:"batch_size" env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	Lease      time.Duration `yaml:"lease" env:"OUTBOX_LEASE" env-default:"30s"`
+	MaxBackoff time.Duration `yaml:"max_backoff" env:"OUTBOX_MAX_BACKOFF" env-default:"5m"`
+	Retention  time.Duration `yaml:"retention" env:"OUTBOX_RETENTION" env-default:"24h"`
+}
+
+// Expiry - через сколько неотвеченный запрос считается истёкшим
+type Expiry struct {
+	TTL       time.Duration `yaml:"ttl" env:"EXPIRY_TTL" env-default:"10m"`
+	Interval  time.Duration `yaml:"interval" env:"EXPIRY_INTERVAL" env-default:"30s"`
+	BatchSize int           `yaml:"batch_size" env:"EXPIRY_BATCH_SIZE" env-default:"100"`
+}
+
+// RatingSync - как часто рейтинг волонтёров переписывается в сервис пользователей
+type RatingSync struct {
+	Interval  time.Duration `yaml:"interval" env:"RATING_SYNC_INTERVAL" env-default:"5s"`
+	BatchSize int           `yaml:"batch_size" env:"RATING_SYNC_BATCH_SIZE" env-default:"100"`
+	Lease     time.Duration `yaml:"lease" env:"RATING_SYNC_LEASE" env-default:"30s"`
+}
+
+// Dispatch - волны рассылки запроса волонтёрам, см. core.DispatchConfig
+type Dispatch struct {
+	WaveSizes     []int           `yaml:"wave_sizes" env:"DISPATCH_WAVE_SIZES" env-default:"3,10,30"`
+	WaveDelays    []time.Duration `yaml:"wave_delays" env:"DISPATCH_WAVE_DELAYS" env-default:"30s,1m,2m"`
+	Interval      time.Duration   `yaml:"interval" env:"DISPATCH_INTERVAL" env-default:"1s"`
+	BatchSize     int             `yaml:"batch_size" env:"DISPATCH_BATCH_SIZE" env-default:"50"`
+	Lease         time.Duration   `yaml:"lease" env:"DISPATCH_LEASE" env-default:"30s"`
+	CandidatePool int             `yaml:"candidate_pool" env:"DISPATCH_CANDIDATE_POOL" env-default:"100"`
+	LoadWindow    time.Duration   `yaml:"load_window" env:"DISPATCH_LOAD_WINDOW" env-default:"24h"`
+	AckTimeout    time.Duration   `yaml:"ack_timeout" env:"DISPATCH_ACK_TIMEOUT" env-default:"15s"`
+	Scoring       Scoring         `yaml:"scoring"`
+	Fairness      Fairness        `yaml:"fairness"`
+}
+
+// Fairness - лимиты нагрузки на волонтёра, 0 отключает лимит, см. core.FairnessConfig
+type Fairness struct {
+	MaxCallsPerDay    int           `yaml:"max_calls_per_day" env:"FAIRNESS_MAX_CALLS_PER_DAY" env-default:"20"`
+	Cooldown          time.Duration `yaml:"cooldown" env:"FAIRNESS_COOLDOWN" env-default:"2m"`
+	ActiveCallTimeout time.Duration `yaml:"active_call_timeout" env:"FAIRNESS_ACTIVE_CALL_TIMEOUT" env-default:"1h"`
+}
+
+// Scoring - веса признаков при подборе волонтёров, см. core.ScoringMatcher
+type Scoring struct {
+	LanguageWeight float64 `yaml:"language_weight" env:"SCORING_LANGUAGE_WEIGHT" env-default:"3"`
+	TimezoneWeight float64 `yaml:"timezone_weight" env:"SCORING_TIMEZONE_WEIGHT" env-default:"1"`
+	DaytimeWeight  float64 `yaml:"daytime_weight" env:"SCORING_DAYTIME_WEIGHT" env-default:"2"`
+	LoadWeight     float64 `yaml:"load_weight" env:"SCORING_LOAD_WEIGHT" env-default:"1.5"`
+	RatingWeight   float64 `yaml:"rating_weight" env:"SCORING_RATING_WEIGHT" env-default:"2"`
+	FairnessWeight float64 `yaml:"fairness_weight" env:"SCORING_FAIRNESS_WEIGHT" env-default:"1.5"`
+	DayStart       int     `yaml:"day_start" env:"SCORING_DAY_START" env-default:"8"`
+	DayEnd         int     `yaml:"day_end" env:"SCORING_DAY_END" env-default:"22"`
+	UnratedScore   float64 `yaml:"unrated_score" env:"SCORING_UNRATED_SCORE" env-default:"0.6"`
+}
+
+type Config struct {
+	LogLevel    string     `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	Address     string     `yaml:"help_address" env:"HELP_ADDRESS" env-default:"localhost:83"`
+	DBAddress   string     `yaml:"db_address" env:"DB_ADDRESS" env-default:"localhost:82"`
+	UserAddress string     `yaml:"user_address" env:"USER_ADDRESS" env-default:"localhost:81"`
+	Kafka       Kafka      `yaml:"kafka"`
+	Outbox      Outbox     `yaml:"outbox"`
+	Expiry      Expiry     `yaml:"expiry"`
+	RatingSync  RatingSync `yaml:"rating_sync"`
+	Dispatch    Dispatch   `yaml:"dispatch"`
+}
+
+func MustLoad(configPath string) Config {
+	var cfg Config
+	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+		if err := cleanenv.ReadEnv(&cfg); err != nil {
+			log.Fatalf("cannot read config %q: %s", configPath, err)
+		}
+	}
+
+	if len(cfg.Kafka.Brokers) == 1 && strings.Contains(cfg.Kafka.Brokers[0], ",") {
+		cfg.Kafka.Brokers = strings.Split(cfg.Kafka.Brokers[0], ",")
+	}
+	if len(cfg.Dispatch.WaveSizes) == 0 || len(cfg.Dispatch.WaveDelays) == 0 {
+		log.Fatalf("dispatch wave_sizes and wave_delays must not be empty")
+	}
+	return cfg
+}
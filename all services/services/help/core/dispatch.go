@@ -0,0 +1,254 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// DispatchConfig - волны рассылки: i-я волна уходит WaveSizes[i] волонтёрам,
+// следующая - через WaveDelays[i]. После последней волны повторяются последние значения.
+type DispatchConfig struct {
+	WaveSizes     []int
+	WaveDelays    []time.Duration
+	Interval      time.Duration
+	BatchSize     int
+	Lease         time.Duration
+	CandidatePool int
+	// LoadWindow - за какой период считается недавняя нагрузка волонтёра
+	LoadWindow time.Duration
+	// AckTimeout - сколько ждать, пока хоть один волонтёр волны подтвердит доставку,
+	// прежде чем разослать следующую волну досрочно. 0 отключает досрочные волны.
+	AckTimeout time.Duration
+	Fairness   FairnessConfig
+}
+
+// maxCandidatePages - сколько страниц по CandidatePool кандидатов просматривается за одну волну
+const maxCandidatePages = 10
+
+// Dispatcher предлагает запрос сначала небольшой группе лучших волонтёров
+// и расширяет круг волнами, пока запрос не примут, не отменят или он не истечёт
+type Dispatcher struct {
+	log     *slog.Logger
+	db      DB
+	users   Users
+	events  Events
+	matcher Matcher
+	clock   Clock
+	cfg     DispatchConfig
+}
+
+func NewDispatcher(log *slog.Logger, db DB, users Users, events Events, matcher Matcher, clock Clock, cfg DispatchConfig) *Dispatcher {
+	return &Dispatcher{log: log, db: db, users: users, events: events, matcher: matcher, clock: clock, cfg: cfg}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.clock.After(d.cfg.Interval):
+			for d.Tick(ctx) == d.cfg.BatchSize {
+			}
+		}
+	}
+}
+
+// Tick рассылает все наступившие волны одной пачкой и возвращает число обработанных запросов
+func (d *Dispatcher) Tick(ctx context.Context) int {
+	requests, err := d.db.ClaimDueWaves(ctx, d.clock.Now(), d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		d.log.Error("failed to claim help requests for dispatch", "error", err)
+		return 0
+	}
+
+	for _, request := range requests {
+		if err := d.dispatch(ctx, request); err != nil {
+			// запрос вернётся после истечения lease
+			d.log.Error("failed to dispatch help request", "id", request.ID, "wave", request.Wave+1, "error", err)
+		}
+	}
+	return len(requests)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, request HelpRequest) error {
+	offered, err := d.db.GetOfferedVolunteers(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+
+	requester, err := d.users.GetProfile(ctx, request.RequesterID)
+	if err != nil {
+		return err
+	}
+
+	now := d.clock.Now()
+	size := d.waveSize(request.Wave)
+	matchRequest := MatchRequest{Request: request, Requester: requester, Now: now}
+	filter := VolunteerFilter{
+		RequesterID: request.RequesterID,
+		ExcludeIDs:  offered,
+		Limit:       d.cfg.CandidatePool,
+		Urgent:      request.Urgent,
+		Languages:   requester.Languages,
+	}
+
+	// кандидаты, упёршиеся в лимиты нагрузки, исключаются уже здесь, поэтому добираем
+	// следующие страницы, пока волна не наберётся или волонтёры не кончатся
+	var candidates []Candidate
+	var matches []Match
+	for page := 0; page < maxCandidatePages; page++ {
+		volunteers, err := d.users.ListVolunteers(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(volunteers))
+		for _, volunteer := range volunteers {
+			ids = append(ids, volunteer.ID)
+		}
+		stats, err := getVolunteerStats(ctx, d.db, ids, now, d.cfg.LoadWindow, d.cfg.Fairness)
+		if err != nil {
+			return err
+		}
+
+		for _, volunteer := range volunteers {
+			candidates = append(candidates, Candidate{
+				Volunteer: volunteer,
+				Stats:     stats[volunteer.ID],
+				Limit:     d.cfg.Fairness.Limit(stats[volunteer.ID], now),
+			})
+		}
+		matches = d.matcher.Match(matchRequest, candidates)
+
+		if len(volunteers) < filter.Limit || eligible(matches) >= size {
+			break
+		}
+		filter.ExcludeIDs = append(slices.Clip(filter.ExcludeIDs), ids...)
+	}
+
+	volunteerIDs := make([]int64, 0, size)
+	for _, match := range matches {
+		if match.Excluded || len(volunteerIDs) == size {
+			break
+		}
+		volunteerIDs = append(volunteerIDs, match.VolunteerID)
+	}
+
+	next := now.Add(d.waveDelay(request.Wave))
+	var ackDeadline *time.Time
+	if d.cfg.AckTimeout > 0 && len(volunteerIDs) > 0 {
+		deadline := now.Add(d.cfg.AckTimeout)
+		ackDeadline = &deadline
+	}
+	if err := d.db.SaveOffers(ctx, request, volunteerIDs, matches, next, ackDeadline, d.events.HelpRequestOffered); err != nil {
+		if errors.Is(err, ErrNotPending) {
+			return nil
+		}
+		return err
+	}
+
+	if len(volunteerIDs) == 0 {
+		d.log.Debug("no volunteers to offer help request", "id", request.ID, "next_wave_at", next)
+		return nil
+	}
+	d.log.Info("help request offered", "id", request.ID, "wave", request.Wave+1, "volunteers", len(volunteerIDs), "next_wave_at", next)
+	return nil
+}
+
+func eligible(matches []Match) int {
+	count := 0
+	for _, match := range matches {
+		if !match.Excluded {
+			count++
+		}
+	}
+	return count
+}
+
+func (d *Dispatcher) waveSize(wave int) int {
+	return d.cfg.WaveSizes[min(wave, len(d.cfg.WaveSizes)-1)]
+}
+
+func (d *Dispatcher) waveDelay(wave int) time.Duration {
+	return d.cfg.WaveDelays[min(wave, len(d.cfg.WaveDelays)-1)]
+}
+
+// GetDispatchLog - оценки кандидатов во всех волнах запроса, для разбора администратором
+func (s *Helpservice) GetDispatchLog(ctx context.Context, id int64) ([]MatchScore, error) {
+	if _, err := s.GetHelpRequest(ctx, id); err != nil {
+		return nil, err
+	}
+
+	scores, err := s.db.GetMatchScores(ctx, id)
+	if err != nil {
+		s.log.Error("failed to get match scores", "id", id, "error", err)
+		return nil, ErrDispatchLog
+	}
+
+	return scores, nil
+}
+
+// AcceptHelpRequest закрепляет запрос за волонтёром, которому он был предложен
+func (s *Helpservice) AcceptHelpRequest(ctx context.Context, id, volunteerID int64) error {
+	if _, err := s.db.GetHelpRequest(ctx, id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", id, "error", err)
+		return ErrGetHelpRequest
+	}
+
+	offered, err := s.db.HasOffer(ctx, id, volunteerID)
+	if err != nil {
+		s.log.Error("failed to check offer", "id", id, "volunteer", volunteerID, "error", err)
+		return ErrAccept
+	}
+	if !offered {
+		return ErrNotOffered
+	}
+
+	// предложение могло уйти раньше, чем волонтёр взял другой звонок или упёрся в лимит
+	now := s.clock.Now()
+	stats, err := getVolunteerStats(ctx, s.db, []int64{volunteerID}, now, 0, s.fairness)
+	if err != nil {
+		s.log.Error("failed to get volunteer stats", "volunteer", volunteerID, "error", err)
+		return ErrAccept
+	}
+	if limit := s.fairness.Limit(stats[volunteerID], now); limit != "" {
+		s.log.Info("volunteer over limit", "id", id, "volunteer", volunteerID, "limit", limit)
+		return ErrVolunteerLimit
+	}
+
+	if _, err := s.db.AcceptHelpRequest(ctx, id, volunteerID, s.events.HelpRequestClosed); err != nil {
+		if errors.Is(err, ErrNotPending) {
+			return ErrNotPending
+		}
+		s.log.Error("failed to accept help request", "id", id, "volunteer", volunteerID, "error", err)
+		return ErrAccept
+	}
+
+	s.log.Info("help request accepted", "id", id, "volunteer", volunteerID)
+
+	return nil
+}
+
+// AcknowledgeOffer записывает подтверждение доставки предложения от приложения волонтёра
+func (s *Helpservice) AcknowledgeOffer(ctx context.Context, id, volunteerID int64, opened bool) error {
+	if id <= 0 || volunteerID <= 0 {
+		return ErrBadArguments
+	}
+
+	if err := s.db.AcknowledgeOffer(ctx, id, volunteerID, opened); err != nil {
+		if errors.Is(err, ErrNotOffered) {
+			return ErrNotOffered
+		}
+		s.log.Error("failed to acknowledge offer", "id", id, "volunteer", volunteerID, "error", err)
+		return ErrAcknowledge
+	}
+
+	s.log.Debug("offer acknowledged", "id", id, "volunteer", volunteerID, "opened", opened)
+	return nil
+}
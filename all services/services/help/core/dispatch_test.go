@@ -0,0 +1,496 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"seeforme/pkg/clock"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeRequest - запрос вместе с расписанием волн, как в help_requests
+type fakeRequest struct {
+	HelpRequest
+	nextWaveAt  time.Time
+	ackDeadline *time.Time
+}
+
+type fakeOffer struct {
+	volunteerID int64
+	wave        int
+	acked       bool
+}
+
+// fakeDB повторяет в памяти то, что диспетчеру нужно от базы; остальные методы не вызываются
+type fakeDB struct {
+	DB
+
+	mu       sync.Mutex
+	requests map[int64]*fakeRequest
+	offers   map[int64][]fakeOffer
+	stats    map[int64]VolunteerStats
+	// waves - кому ушла каждая волна, по запросам
+	waves map[int64][][]int64
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		requests: map[int64]*fakeRequest{},
+		offers:   map[int64][]fakeOffer{},
+		stats:    map[int64]VolunteerStats{},
+		waves:    map[int64][][]int64{},
+	}
+}
+
+func (d *fakeDB) addRequest(request HelpRequest, nextWaveAt time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.requests[request.ID] = &fakeRequest{HelpRequest: request, nextWaveAt: nextWaveAt}
+}
+
+func (d *fakeDB) wavesOf(id int64) [][]int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.waves[id])
+}
+
+func (d *fakeDB) GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	request, ok := d.requests[id]
+	if !ok {
+		return HelpRequest{}, ErrNotFound
+	}
+	return request.HelpRequest, nil
+}
+
+func (d *fakeDB) ClaimDueWaves(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]HelpRequest, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var claimed []HelpRequest
+	for _, request := range d.requests {
+		if len(claimed) == limit {
+			break
+		}
+		if request.Status != StatusPending {
+			continue
+		}
+		due := !request.nextWaveAt.After(now)
+		unacked := request.ackDeadline != nil && !request.ackDeadline.After(now) && !d.acked(request.ID, request.Wave)
+		if !due && !unacked {
+			continue
+		}
+		request.nextWaveAt = now.Add(lease)
+		request.ackDeadline = nil
+		claimed = append(claimed, request.HelpRequest)
+	}
+	sort.Slice(claimed, func(i, j int) bool { return claimed[i].ID < claimed[j].ID })
+	return claimed, nil
+}
+
+func (d *fakeDB) acked(id int64, wave int) bool {
+	for _, offer := range d.offers[id] {
+		if offer.wave == wave && offer.acked {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *fakeDB) GetOfferedVolunteers(ctx context.Context, helpRequestID int64) ([]int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ids := []int64{}
+	for _, offer := range d.offers[helpRequestID] {
+		ids = append(ids, offer.volunteerID)
+	}
+	return ids, nil
+}
+
+func (d *fakeDB) SaveOffers(ctx context.Context, request HelpRequest, volunteerIDs []int64, matches []Match, nextWaveAt time.Time, ackDeadline *time.Time, event func(HelpRequest, []int64) (OutboxMessage, error)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored := d.requests[request.ID]
+	if stored.Status != StatusPending || stored.Wave != request.Wave {
+		return ErrNotPending
+	}
+	if len(volunteerIDs) > 0 {
+		stored.Wave++
+	}
+	stored.nextWaveAt = nextWaveAt
+	stored.ackDeadline = ackDeadline
+	if len(volunteerIDs) == 0 {
+		return nil
+	}
+
+	for _, id := range volunteerIDs {
+		d.offers[request.ID] = append(d.offers[request.ID], fakeOffer{volunteerID: id, wave: stored.Wave})
+	}
+	d.waves[request.ID] = append(d.waves[request.ID], slices.Clone(volunteerIDs))
+	_, err := event(stored.HelpRequest, volunteerIDs)
+	return err
+}
+
+func (d *fakeDB) GetVolunteerStats(ctx context.Context, volunteerIDs []int64, assignedSince, callsSince, acceptedSince time.Time) (map[int64]VolunteerStats, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := map[int64]VolunteerStats{}
+	for _, id := range volunteerIDs {
+		if s, ok := d.stats[id]; ok {
+			stats[id] = s
+		}
+	}
+	return stats, nil
+}
+
+func (d *fakeDB) HasOffer(ctx context.Context, helpRequestID, volunteerID int64) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, offer := range d.offers[helpRequestID] {
+		if offer.volunteerID == volunteerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d *fakeDB) AcknowledgeOffer(ctx context.Context, helpRequestID, volunteerID int64, opened bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	offers := d.offers[helpRequestID]
+	for i := range offers {
+		if offers[i].volunteerID == volunteerID {
+			offers[i].acked = true
+			return nil
+		}
+	}
+	return ErrNotOffered
+}
+
+func (d *fakeDB) AcceptHelpRequest(ctx context.Context, id, volunteerID int64, events func(HelpRequest) ([]OutboxMessage, error)) (HelpRequest, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	request := d.requests[id]
+	if request.Status != StatusPending {
+		return HelpRequest{}, ErrNotPending
+	}
+	request.Status = StatusAccepted
+	request.VolunteerID = &volunteerID
+	if _, err := events(request.HelpRequest); err != nil {
+		return HelpRequest{}, err
+	}
+	return request.HelpRequest, nil
+}
+
+// fakeUsers отдаёт волонтёров в заданном порядке, как сервис пользователей - по рейтингу
+type fakeUsers struct {
+	Users
+
+	volunteers []Volunteer
+	// onList вызывается при каждом запросе кандидатов
+	onList func()
+}
+
+func (u *fakeUsers) ListVolunteers(ctx context.Context, filter VolunteerFilter) ([]Volunteer, error) {
+	if u.onList != nil {
+		u.onList()
+	}
+	volunteers := []Volunteer{}
+	for _, volunteer := range u.volunteers {
+		if len(volunteers) == filter.Limit {
+			break
+		}
+		if volunteer.ID == filter.RequesterID || slices.Contains(filter.ExcludeIDs, volunteer.ID) {
+			continue
+		}
+		volunteers = append(volunteers, volunteer)
+	}
+	return volunteers, nil
+}
+
+func (u *fakeUsers) GetProfile(ctx context.Context, userID int64) (Profile, error) {
+	return Profile{ID: userID}, nil
+}
+
+type fakeEvents struct {
+	Events
+}
+
+func (fakeEvents) HelpRequestOffered(request HelpRequest, volunteerIDs []int64) (OutboxMessage, error) {
+	return OutboxMessage{}, nil
+}
+
+func (fakeEvents) HelpRequestClosed(request HelpRequest) ([]OutboxMessage, error) {
+	return nil, nil
+}
+
+// makeVolunteers - волонтёры с id от 1 до n, рейтинг падает с ростом id
+func makeVolunteers(n int) []Volunteer {
+	volunteers := make([]Volunteer, 0, n)
+	for i := 1; i <= n; i++ {
+		volunteers = append(volunteers, Volunteer{
+			Profile:       Profile{ID: int64(i)},
+			RatingAverage: MaxScore - float64(i)*0.1,
+			RatingCount:   1,
+		})
+	}
+	return volunteers
+}
+
+type dispatchTest struct {
+	db         *fakeDB
+	users      *fakeUsers
+	clock      *clock.Fake
+	service    *Helpservice
+	dispatcher *Dispatcher
+}
+
+func newDispatchTest(cfg DispatchConfig, volunteers int) *dispatchTest {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	db := newFakeDB()
+	users := &fakeUsers{volunteers: makeVolunteers(volunteers)}
+	c := clock.NewFake(start)
+	matcher := NewScoringMatcher(ScoringConfig{Weights: MatchWeights{Rating: 1}, DayStart: 8, DayEnd: 22})
+	return &dispatchTest{
+		db:         db,
+		users:      users,
+		clock:      c,
+		service:    NewHelpService(log, db, users, fakeEvents{}, c, cfg.Fairness),
+		dispatcher: NewDispatcher(log, db, users, fakeEvents{}, matcher, c, cfg),
+	}
+}
+
+func defaultDispatchConfig() DispatchConfig {
+	return DispatchConfig{
+		WaveSizes:     []int{2, 3},
+		WaveDelays:    []time.Duration{30 * time.Second, time.Minute},
+		Interval:      time.Second,
+		BatchSize:     10,
+		Lease:         10 * time.Second,
+		CandidatePool: 10,
+		LoadWindow:    time.Hour,
+	}
+}
+
+func (dt *dispatchTest) tick(t *testing.T, want int) {
+	t.Helper()
+	if got := dt.dispatcher.Tick(context.Background()); got != want {
+		t.Fatalf("Tick() = %d requests, want %d", got, want)
+	}
+}
+
+func (dt *dispatchTest) checkWaves(t *testing.T, id int64, want ...[]int64) {
+	t.Helper()
+	got := dt.db.wavesOf(id)
+	if len(got) != len(want) {
+		t.Fatalf("request %d: %d waves %v, want %d %v", id, len(got), got, len(want), want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("request %d wave %d: offered %v, want %v", id, i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestDispatcherWaveSizesAndDelays(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 20)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2})
+
+	dt.clock.Advance(29 * time.Second)
+	dt.tick(t, 0)
+
+	dt.clock.Advance(time.Second)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2}, []int64{3, 4, 5})
+
+	// после последней волны повторяются последние размер и задержка
+	dt.clock.Advance(59 * time.Second)
+	dt.tick(t, 0)
+	dt.clock.Advance(time.Second)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2}, []int64{3, 4, 5}, []int64{6, 7, 8})
+
+	dt.clock.Advance(time.Minute)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2}, []int64{3, 4, 5}, []int64{6, 7, 8}, []int64{9, 10, 11})
+}
+
+func TestDispatcherPagesPastExcludedCandidates(t *testing.T) {
+	cfg := defaultDispatchConfig()
+	cfg.CandidatePool = 2
+	dt := newDispatchTest(cfg, 10)
+	// лучшие по рейтингу сейчас на звонках и не должны занимать всю выдачу
+	for id := int64(1); id <= 5; id++ {
+		dt.db.stats[id] = VolunteerStats{OnCall: true}
+	}
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{6, 7})
+}
+
+func TestDispatcherSkipsExcludedCandidates(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 10)
+	dt.users.volunteers[0].Blocked = true
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{2, 3})
+}
+
+func TestDispatcherSkipsVolunteersOverLimits(t *testing.T) {
+	cfg := defaultDispatchConfig()
+	cfg.Fairness = FairnessConfig{MaxCallsPerDay: 3}
+	dt := newDispatchTest(cfg, 10)
+	dt.db.stats[1] = VolunteerStats{OnCall: true}
+	dt.db.stats[2] = VolunteerStats{CallsToday: 3}
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{3, 4})
+}
+
+func TestAcceptRejectsVolunteerOverLimit(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 20)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+	dt.tick(t, 1)
+
+	// волонтёр взял другой звонок после того, как получил предложение
+	dt.db.stats[1] = VolunteerStats{OnCall: true}
+	if err := dt.service.AcceptHelpRequest(context.Background(), 1, 1); !errors.Is(err, ErrVolunteerLimit) {
+		t.Fatalf("AcceptHelpRequest() error = %v, want %v", err, ErrVolunteerLimit)
+	}
+	if err := dt.service.AcceptHelpRequest(context.Background(), 1, 2); err != nil {
+		t.Fatalf("AcceptHelpRequest() error = %v", err)
+	}
+}
+
+func TestDispatcherEmptyWaveKeepsWaveNumber(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 0)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1)
+
+	// волонтёры появились: первая волна всё ещё первая, с её размером
+	dt.users.volunteers = makeVolunteers(5)
+	dt.clock.Advance(30 * time.Second)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2})
+}
+
+func TestDispatcherAdvancesEarlyWithoutAcks(t *testing.T) {
+	cfg := defaultDispatchConfig()
+	cfg.AckTimeout = 10 * time.Second
+	dt := newDispatchTest(cfg, 20)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	dt.clock.Advance(9 * time.Second)
+	dt.tick(t, 0)
+
+	// никто из первой волны не подтвердил доставку - вторая уходит, не дожидаясь 30 секунд
+	dt.clock.Advance(time.Second)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2}, []int64{3, 4, 5})
+
+	// подтверждение во второй волне отменяет досрочную рассылку, третья ждёт обычную минуту
+	if err := dt.service.AcknowledgeOffer(context.Background(), 1, 4, false); err != nil {
+		t.Fatalf("AcknowledgeOffer() error = %v", err)
+	}
+	dt.clock.Advance(10 * time.Second)
+	dt.tick(t, 0)
+	dt.clock.Advance(49 * time.Second)
+	dt.tick(t, 0)
+	dt.clock.Advance(time.Second)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2}, []int64{3, 4, 5}, []int64{6, 7, 8})
+}
+
+func TestDispatcherStopsOnAccept(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 20)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	dt.tick(t, 1)
+	if err := dt.service.AcceptHelpRequest(context.Background(), 1, 2); err != nil {
+		t.Fatalf("AcceptHelpRequest() error = %v", err)
+	}
+
+	dt.clock.Advance(time.Hour)
+	dt.tick(t, 0)
+	dt.checkWaves(t, 1, []int64{1, 2})
+}
+
+func TestDispatcherDropsWaveAcceptedMeanwhile(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 20)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+	dt.tick(t, 1)
+
+	// запрос приняли, пока диспетчер подбирал вторую волну
+	dt.users.onList = func() {
+		dt.db.mu.Lock()
+		dt.db.requests[1].Status = StatusAccepted
+		dt.db.mu.Unlock()
+	}
+	dt.clock.Advance(30 * time.Second)
+	dt.tick(t, 1)
+	dt.checkWaves(t, 1, []int64{1, 2})
+
+	dt.users.onList = nil
+	dt.clock.Advance(time.Hour)
+	dt.tick(t, 0)
+}
+
+func TestDispatcherRun(t *testing.T) {
+	dt := newDispatchTest(defaultDispatchConfig(), 20)
+	dt.db.addRequest(HelpRequest{ID: 1, RequesterID: 100, Status: StatusPending}, start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		dt.dispatcher.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	advance := func() {
+		t.Helper()
+		waitFor(t, func() bool { return dt.clock.Waiters() == 1 })
+		dt.clock.Advance(time.Second)
+	}
+
+	advance()
+	waitFor(t, func() bool { return len(dt.db.wavesOf(1)) == 1 })
+	for range 30 {
+		advance()
+	}
+	// Run снова уснул: вторая волна ушла до того, как он ждёт следующий интервал
+	waitFor(t, func() bool { return dt.clock.Waiters() == 1 })
+	dt.checkWaves(t, 1, []int64{1, 2}, []int64{3, 4, 5})
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,30 @@
+package core
+
+import "errors"
+
+var (
+	ErrBadArguments    = errors.New("bad arguments")
+	ErrSaveHelpRequest = errors.New("failed to save help request")
+	ErrGetHistory      = errors.New("failed to get history")
+	ErrDeleteUserData  = errors.New("failed to delete user data")
+	ErrNotFound        = errors.New("help request not found")
+	ErrGetHelpRequest  = errors.New("failed to get help request")
+	ErrSaveCall        = errors.New("failed to save call")
+	ErrNotCompleted    = errors.New("help request is not completed")
+	ErrNotParticipant  = errors.New("user did not take part in the call")
+	ErrAlreadyRated    = errors.New("help request already rated")
+	ErrSaveRating      = errors.New("failed to save rating")
+	ErrNotRequester    = errors.New("user is not the requester")
+	ErrNotPending      = errors.New("help request is not pending")
+	ErrCancelled       = errors.New("help request is cancelled")
+	ErrCancel          = errors.New("failed to cancel help request")
+	ErrNotOffered      = errors.New("help request was not offered to the volunteer")
+	ErrAccept          = errors.New("failed to accept help request")
+	ErrDispatchLog     = errors.New("failed to get dispatch log")
+	ErrEscalate        = errors.New("failed to escalate help request")
+	ErrVolunteerLimit  = errors.New("volunteer has reached a call limit")
+	ErrVolunteerStats  = errors.New("failed to get volunteer stats")
+	ErrAcknowledge     = errors.New("failed to acknowledge offer")
+	ErrNotAccepted     = errors.New("help request is not accepted")
+	ErrSaveEvent       = errors.New("failed to save event")
+)
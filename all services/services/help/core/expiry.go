@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type ExpiryConfig struct {
+	Interval  time.Duration
+	TTL       time.Duration
+	BatchSize int
+}
+
+// CancelHelpRequest отменяет запрос по просьбе его автора
+func (s *Helpservice) CancelHelpRequest(ctx context.Context, id, requesterID int64) error {
+	request, err := s.db.GetHelpRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", id, "error", err)
+		return ErrGetHelpRequest
+	}
+	if request.RequesterID != requesterID {
+		return ErrNotRequester
+	}
+
+	if _, err := s.db.CloseHelpRequest(ctx, id, StatusCancelled, s.events.HelpRequestClosed); err != nil {
+		if errors.Is(err, ErrNotPending) {
+			return ErrNotPending
+		}
+		s.log.Error("failed to cancel help request", "id", id, "error", err)
+		return ErrCancel
+	}
+
+	s.log.Info("help request cancelled", "id", id, "requester", requesterID)
+
+	return nil
+}
+
+// EscalateHelpRequest - автор просит разослать запрос срочно, не дожидаясь следующей волны
+func (s *Helpservice) EscalateHelpRequest(ctx context.Context, id, requesterID int64) error {
+	request, err := s.db.GetHelpRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", id, "error", err)
+		return ErrGetHelpRequest
+	}
+	if request.RequesterID != requesterID {
+		return ErrNotRequester
+	}
+
+	if err := s.db.EscalateHelpRequest(ctx, id); err != nil {
+		if errors.Is(err, ErrNotPending) {
+			return ErrNotPending
+		}
+		s.log.Error("failed to escalate help request", "id", id, "error", err)
+		return ErrEscalate
+	}
+
+	s.log.Info("help request escalated", "id", id, "requester", requesterID)
+
+	return nil
+}
+
+// Expirer переводит в expired запросы, на которые никто не ответил за TTL
+type Expirer struct {
+	log    *slog.Logger
+	db     DB
+	events Events
+	clock  Clock
+	cfg    ExpiryConfig
+}
+
+func NewExpirer(log *slog.Logger, db DB, events Events, clock Clock, cfg ExpiryConfig) *Expirer {
+	return &Expirer{log: log, db: db, events: events, clock: clock, cfg: cfg}
+}
+
+func (e *Expirer) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.clock.After(e.cfg.Interval):
+			for e.Tick(ctx) == e.cfg.BatchSize {
+			}
+		}
+	}
+}
+
+// Tick переводит в expired одну пачку просроченных запросов и возвращает её размер
+func (e *Expirer) Tick(ctx context.Context) int {
+	expired, err := e.db.ExpireHelpRequests(ctx, e.clock.Now().Add(-e.cfg.TTL), e.cfg.BatchSize, e.events.HelpRequestClosed)
+	if err != nil {
+		e.log.Error("failed to expire help requests", "error", err)
+		return 0
+	}
+
+	for _, request := range expired {
+		e.log.Info("help request expired", "id", request.ID, "requester", request.RequesterID)
+	}
+	return len(expired)
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CallsWindow - за какой период считается дневной лимит звонков
+const CallsWindow = 24 * time.Hour
+
+// FairnessConfig - ограничения, чтобы звонки не доставались одним и тем же волонтёрам.
+// Нулевое значение отключает ограничение.
+type FairnessConfig struct {
+	MaxCallsPerDay int
+	// Cooldown - пауза после окончания звонка, прежде чем волонтёру предложат следующий
+	Cooldown time.Duration
+	// ActiveCallTimeout - сколько принятый запрос без записанного звонка считается идущим звонком
+	ActiveCallTimeout time.Duration
+}
+
+// VolunteerStats - нагрузка волонтёра на момент подбора
+type VolunteerStats struct {
+	// RecentAssignments - запросы, доставшиеся волонтёру за DispatchConfig.LoadWindow
+	RecentAssignments int        `db:"recent_assignments" json:"recentAssignments"`
+	CallsToday        int        `db:"calls_today" json:"callsToday"`
+	LastCallEndedAt   *time.Time `db:"last_call_ended_at" json:"lastCallEndedAt"`
+	OnCall            bool       `db:"on_call" json:"onCall"`
+}
+
+// Limit - почему волонтёру сейчас нельзя предлагать запросы, пустая строка - можно
+func (c FairnessConfig) Limit(stats VolunteerStats, now time.Time) string {
+	switch {
+	case stats.OnCall:
+		return "on an active call"
+	case c.MaxCallsPerDay > 0 && stats.CallsToday >= c.MaxCallsPerDay:
+		return fmt.Sprintf("reached %d calls in the last 24 hours", c.MaxCallsPerDay)
+	case c.Cooldown > 0 && stats.LastCallEndedAt != nil && now.Sub(*stats.LastCallEndedAt) < c.Cooldown:
+		return fmt.Sprintf("cooling down until %s", stats.LastCallEndedAt.Add(c.Cooldown).UTC().Format(time.RFC3339))
+	}
+	return ""
+}
+
+// GetVolunteerStats - нагрузка волонтёра и ограничение, которое сейчас действует, для администратора
+func (s *Helpservice) GetVolunteerStats(ctx context.Context, volunteerID int64) (VolunteerStats, string, error) {
+	now := s.clock.Now()
+	stats, err := getVolunteerStats(ctx, s.db, []int64{volunteerID}, now, 0, s.fairness)
+	if err != nil {
+		s.log.Error("failed to get volunteer stats", "volunteer", volunteerID, "error", err)
+		return VolunteerStats{}, "", ErrVolunteerStats
+	}
+
+	return stats[volunteerID], s.fairness.Limit(stats[volunteerID], now), nil
+}
+
+// getVolunteerStats - нагрузка волонтёров, недавние запросы считаются за loadWindow
+func getVolunteerStats(ctx context.Context, db DB, volunteerIDs []int64, now time.Time, loadWindow time.Duration, fairness FairnessConfig) (map[int64]VolunteerStats, error) {
+	return db.GetVolunteerStats(ctx, volunteerIDs, now.Add(-loadWindow), now.Add(-CallsWindow), now.Add(-fairness.ActiveCallTimeout))
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Candidate - волонтёр вместе с его текущей нагрузкой
+type Candidate struct {
+	Volunteer
+	Stats VolunteerStats
+	// Limit - непустой, если волонтёр упёрся в ограничения FairnessConfig
+	Limit string
+}
+
+type MatchRequest struct {
+	Request   HelpRequest
+	Requester Profile
+	Now       time.Time
+}
+
+// ScoreFactor - вклад одного признака в итоговую оценку: Value от 0 до 1, умноженное на Weight
+type ScoreFactor struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Weight float64 `json:"weight"`
+	Reason string  `json:"reason"`
+}
+
+type Match struct {
+	VolunteerID int64
+	Score       float64
+	// Excluded - кандидата нельзя предлагать ни при какой оценке
+	Excluded bool
+	Factors  []ScoreFactor
+}
+
+// Matcher упорядочивает кандидатов: лучшие первыми, исключённые в конце
+type Matcher interface {
+	Match(request MatchRequest, candidates []Candidate) []Match
+}
+
+const (
+	FactorLanguage = "language"
+	FactorTimezone = "timezone"
+	FactorDaytime  = "daytime"
+	FactorLoad     = "load"
+	FactorRating   = "rating"
+	FactorBlocked  = "blocked"
+	FactorFairness = "fairness"
+	// FactorAvailability - окна доступности и «не беспокоить», влияет только на исключение
+	FactorAvailability = "availability"
+)
+
+type MatchWeights struct {
+	Language float64
+	Timezone float64
+	Daytime  float64
+	Load     float64
+	Rating   float64
+	Fairness float64
+}
+
+// ScoringConfig - настройки стратегии по умолчанию.
+// Дневное время волонтёра - с DayStart до DayEnd часов по его часовому поясу.
+type ScoringConfig struct {
+	Weights  MatchWeights
+	DayStart int
+	DayEnd   int
+	// UnratedScore - оценка рейтинга для волонтёра без оценок, чтобы новички тоже получали запросы
+	UnratedScore float64
+}
+
+// ScoringMatcher - стратегия по умолчанию: взвешенная сумма признаков
+type ScoringMatcher struct {
+	cfg ScoringConfig
+}
+
+func NewScoringMatcher(cfg ScoringConfig) *ScoringMatcher {
+	return &ScoringMatcher{cfg: cfg}
+}
+
+func (m *ScoringMatcher) Match(request MatchRequest, candidates []Candidate) []Match {
+	matches := make([]Match, 0, len(candidates))
+	for _, candidate := range candidates {
+		matches = append(matches, m.score(request, candidate))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Excluded != matches[j].Excluded {
+			return !matches[i].Excluded
+		}
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+func (m *ScoringMatcher) score(request MatchRequest, candidate Candidate) Match {
+	match := Match{VolunteerID: candidate.ID}
+	switch {
+	case candidate.Blocked:
+		match.Excluded = true
+		match.Factors = []ScoreFactor{{Name: FactorBlocked, Reason: "volunteer and requester have blocked each other"}}
+		return match
+	case candidate.DoNotDisturb:
+		match.Excluded = true
+		match.Factors = []ScoreFactor{{Name: FactorAvailability, Reason: "do not disturb"}}
+		return match
+	case candidate.OffSchedule && !request.Request.Urgent:
+		match.Excluded = true
+		match.Factors = []ScoreFactor{{Name: FactorAvailability, Reason: "outside availability windows"}}
+		return match
+	case candidate.Limit != "":
+		match.Excluded = true
+		match.Factors = []ScoreFactor{{Name: FactorFairness, Reason: candidate.Limit}}
+		return match
+	}
+
+	w := m.cfg.Weights
+	if candidate.OffSchedule {
+		match.Factors = append(match.Factors, ScoreFactor{Name: FactorAvailability, Reason: "outside availability windows, request is urgent"})
+	}
+	match.Factors = append(match.Factors,
+		m.language(request.Requester, candidate, w.Language),
+		m.timezone(request, candidate, w.Timezone),
+		m.daytime(request, candidate, w.Daytime),
+		m.load(candidate, w.Load),
+		m.rating(candidate, w.Rating),
+		m.fairness(candidate, w.Fairness),
+	)
+	for _, factor := range match.Factors {
+		match.Score += factor.Value * factor.Weight
+	}
+	return match
+}
+
+func (m *ScoringMatcher) language(requester Profile, candidate Candidate, weight float64) ScoreFactor {
+	factor := ScoreFactor{Name: FactorLanguage, Weight: weight}
+	switch {
+	case len(requester.Languages) == 0:
+		factor.Value = 0.5
+		factor.Reason = "requester has no languages set"
+	case len(candidate.Languages) == 0:
+		factor.Value = 0.5
+		factor.Reason = "volunteer has no languages set"
+	default:
+		for _, language := range requester.Languages {
+			if slices.Contains(candidate.Languages, language) {
+				factor.Value = 1
+				factor.Reason = "speaks " + language
+				return factor
+			}
+		}
+		factor.Reason = "no common language"
+	}
+	return factor
+}
+
+func (m *ScoringMatcher) timezone(request MatchRequest, candidate Candidate, weight float64) ScoreFactor {
+	factor := ScoreFactor{Name: FactorTimezone, Weight: weight}
+	requesterLocation, err1 := time.LoadLocation(request.Requester.Timezone)
+	volunteerLocation, err2 := time.LoadLocation(candidate.Timezone)
+	if request.Requester.Timezone == "" || candidate.Timezone == "" || err1 != nil || err2 != nil {
+		factor.Value = 0.5
+		factor.Reason = "timezone unknown"
+		return factor
+	}
+
+	_, requesterOffset := request.Now.In(requesterLocation).Zone()
+	_, volunteerOffset := request.Now.In(volunteerLocation).Zone()
+	hours := math.Abs(float64(requesterOffset-volunteerOffset)) / 3600
+	if hours > 12 {
+		hours = 24 - hours
+	}
+	factor.Value = 1 - hours/12
+	factor.Reason = fmt.Sprintf("%.1f hours from requester", hours)
+	return factor
+}
+
+func (m *ScoringMatcher) daytime(request MatchRequest, candidate Candidate, weight float64) ScoreFactor {
+	factor := ScoreFactor{Name: FactorDaytime, Weight: weight}
+	location, err := time.LoadLocation(candidate.Timezone)
+	if candidate.Timezone == "" || err != nil {
+		factor.Value = 0.5
+		factor.Reason = "timezone unknown"
+		return factor
+	}
+
+	hour := request.Now.In(location).Hour()
+	if hour >= m.cfg.DayStart && hour < m.cfg.DayEnd {
+		factor.Value = 1
+		factor.Reason = fmt.Sprintf("%02d:00 local time", hour)
+	} else {
+		factor.Reason = fmt.Sprintf("%02d:00 local time, outside daytime", hour)
+	}
+	return factor
+}
+
+func (m *ScoringMatcher) load(candidate Candidate, weight float64) ScoreFactor {
+	return ScoreFactor{
+		Name:   FactorLoad,
+		Weight: weight,
+		Value:  1 / float64(1+candidate.Stats.RecentAssignments),
+		Reason: fmt.Sprintf("%d recent requests", candidate.Stats.RecentAssignments),
+	}
+}
+
+// fairness отдаёт предпочтение тем, кто сегодня звонил меньше
+func (m *ScoringMatcher) fairness(candidate Candidate, weight float64) ScoreFactor {
+	return ScoreFactor{
+		Name:   FactorFairness,
+		Weight: weight,
+		Value:  1 / float64(1+candidate.Stats.CallsToday),
+		Reason: fmt.Sprintf("%d calls in the last 24 hours", candidate.Stats.CallsToday),
+	}
+}
+
+func (m *ScoringMatcher) rating(candidate Candidate, weight float64) ScoreFactor {
+	factor := ScoreFactor{Name: FactorRating, Weight: weight}
+	if candidate.RatingCount == 0 {
+		factor.Value = m.cfg.UnratedScore
+		factor.Reason = "no ratings yet"
+		return factor
+	}
+	factor.Value = (candidate.RatingAverage - MinScore) / (MaxScore - MinScore)
+	factor.Reason = fmt.Sprintf("%.2f average from %d ratings", candidate.RatingAverage, candidate.RatingCount)
+	return factor
+}
@@ -0,0 +1,112 @@
+package core
+
+import "time"
+
+const (
+	StatusPending   = "pending"
+	StatusAccepted  = "accepted"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusExpired   = "expired"
+)
+
+const (
+	MinScore = 1
+	MaxScore = 5
+	MaxTags  = 10
+)
+
+type HelpRequest struct {
+	ID          int64     `db:"id" json:"id"`
+	RequesterID int64     `db:"requester_id" json:"requesterId"`
+	Question    string    `db:"question" json:"question"`
+	Status      string    `db:"status" json:"status"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	VolunteerID *int64    `db:"volunteer_id" json:"volunteerId"`
+	// Wave - сколько волн рассылки уже ушло волонтёрам
+	Wave int `db:"wave" json:"wave"`
+	// Urgent - запрос предлагается и волонтёрам вне их окон доступности
+	Urgent bool `db:"urgent" json:"urgent"`
+}
+
+// Profile - то, что сервис пользователей знает о языках и часовом поясе человека
+type Profile struct {
+	ID        int64
+	Languages []string
+	Timezone  string
+}
+
+// Volunteer - кандидат для рассылки запроса
+type Volunteer struct {
+	Profile
+	RatingAverage float64
+	RatingCount   int64
+	// Blocked - между волонтёром и автором запроса есть блокировка
+	Blocked bool
+	// OffSchedule - сейчас вне окон доступности, которые задал волонтёр
+	OffSchedule bool
+	// DoNotDisturb - волонтёр включил «не беспокоить», это соблюдается и для срочных запросов
+	DoNotDisturb bool
+}
+
+// VolunteerFilter - какие кандидаты нужны для очередной волны
+type VolunteerFilter struct {
+	RequesterID int64
+	ExcludeIDs  []int64
+	Limit       int
+	// Urgent - срочный запрос предлагается и тем, кто вне окон доступности
+	Urgent bool
+	// Languages - языки автора: говорящие на них волонтёры попадают в выдачу раньше остальных
+	Languages []string
+}
+
+// MatchScore - сохранённая оценка кандидата в одной из волн рассылки
+type MatchScore struct {
+	HelpRequestID int64         `db:"help_request_id" json:"helpRequestId"`
+	Wave          int           `db:"wave" json:"wave"`
+	VolunteerID   int64         `db:"volunteer_id" json:"volunteerId"`
+	Score         float64       `db:"score" json:"score"`
+	Offered       bool          `db:"offered" json:"offered"`
+	Excluded      bool          `db:"excluded" json:"excluded"`
+	Factors       []ScoreFactor `db:"-" json:"factors"`
+	CreatedAt     time.Time     `db:"created_at" json:"createdAt"`
+}
+
+type Call struct {
+	ID            int64      `db:"id" json:"id"`
+	HelpRequestID int64      `db:"help_request_id" json:"helpRequestId"`
+	BlindID       int64      `db:"blind_id" json:"blindId"`
+	VolunteerID   *int64     `db:"volunteer_id" json:"volunteerId"`
+	StartedAt     time.Time  `db:"started_at" json:"startedAt"`
+	EndedAt       *time.Time `db:"ended_at" json:"endedAt"`
+}
+
+type Rating struct {
+	ID            int64     `db:"id" json:"id"`
+	HelpRequestID int64     `db:"help_request_id" json:"helpRequestId"`
+	RaterID       int64     `db:"rater_id" json:"raterId"`
+	RateeID       int64     `db:"ratee_id" json:"rateeId"`
+	Score         int       `db:"score" json:"score"`
+	Tags          []string  `db:"-" json:"tags"`
+	Comment       string    `db:"comment" json:"comment"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+}
+
+// RatingSync - волонтёр из очереди пересчёта и итог по его сохранённым оценкам
+type RatingSync struct {
+	VolunteerID int64 `db:"volunteer_id"`
+	Version     int64 `db:"version"`
+	Sum         int64 `db:"rating_sum"`
+	Count       int64 `db:"rating_count"`
+}
+
+// OutboxMessage - событие, сохранённое вместе с изменением состояния
+// и ожидающее отправки в Kafka
+type OutboxMessage struct {
+	ID        int64  `db:"id"`
+	MessageID string `db:"message_id"`
+	Topic     string `db:"topic"`
+	Key       string `db:"key"`
+	Payload   []byte `db:"payload"`
+	Attempts  int    `db:"attempts"`
+}
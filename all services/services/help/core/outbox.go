@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type RelayConfig struct {
+	Interval   time.Duration
+	BatchSize  int
+	Lease      time.Duration
+	MaxBackoff time.Duration
+	Retention  time.Duration
+}
+
+// Relay переносит сообщения из outbox в Kafka. Неудачные попытки
+// повторяются с экспоненциальной задержкой, пока сообщение не уйдёт.
+type Relay struct {
+	log       *slog.Logger
+	outbox    Outbox
+	publisher Publisher
+	clock     Clock
+	cfg       RelayConfig
+}
+
+func NewRelay(log *slog.Logger, outbox Outbox, publisher Publisher, clock Clock, cfg RelayConfig) *Relay {
+	return &Relay{log: log, outbox: outbox, publisher: publisher, clock: clock, cfg: cfg}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(time.Hour)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			if err := r.outbox.DeleteSentOutbox(ctx, r.clock.Now().Add(-r.cfg.Retention)); err != nil {
+				r.log.Error("failed to clean up outbox", "error", err)
+			}
+		case <-ticker.C:
+			// выбираем пачки, пока очередь не опустеет
+			for r.relayBatch(ctx) == r.cfg.BatchSize {
+			}
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) int {
+	messages, err := r.outbox.ClaimOutbox(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		r.log.Error("failed to claim outbox messages", "error", err)
+		return 0
+	}
+
+	// после неудачи остальные сообщения с тем же ключом ждут: иначе потребитель получит их не по порядку
+	failed := make(map[string]bool)
+	for _, message := range messages {
+		if failed[message.Key] {
+			if err := r.outbox.ReleaseOutbox(ctx, message.ID); err != nil {
+				r.log.Error("failed to release outbox message", "id", message.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, message); err != nil {
+			failed[message.Key] = true
+			next := r.clock.Now().Add(r.backoff(message.Attempts))
+			r.log.Error("failed to publish outbox message", "id", message.ID, "attempts", message.Attempts+1, "next_attempt", next, "error", err)
+			if err := r.outbox.MarkOutboxFailed(ctx, message.ID, next, err.Error()); err != nil {
+				r.log.Error("failed to reschedule outbox message", "id", message.ID, "error", err)
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkOutboxSent(ctx, message.ID); err != nil {
+			// сообщение уйдёт повторно после lease: потребители должны быть идемпотентны
+			r.log.Error("failed to mark outbox message sent", "id", message.ID, "error", err)
+		}
+	}
+
+	return len(messages)
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := time.Second << min(attempts, 20)
+	return min(delay, r.cfg.MaxBackoff)
+}
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"seeforme/pkg/clock"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeOutboxRow - строка outbox
+type fakeOutboxRow struct {
+	OutboxMessage
+	sent        bool
+	nextAttempt time.Time
+	lockedUntil time.Time
+	lastError   string
+}
+
+// fakeOutbox повторяет ClaimOutbox из adapters/db: сообщение ждёт, пока более раннее с тем же ключом не ушло
+type fakeOutbox struct {
+	Outbox
+
+	clock *clock.Fake
+	rows  []*fakeOutboxRow
+}
+
+func (o *fakeOutbox) add(key string) {
+	id := int64(len(o.rows) + 1)
+	o.rows = append(o.rows, &fakeOutboxRow{OutboxMessage: OutboxMessage{ID: id, Topic: "help-events", Key: key}, nextAttempt: o.clock.Now()})
+}
+
+func (o *fakeOutbox) row(id int64) *fakeOutboxRow {
+	return o.rows[id-1]
+}
+
+func (o *fakeOutbox) ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error) {
+	now := o.clock.Now()
+	// как в UPDATE ... WHERE id IN (SELECT ...): отбор видит строки до взятия lease
+	var claimed []*fakeOutboxRow
+	for i, row := range o.rows {
+		if len(claimed) == limit {
+			break
+		}
+		if row.sent || row.nextAttempt.After(now) || !row.lockedUntil.Before(now) {
+			continue
+		}
+		waits := slices.ContainsFunc(o.rows[:i], func(earlier *fakeOutboxRow) bool {
+			return earlier.Key == row.Key && !earlier.sent && (earlier.nextAttempt.After(now) || !earlier.lockedUntil.Before(now))
+		})
+		if !waits {
+			claimed = append(claimed, row)
+		}
+	}
+
+	messages := []OutboxMessage{}
+	for _, row := range claimed {
+		row.lockedUntil = now.Add(lease)
+		messages = append(messages, row.OutboxMessage)
+	}
+	return messages, nil
+}
+
+func (o *fakeOutbox) MarkOutboxSent(ctx context.Context, id int64) error {
+	o.row(id).sent = true
+	o.row(id).lockedUntil = time.Time{}
+	return nil
+}
+
+func (o *fakeOutbox) MarkOutboxFailed(ctx context.Context, id int64, nextAttempt time.Time, reason string) error {
+	row := o.row(id)
+	row.Attempts++
+	row.nextAttempt = nextAttempt
+	row.lastError = reason
+	row.lockedUntil = time.Time{}
+	return nil
+}
+
+func (o *fakeOutbox) ReleaseOutbox(ctx context.Context, id int64) error {
+	o.row(id).lockedUntil = time.Time{}
+	return nil
+}
+
+// fakePublisher не может отправить сообщения с ключами из down
+type fakePublisher struct {
+	down      map[string]bool
+	published []int64
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, message OutboxMessage) error {
+	if p.down[message.Key] {
+		return errors.New("kafka is down")
+	}
+	p.published = append(p.published, message.ID)
+	return nil
+}
+
+type relayTest struct {
+	relay     *Relay
+	outbox    *fakeOutbox
+	publisher *fakePublisher
+	clock     *clock.Fake
+}
+
+func newRelayTest() *relayTest {
+	c := clock.NewFake(start)
+	rt := &relayTest{
+		outbox:    &fakeOutbox{clock: c},
+		publisher: &fakePublisher{down: map[string]bool{}},
+		clock:     c,
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	rt.relay = NewRelay(log, rt.outbox, rt.publisher, c, RelayConfig{
+		Interval:   time.Second,
+		BatchSize:  10,
+		Lease:      time.Minute,
+		MaxBackoff: time.Minute,
+	})
+	return rt
+}
+
+func TestRelayPublishesInOrderAndMarksSent(t *testing.T) {
+	rt := newRelayTest()
+	rt.outbox.add("1")
+	rt.outbox.add("2")
+	rt.outbox.add("1")
+
+	if n := rt.relay.relayBatch(context.Background()); n != 3 {
+		t.Fatalf("relayed %d messages, want 3", n)
+	}
+	if !slices.Equal(rt.publisher.published, []int64{1, 2, 3}) {
+		t.Fatalf("published %v, want [1 2 3]", rt.publisher.published)
+	}
+	if n := rt.relay.relayBatch(context.Background()); n != 0 {
+		t.Fatalf("relayed %d messages again", n)
+	}
+}
+
+func TestRelayHoldsKeyAfterFailure(t *testing.T) {
+	rt := newRelayTest()
+	rt.outbox.add("1")
+	rt.outbox.add("2")
+	rt.outbox.add("1")
+	rt.publisher.down["1"] = true
+
+	rt.relay.relayBatch(context.Background())
+	// сообщение 3 не обгоняет неотправленное 1 с тем же ключом
+	if !slices.Equal(rt.publisher.published, []int64{2}) {
+		t.Fatalf("published %v, want [2]", rt.publisher.published)
+	}
+	failed := rt.outbox.row(1)
+	if failed.Attempts != 1 || !failed.nextAttempt.Equal(start.Add(time.Second)) || failed.lastError != "kafka is down" {
+		t.Fatalf("failed message = %+v, want one attempt retried in 1s", failed)
+	}
+	if held := rt.outbox.row(3); held.Attempts != 0 || !held.lockedUntil.IsZero() {
+		t.Fatalf("held message = %+v, want released without an attempt", held)
+	}
+	if n := rt.relay.relayBatch(context.Background()); n != 0 {
+		t.Fatalf("relayed %d messages before backoff", n)
+	}
+
+	rt.publisher.down["1"] = false
+	rt.clock.Advance(time.Second)
+	rt.relay.relayBatch(context.Background())
+	if !slices.Equal(rt.publisher.published, []int64{2, 1, 3}) {
+		t.Fatalf("published %v, want [2 1 3]", rt.publisher.published)
+	}
+}
+
+func TestRelayBackoffIsCapped(t *testing.T) {
+	rt := newRelayTest()
+
+	for attempts, want := range map[int]time.Duration{
+		0:   time.Second,
+		1:   2 * time.Second,
+		5:   32 * time.Second,
+		6:   time.Minute,
+		100: time.Minute,
+	} {
+		if got := rt.relay.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+type DB interface {
+	// event строит сообщение для outbox по уже сохранённому запросу, в той же транзакции
+	SaveHelpRequest(ctx context.Context, request HelpRequest, event func(HelpRequest) (OutboxMessage, error)) (int64, error)
+	// GetHelpRequestsByUser - запросы, которые пользователь создал или принял как волонтёр
+	GetHelpRequestsByUser(ctx context.Context, userID int64) ([]HelpRequest, error)
+	GetCallsByUser(ctx context.Context, userID int64) ([]Call, error)
+	DeleteUserData(ctx context.Context, userID int64) error
+	GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error)
+	// SaveCall записывает звонок и закрывает запрос; ErrNotAccepted - запрос уже не в статусе accepted
+	SaveCall(ctx context.Context, call Call) (int64, error)
+	// SaveRating сохраняет оценку. Если syncRatee, оценённый волонтёр в той же транзакции
+	// ставится в очередь на пересчёт рейтинга в сервисе пользователей.
+	SaveRating(ctx context.Context, rating Rating, syncRatee bool) error
+	// GetRatingsByUser - оценки, которые пользователь поставил и получил
+	GetRatingsByUser(ctx context.Context, userID int64) ([]Rating, error)
+	// CloseHelpRequest переводит запрос из pending в status, ErrNotPending - если он уже не pending
+	CloseHelpRequest(ctx context.Context, id int64, status string, events func(HelpRequest) ([]OutboxMessage, error)) (HelpRequest, error)
+	// ExpireHelpRequests переводит в expired не больше limit запросов, созданных до before
+	ExpireHelpRequests(ctx context.Context, before time.Time, limit int, events func(HelpRequest) ([]OutboxMessage, error)) ([]HelpRequest, error)
+	// ClaimDueWaves отдаёт pending-запросы, которым пора разослать следующую волну,
+	// и откладывает их на lease, чтобы их не взял другой экземпляр
+	ClaimDueWaves(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]HelpRequest, error)
+	GetOfferedVolunteers(ctx context.Context, helpRequestID int64) ([]int64, error)
+	// SaveOffers записывает волну и планирует следующую на nextWaveAt. Если волонтёров нет,
+	// номер волны не меняется. ErrNotPending - запрос уже закрыт или волну разослал кто-то другой.
+	// Вместе с волной сохраняются оценки всех кандидатов, чтобы её можно было разобрать позже.
+	// Если до ackDeadline никто из волны не подтвердит доставку, ClaimDueWaves отдаст запрос раньше nextWaveAt.
+	SaveOffers(ctx context.Context, request HelpRequest, volunteerIDs []int64, matches []Match, nextWaveAt time.Time, ackDeadline *time.Time, event func(HelpRequest, []int64) (OutboxMessage, error)) error
+	// GetVolunteerStats - нагрузка волонтёров: запросы, созданные с assignedSince, звонки с callsSince
+	// и принятые с acceptedSince запросы без записанного звонка. Волонтёров без нагрузки в ответе нет.
+	GetVolunteerStats(ctx context.Context, volunteerIDs []int64, assignedSince, callsSince, acceptedSince time.Time) (map[int64]VolunteerStats, error)
+	GetMatchScores(ctx context.Context, helpRequestID int64) ([]MatchScore, error)
+	HasOffer(ctx context.Context, helpRequestID, volunteerID int64) (bool, error)
+	// AcknowledgeOffer отмечает, что предложение дошло до телефона волонтёра (и открыто, если opened).
+	// ErrNotOffered - запрос волонтёру не предлагался.
+	AcknowledgeOffer(ctx context.Context, helpRequestID, volunteerID int64, opened bool) error
+	AcceptHelpRequest(ctx context.Context, id, volunteerID int64, events func(HelpRequest) ([]OutboxMessage, error)) (HelpRequest, error)
+	// EscalateHelpRequest помечает pending-запрос срочным и планирует следующую волну на сейчас
+	EscalateHelpRequest(ctx context.Context, id int64) error
+	// SaveEvent кладёт событие в outbox, не меняя запрос
+	SaveEvent(ctx context.Context, message OutboxMessage) error
+}
+
+type Outbox interface {
+	ClaimOutbox(ctx context.Context, limit int, lease time.Duration) ([]OutboxMessage, error)
+	MarkOutboxSent(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, nextAttempt time.Time, reason string) error
+	ReleaseOutbox(ctx context.Context, id int64) error
+	DeleteSentOutbox(ctx context.Context, before time.Time) error
+}
+
+// RatingQueue - очередь волонтёров, чей рейтинг нужно переписать в сервисе пользователей
+type RatingQueue interface {
+	ClaimRatingSyncs(ctx context.Context, limit int, lease time.Duration) ([]RatingSync, error)
+	CompleteRatingSync(ctx context.Context, volunteerID, version int64) error
+}
+
+type Events interface {
+	HelpRequestCreated(request HelpRequest) (OutboxMessage, error)
+	HelpRequestOffered(request HelpRequest, volunteerIDs []int64) (OutboxMessage, error)
+	// HelpRequestClosed - события о том, что запрос больше не ждёт волонтёров
+	// (принят, отменён или истёк), для волонтёров и для его автора
+	HelpRequestClosed(request HelpRequest) ([]OutboxMessage, error)
+	// VolunteerConnecting - автору запроса: волонтёр вошёл в комнату звонка
+	VolunteerConnecting(request HelpRequest) (OutboxMessage, error)
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, message OutboxMessage) error
+}
+
+type Users interface {
+	// SetVolunteerRating записывает итог по всем оценкам волонтёра, повтор безопасен
+	SetVolunteerRating(ctx context.Context, volunteerID int64, sum, count int64) error
+	// ListVolunteers - волонтёры, которых сейчас можно беспокоить, лучшие по рейтингу первыми.
+	// Волонтёров с блокировкой, «не беспокоить» и, кроме срочных запросов, вне окон доступности
+	// отсеивает сервис пользователей: они не занимают места в выдаче.
+	ListVolunteers(ctx context.Context, filter VolunteerFilter) ([]Volunteer, error)
+	GetProfile(ctx context.Context, userID int64) (Profile, error)
+}
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type HelpService interface {
+	CreateHelpRequest(ctx context.Context, requesterID int64, question string) (int64, error)
+	GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error)
+	CancelHelpRequest(ctx context.Context, id, requesterID int64) error
+	AcceptHelpRequest(ctx context.Context, id, volunteerID int64) error
+	AcknowledgeOffer(ctx context.Context, id, volunteerID int64, opened bool) error
+	EscalateHelpRequest(ctx context.Context, id, requesterID int64) error
+	GetDispatchLog(ctx context.Context, id int64) ([]MatchScore, error)
+	GetVolunteerStats(ctx context.Context, volunteerID int64) (VolunteerStats, string, error)
+	GetUserHistory(ctx context.Context, userID int64) ([]HelpRequest, []Call, []Rating, error)
+	DeleteUserData(ctx context.Context, userID int64) error
+	RecordCall(ctx context.Context, call Call) (int64, error)
+	VolunteerConnecting(ctx context.Context, id, volunteerID int64) error
+	SubmitRating(ctx context.Context, rating Rating) error
+}
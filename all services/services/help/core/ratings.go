@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z_]{1,32}$`)
+
+func (s *Helpservice) GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error) {
+	request, err := s.db.GetHelpRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return HelpRequest{}, ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", id, "error", err)
+		return HelpRequest{}, ErrGetHelpRequest
+	}
+
+	return request, nil
+}
+
+// RecordCall сохраняет завершённый звонок и закрывает запрос
+func (s *Helpservice) RecordCall(ctx context.Context, call Call) (int64, error) {
+	request, err := s.db.GetHelpRequest(ctx, call.HelpRequestID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return 0, ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", call.HelpRequestID, "error", err)
+		return 0, ErrGetHelpRequest
+	}
+	if call.VolunteerID == nil || call.EndedAt == nil || call.EndedAt.Before(call.StartedAt) {
+		return 0, ErrBadArguments
+	}
+	switch request.Status {
+	case StatusAccepted:
+	case StatusCancelled:
+		return 0, ErrCancelled
+	default:
+		// истёкший запрос никто не принял, а у закрытого звонок уже записан
+		return 0, ErrNotAccepted
+	}
+	// звонок может записать только волонтёр, принявший запрос
+	if request.VolunteerID == nil || *request.VolunteerID != *call.VolunteerID {
+		return 0, ErrNotParticipant
+	}
+	call.BlindID = request.RequesterID
+
+	id, err := s.db.SaveCall(ctx, call)
+	if err != nil {
+		if errors.Is(err, ErrNotAccepted) {
+			return 0, ErrNotAccepted
+		}
+		s.log.Error("failed to save call", "help_request", call.HelpRequestID, "error", err)
+		return 0, ErrSaveCall
+	}
+
+	s.log.Info("call recorded", "id", id, "help_request", call.HelpRequestID)
+
+	return id, nil
+}
+
+// VolunteerConnecting сообщает автору запроса, что волонтёр вошёл в комнату и звонок вот-вот начнётся.
+// Событие идёт через outbox с тем же ключом, что и accept, поэтому автор получит его после accept.
+func (s *Helpservice) VolunteerConnecting(ctx context.Context, id, volunteerID int64) error {
+	request, err := s.db.GetHelpRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", id, "error", err)
+		return ErrGetHelpRequest
+	}
+	if request.Status != StatusAccepted {
+		return ErrNotAccepted
+	}
+	if request.VolunteerID == nil || *request.VolunteerID != volunteerID {
+		return ErrNotParticipant
+	}
+
+	message, err := s.events.VolunteerConnecting(request)
+	if err != nil {
+		s.log.Error("failed to build volunteer connecting event", "id", id, "error", err)
+		return ErrSaveEvent
+	}
+	if err := s.db.SaveEvent(ctx, message); err != nil {
+		s.log.Error("failed to save volunteer connecting event", "id", id, "error", err)
+		return ErrSaveEvent
+	}
+
+	s.log.Info("volunteer connecting", "id", id, "volunteer", volunteerID)
+
+	return nil
+}
+
+// SubmitRating принимает оценку от любой из сторон завершённого звонка.
+// Рейтинг волонтёра в профиле потом пересчитывает RatingSyncer.
+func (s *Helpservice) SubmitRating(ctx context.Context, rating Rating) error {
+	if rating.Score < MinScore || rating.Score > MaxScore || len(rating.Tags) > MaxTags {
+		return ErrBadArguments
+	}
+	for _, tag := range rating.Tags {
+		if !tagPattern.MatchString(tag) {
+			return ErrBadArguments
+		}
+	}
+
+	request, err := s.db.GetHelpRequest(ctx, rating.HelpRequestID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrNotFound
+		}
+		s.log.Error("failed to get help request", "id", rating.HelpRequestID, "error", err)
+		return ErrGetHelpRequest
+	}
+	if request.Status != StatusCompleted || request.VolunteerID == nil {
+		return ErrNotCompleted
+	}
+
+	volunteerRated := false
+	switch rating.RaterID {
+	case request.RequesterID:
+		rating.RateeID = *request.VolunteerID
+		volunteerRated = true
+	case *request.VolunteerID:
+		rating.RateeID = request.RequesterID
+	default:
+		return ErrNotParticipant
+	}
+
+	if err := s.db.SaveRating(ctx, rating, volunteerRated); err != nil {
+		if errors.Is(err, ErrAlreadyRated) {
+			return ErrAlreadyRated
+		}
+		s.log.Error("failed to save rating", "help_request", rating.HelpRequestID, "error", err)
+		return ErrSaveRating
+	}
+
+	s.log.Info("rating submitted", "help_request", rating.HelpRequestID, "rater", rating.RaterID, "score", rating.Score)
+
+	return nil
+}
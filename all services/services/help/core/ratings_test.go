@@ -0,0 +1,321 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"seeforme/pkg/clock"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ratingsDB хранит запросы, звонки и оценки; остальные методы DB не вызываются
+type ratingsDB struct {
+	DB
+
+	requests map[int64]HelpRequest
+	calls    []Call
+	ratings  []Rating
+	// synced - волонтёры, поставленные в очередь пересчёта
+	synced []int64
+	// saveCallErr возвращает SaveCall, как если бы запрос сменил статус после проверки
+	saveCallErr error
+}
+
+func newRatingsDB(requests ...HelpRequest) *ratingsDB {
+	db := &ratingsDB{requests: map[int64]HelpRequest{}}
+	for _, request := range requests {
+		db.requests[request.ID] = request
+	}
+	return db
+}
+
+func (d *ratingsDB) GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error) {
+	request, ok := d.requests[id]
+	if !ok {
+		return HelpRequest{}, ErrNotFound
+	}
+	return request, nil
+}
+
+func (d *ratingsDB) SaveCall(ctx context.Context, call Call) (int64, error) {
+	if d.saveCallErr != nil {
+		return 0, d.saveCallErr
+	}
+	request := d.requests[call.HelpRequestID]
+	request.Status = StatusCompleted
+	d.requests[call.HelpRequestID] = request
+	d.calls = append(d.calls, call)
+	return int64(len(d.calls)), nil
+}
+
+func (d *ratingsDB) SaveRating(ctx context.Context, rating Rating, syncRatee bool) error {
+	for _, saved := range d.ratings {
+		if saved.HelpRequestID == rating.HelpRequestID && saved.RaterID == rating.RaterID {
+			return ErrAlreadyRated
+		}
+	}
+	d.ratings = append(d.ratings, rating)
+	if syncRatee {
+		d.synced = append(d.synced, rating.RateeID)
+	}
+	return nil
+}
+
+func newRatingsService(db DB) *Helpservice {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewHelpService(log, db, &fakeUsers{}, fakeEvents{}, clock.NewFake(start), FairnessConfig{})
+}
+
+func volunteer(id int64) *int64 { return &id }
+
+func call(helpRequestID, volunteerID int64) Call {
+	ended := start.Add(5 * time.Minute)
+	return Call{HelpRequestID: helpRequestID, VolunteerID: volunteer(volunteerID), StartedAt: start, EndedAt: &ended}
+}
+
+func TestRecordCallCompletesAcceptedRequest(t *testing.T) {
+	db := newRatingsDB(HelpRequest{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusAccepted})
+	service := newRatingsService(db)
+
+	if _, err := service.RecordCall(context.Background(), call(1, 7)); err != nil {
+		t.Fatalf("RecordCall: %v", err)
+	}
+	if len(db.calls) != 1 || db.calls[0].BlindID != 100 {
+		t.Fatalf("saved calls = %+v, want one call of blind 100", db.calls)
+	}
+	if status := db.requests[1].Status; status != StatusCompleted {
+		t.Fatalf("status = %s, want %s", status, StatusCompleted)
+	}
+
+	// у закрытого запроса звонок уже записан
+	if _, err := service.RecordCall(context.Background(), call(1, 7)); !errors.Is(err, ErrNotAccepted) {
+		t.Fatalf("second RecordCall = %v, want %v", err, ErrNotAccepted)
+	}
+	if len(db.calls) != 1 {
+		t.Fatalf("saved %d calls, want 1", len(db.calls))
+	}
+}
+
+func TestRecordCallRejectsRequestsNotAccepted(t *testing.T) {
+	for status, want := range map[string]error{
+		StatusPending:   ErrNotAccepted,
+		StatusExpired:   ErrNotAccepted,
+		StatusCompleted: ErrNotAccepted,
+		StatusCancelled: ErrCancelled,
+	} {
+		db := newRatingsDB(HelpRequest{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: status})
+		if _, err := newRatingsService(db).RecordCall(context.Background(), call(1, 7)); !errors.Is(err, want) {
+			t.Errorf("RecordCall on %s request = %v, want %v", status, err, want)
+		}
+		if len(db.calls) != 0 {
+			t.Errorf("call saved for %s request", status)
+		}
+	}
+}
+
+func TestRecordCallRejectsOtherVolunteer(t *testing.T) {
+	db := newRatingsDB(HelpRequest{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusAccepted})
+
+	if _, err := newRatingsService(db).RecordCall(context.Background(), call(1, 8)); !errors.Is(err, ErrNotParticipant) {
+		t.Fatalf("RecordCall = %v, want %v", err, ErrNotParticipant)
+	}
+}
+
+func TestRecordCallLosesRaceWithStatusChange(t *testing.T) {
+	// запрос отменили между проверкой и записью звонка
+	db := newRatingsDB(HelpRequest{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusAccepted})
+	db.saveCallErr = ErrNotAccepted
+
+	if _, err := newRatingsService(db).RecordCall(context.Background(), call(1, 7)); !errors.Is(err, ErrNotAccepted) {
+		t.Fatalf("RecordCall = %v, want %v", err, ErrNotAccepted)
+	}
+}
+
+func TestSubmitRatingRoutesRateeAndQueuesVolunteer(t *testing.T) {
+	db := newRatingsDB(HelpRequest{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusCompleted})
+	service := newRatingsService(db)
+
+	if err := service.SubmitRating(context.Background(), Rating{HelpRequestID: 1, RaterID: 100, Score: 5, Tags: []string{"polite"}}); err != nil {
+		t.Fatalf("requester rating: %v", err)
+	}
+	if err := service.SubmitRating(context.Background(), Rating{HelpRequestID: 1, RaterID: 7, Score: 4}); err != nil {
+		t.Fatalf("volunteer rating: %v", err)
+	}
+
+	if len(db.ratings) != 2 || db.ratings[0].RateeID != 7 || db.ratings[1].RateeID != 100 {
+		t.Fatalf("ratings = %+v, want volunteer 7 and requester 100 rated", db.ratings)
+	}
+	// в сервис пользователей переписывается только рейтинг волонтёра
+	if !slices.Equal(db.synced, []int64{7}) {
+		t.Fatalf("queued for sync %v, want [7]", db.synced)
+	}
+
+	err := service.SubmitRating(context.Background(), Rating{HelpRequestID: 1, RaterID: 100, Score: 1})
+	if !errors.Is(err, ErrAlreadyRated) {
+		t.Fatalf("repeated rating = %v, want %v", err, ErrAlreadyRated)
+	}
+}
+
+func TestSubmitRatingRejectsBadRatings(t *testing.T) {
+	db := newRatingsDB(
+		HelpRequest{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusCompleted},
+		HelpRequest{ID: 2, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusAccepted},
+	)
+	service := newRatingsService(db)
+
+	tests := []struct {
+		name   string
+		rating Rating
+		want   error
+	}{
+		{"score too low", Rating{HelpRequestID: 1, RaterID: 100, Score: MinScore - 1}, ErrBadArguments},
+		{"score too high", Rating{HelpRequestID: 1, RaterID: 100, Score: MaxScore + 1}, ErrBadArguments},
+		{"bad tag", Rating{HelpRequestID: 1, RaterID: 100, Score: 5, Tags: []string{"Not OK"}}, ErrBadArguments},
+		{"too many tags", Rating{HelpRequestID: 1, RaterID: 100, Score: 5, Tags: make([]string, MaxTags+1)}, ErrBadArguments},
+		{"unknown request", Rating{HelpRequestID: 3, RaterID: 100, Score: 5}, ErrNotFound},
+		{"call not finished", Rating{HelpRequestID: 2, RaterID: 100, Score: 5}, ErrNotCompleted},
+		{"stranger", Rating{HelpRequestID: 1, RaterID: 8, Score: 5}, ErrNotParticipant},
+	}
+	for _, tt := range tests {
+		if err := service.SubmitRating(context.Background(), tt.rating); !errors.Is(err, tt.want) {
+			t.Errorf("%s: SubmitRating = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if len(db.ratings) != 0 {
+		t.Fatalf("saved ratings %+v", db.ratings)
+	}
+}
+
+// fakeRatingQueue повторяет rating_syncs: версия растёт с каждой новой оценкой,
+// а итог считается по всем оценкам волонтёра в момент захвата
+type fakeRatingQueue struct {
+	clock   *clock.Fake
+	scores  map[int64][]int
+	version map[int64]int64
+	locked  map[int64]time.Time
+}
+
+func newFakeRatingQueue(c *clock.Fake) *fakeRatingQueue {
+	return &fakeRatingQueue{clock: c, scores: map[int64][]int{}, version: map[int64]int64{}, locked: map[int64]time.Time{}}
+}
+
+func (q *fakeRatingQueue) rate(volunteerID int64, score int) {
+	q.scores[volunteerID] = append(q.scores[volunteerID], score)
+	q.version[volunteerID]++
+}
+
+func (q *fakeRatingQueue) ClaimRatingSyncs(ctx context.Context, limit int, lease time.Duration) ([]RatingSync, error) {
+	var ids []int64
+	for id := range q.version {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	syncs := []RatingSync{}
+	for _, id := range ids {
+		if len(syncs) == limit {
+			break
+		}
+		if q.clock.Now().Before(q.locked[id]) {
+			continue
+		}
+		q.locked[id] = q.clock.Now().Add(lease)
+		sync := RatingSync{VolunteerID: id, Version: q.version[id]}
+		for _, score := range q.scores[id] {
+			sync.Sum += int64(score)
+			sync.Count++
+		}
+		syncs = append(syncs, sync)
+	}
+	return syncs, nil
+}
+
+func (q *fakeRatingQueue) CompleteRatingSync(ctx context.Context, volunteerID, version int64) error {
+	if q.version[volunteerID] == version {
+		delete(q.version, volunteerID)
+	}
+	delete(q.locked, volunteerID)
+	return nil
+}
+
+// ratingUsers запоминает последний записанный рейтинг; failures первых вызовов завершаются ошибкой
+type ratingUsers struct {
+	Users
+
+	mu       sync.Mutex
+	ratings  map[int64][2]int64
+	failures int
+}
+
+func (u *ratingUsers) SetVolunteerRating(ctx context.Context, volunteerID int64, sum, count int64) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.failures > 0 {
+		u.failures--
+		return errors.New("user service is down")
+	}
+	u.ratings[volunteerID] = [2]int64{sum, count}
+	return nil
+}
+
+type ratingSyncTest struct {
+	clock  *clock.Fake
+	queue  *fakeRatingQueue
+	users  *ratingUsers
+	syncer *RatingSyncer
+}
+
+func newRatingSyncTest() *ratingSyncTest {
+	c := clock.NewFake(start)
+	rt := &ratingSyncTest{clock: c, queue: newFakeRatingQueue(c), users: &ratingUsers{ratings: map[int64][2]int64{}}}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	rt.syncer = NewRatingSyncer(log, rt.queue, rt.users, c, RatingSyncConfig{Interval: time.Second, BatchSize: 10, Lease: time.Minute})
+	return rt
+}
+
+func TestRatingSyncerWritesAggregatedRating(t *testing.T) {
+	rt := newRatingSyncTest()
+	rt.queue.rate(7, 5)
+	rt.queue.rate(7, 3)
+	rt.queue.rate(8, 4)
+
+	if n := rt.syncer.Tick(context.Background()); n != 2 {
+		t.Fatalf("Tick synced %d volunteers, want 2", n)
+	}
+	if got := rt.users.ratings[7]; got != [2]int64{8, 2} {
+		t.Fatalf("rating of 7 = %v, want sum 8 of 2", got)
+	}
+	if got := rt.users.ratings[8]; got != [2]int64{4, 1} {
+		t.Fatalf("rating of 8 = %v, want sum 4 of 1", got)
+	}
+	if n := rt.syncer.Tick(context.Background()); n != 0 {
+		t.Fatalf("queue still has %d volunteers", n)
+	}
+}
+
+func TestRatingSyncerRetriesAfterLease(t *testing.T) {
+	rt := newRatingSyncTest()
+	rt.users.failures = 1
+	rt.queue.rate(7, 5)
+
+	rt.syncer.Tick(context.Background())
+	if _, ok := rt.users.ratings[7]; ok {
+		t.Fatalf("rating written despite failure")
+	}
+	// до конца lease волонтёр занят неудачной попыткой
+	if n := rt.syncer.Tick(context.Background()); n != 0 {
+		t.Fatalf("Tick during lease synced %d volunteers", n)
+	}
+
+	rt.clock.Advance(time.Minute)
+	if n := rt.syncer.Tick(context.Background()); n != 1 {
+		t.Fatalf("Tick after lease synced %d volunteers, want 1", n)
+	}
+	if got := rt.users.ratings[7]; got != [2]int64{5, 1} {
+		t.Fatalf("rating of 7 = %v, want sum 5 of 1", got)
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type RatingSyncConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	Lease     time.Duration
+}
+
+// RatingSyncer переписывает рейтинг волонтёров в сервис пользователей. Рейтинг каждый раз
+// считается заново по сохранённым оценкам, поэтому неудачная попытка просто повторяется после lease.
+type RatingSyncer struct {
+	log   *slog.Logger
+	queue RatingQueue
+	users Users
+	clock Clock
+	cfg   RatingSyncConfig
+}
+
+func NewRatingSyncer(log *slog.Logger, queue RatingQueue, users Users, clock Clock, cfg RatingSyncConfig) *RatingSyncer {
+	return &RatingSyncer{log: log, queue: queue, users: users, clock: clock, cfg: cfg}
+}
+
+func (r *RatingSyncer) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.After(r.cfg.Interval):
+			for r.Tick(ctx) == r.cfg.BatchSize {
+			}
+		}
+	}
+}
+
+// Tick пересчитывает одну пачку волонтёров и возвращает её размер
+func (r *RatingSyncer) Tick(ctx context.Context) int {
+	syncs, err := r.queue.ClaimRatingSyncs(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		r.log.Error("failed to claim rating syncs", "error", err)
+		return 0
+	}
+
+	for _, sync := range syncs {
+		if err := r.users.SetVolunteerRating(ctx, sync.VolunteerID, sync.Sum, sync.Count); err != nil {
+			r.log.Error("failed to set volunteer rating", "volunteer", sync.VolunteerID, "retry_in", r.cfg.Lease, "error", err)
+			continue
+		}
+		if err := r.queue.CompleteRatingSync(ctx, sync.VolunteerID, sync.Version); err != nil {
+			r.log.Error("failed to complete rating sync", "volunteer", sync.VolunteerID, "error", err)
+			continue
+		}
+		r.log.Info("volunteer rating synced", "volunteer", sync.VolunteerID, "sum", sync.Sum, "count", sync.Count)
+	}
+
+	return len(syncs)
+}
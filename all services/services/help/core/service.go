@@ -0,0 +1,72 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+)
+
+type Helpservice struct {
+	log      *slog.Logger
+	db       DB
+	users    Users
+	events   Events
+	clock    Clock
+	fairness FairnessConfig
+}
+
+func NewHelpService(log *slog.Logger, db DB, users Users, events Events, clock Clock, fairness FairnessConfig) *Helpservice {
+	return &Helpservice{log: log, db: db, users: users, events: events, clock: clock, fairness: fairness}
+}
+
+func (s *Helpservice) CreateHelpRequest(ctx context.Context, requesterID int64, question string) (int64, error) {
+	if requesterID <= 0 {
+		return 0, ErrBadArguments
+	}
+
+	id, err := s.db.SaveHelpRequest(ctx, HelpRequest{
+		RequesterID: requesterID,
+		Question:    question,
+		Status:      StatusPending,
+	}, s.events.HelpRequestCreated)
+	if err != nil {
+		s.log.Error("failed to save help request", "error", err)
+		return 0, ErrSaveHelpRequest
+	}
+
+	s.log.Info("help request created", "id", id, "requester", requesterID)
+
+	return id, nil
+}
+
+func (s *Helpservice) GetUserHistory(ctx context.Context, userID int64) ([]HelpRequest, []Call, []Rating, error) {
+	requests, err := s.db.GetHelpRequestsByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get help requests", "user", userID, "error", err)
+		return nil, nil, nil, ErrGetHistory
+	}
+
+	calls, err := s.db.GetCallsByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get calls", "user", userID, "error", err)
+		return nil, nil, nil, ErrGetHistory
+	}
+
+	ratings, err := s.db.GetRatingsByUser(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get ratings", "user", userID, "error", err)
+		return nil, nil, nil, ErrGetHistory
+	}
+
+	return requests, calls, ratings, nil
+}
+
+func (s *Helpservice) DeleteUserData(ctx context.Context, userID int64) error {
+	if err := s.db.DeleteUserData(ctx, userID); err != nil {
+		s.log.Error("failed to delete user data", "user", userID, "error", err)
+		return ErrDeleteUserData
+	}
+
+	s.log.Info("user data deleted", "user", userID)
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// historyDB отбирает историю так же, как запросы в adapters/db
+type historyDB struct {
+	DB
+
+	requests []HelpRequest
+	calls    []Call
+	ratings  []Rating
+	err      error
+}
+
+func (d *historyDB) GetHelpRequestsByUser(ctx context.Context, userID int64) ([]HelpRequest, error) {
+	var requests []HelpRequest
+	for _, request := range d.requests {
+		if request.RequesterID == userID || request.VolunteerID != nil && *request.VolunteerID == userID {
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+func (d *historyDB) GetCallsByUser(ctx context.Context, userID int64) ([]Call, error) {
+	var calls []Call
+	for _, call := range d.calls {
+		if call.BlindID == userID || call.VolunteerID != nil && *call.VolunteerID == userID {
+			calls = append(calls, call)
+		}
+	}
+	return calls, nil
+}
+
+func (d *historyDB) GetRatingsByUser(ctx context.Context, userID int64) ([]Rating, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	var ratings []Rating
+	for _, rating := range d.ratings {
+		if rating.RaterID == userID || rating.RateeID == userID {
+			ratings = append(ratings, rating)
+		}
+	}
+	return ratings, nil
+}
+
+func ids[T any](items []T, id func(T) int64) []int64 {
+	var result []int64
+	for _, item := range items {
+		result = append(result, id(item))
+	}
+	return result
+}
+
+func TestGetUserHistoryIncludesVolunteerSide(t *testing.T) {
+	db := &historyDB{
+		requests: []HelpRequest{
+			{ID: 1, RequesterID: 100, VolunteerID: volunteer(7), Status: StatusCompleted},
+			{ID: 2, RequesterID: 7, Status: StatusPending},
+			{ID: 3, RequesterID: 101, VolunteerID: volunteer(8), Status: StatusCompleted},
+		},
+		calls: []Call{
+			{ID: 1, HelpRequestID: 1, BlindID: 100, VolunteerID: volunteer(7)},
+			{ID: 2, HelpRequestID: 3, BlindID: 101, VolunteerID: volunteer(8)},
+		},
+		ratings: []Rating{
+			{ID: 1, HelpRequestID: 1, RaterID: 100, RateeID: 7, Score: 5},
+			{ID: 2, HelpRequestID: 1, RaterID: 7, RateeID: 100, Score: 4},
+			{ID: 3, HelpRequestID: 3, RaterID: 101, RateeID: 8, Score: 3},
+		},
+	}
+
+	// волонтёр 7 принял запрос 1 и сам создал запрос 2
+	requests, calls, ratings, err := newRatingsService(db).GetUserHistory(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetUserHistory: %v", err)
+	}
+	if got := ids(requests, func(r HelpRequest) int64 { return r.ID }); !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("requests = %v, want [1 2]", got)
+	}
+	if got := ids(calls, func(c Call) int64 { return c.ID }); !slices.Equal(got, []int64{1}) {
+		t.Errorf("calls = %v, want [1]", got)
+	}
+	// и полученные, и поставленные оценки
+	if got := ids(ratings, func(r Rating) int64 { return r.ID }); !slices.Equal(got, []int64{1, 2}) {
+		t.Errorf("ratings = %v, want [1 2]", got)
+	}
+}
+
+func TestGetUserHistoryFailsOnStorageError(t *testing.T) {
+	db := &historyDB{err: errors.New("db is down")}
+
+	if _, _, _, err := newRatingsService(db).GetUserHistory(context.Background(), 7); !errors.Is(err, ErrGetHistory) {
+		t.Fatalf("GetUserHistory = %v, want %v", err, ErrGetHistory)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"seeforme/help/adapters/db"
+	"seeforme/help/adapters/kafka"
+	"seeforme/help/adapters/user"
+	"seeforme/help/config"
+	"seeforme/help/core"
+	"seeforme/pkg/clock"
+	_ "time/tzdata" // в образе alpine нет базы часовых поясов
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	helpgrpc "seeforme/help/adapters/grpc"
+	helppb "seeforme/proto/help"
+)
+
+func main() {
+	var configPath string
+	flag.StringVar(&configPath, "config", "config.yaml", "server configuration file")
+	flag.Parse()
+	cfg := config.MustLoad(configPath)
+
+	log := mustMakeLogger(cfg.LogLevel)
+
+	log.Info("starting server")
+	log.Debug("debug messages are enabled")
+
+	storage, err := db.New(log, cfg.DBAddress)
+	if err != nil {
+		log.Error("failed to connect to database", "error", err)
+		return
+	}
+
+	if err := storage.Migrate(); err != nil {
+		log.Error("failed to migrate db", "error", err)
+		return
+	}
+
+	userservice, err := user.NewClient(cfg.UserAddress, log)
+	if err != nil {
+		log.Error("failed to init user adapter", "error", err)
+		return
+	}
+
+	kafkaClient, err := kafka.NewClient(cfg.Kafka.Brokers, cfg.Kafka.HelpTopic, cfg.Kafka.ResponseTopic, log)
+	if err != nil {
+		log.Error("failed to init kafka client", "error", err)
+		return
+	}
+	defer kafkaClient.Close()
+
+	fairness := core.FairnessConfig{
+		MaxCallsPerDay:    cfg.Dispatch.Fairness.MaxCallsPerDay,
+		Cooldown:          cfg.Dispatch.Fairness.Cooldown,
+		ActiveCallTimeout: cfg.Dispatch.Fairness.ActiveCallTimeout,
+	}
+	helpService := core.NewHelpService(log, storage, userservice, kafkaClient, clock.Real{}, fairness)
+
+	listener, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		log.Error("failed to listen", "error", err)
+		return
+	}
+
+	s := grpc.NewServer()
+	helppb.RegisterHelpServer(s, helpgrpc.NewServer(helpService))
+	reflection.Register(s)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	relay := core.NewRelay(log, storage, kafkaClient, clock.Real{}, core.RelayConfig{
+		Interval:   cfg.Outbox.Interval,
+		BatchSize:  cfg.Outbox.BatchSize,
+		Lease:      cfg.Outbox.Lease,
+		MaxBackoff: cfg.Outbox.MaxBackoff,
+		Retention:  cfg.Outbox.Retention,
+	})
+	go relay.Run(ctx)
+
+	expirer := core.NewExpirer(log, storage, kafkaClient, clock.Real{}, core.ExpiryConfig{
+		TTL:       cfg.Expiry.TTL,
+		Interval:  cfg.Expiry.Interval,
+		BatchSize: cfg.Expiry.BatchSize,
+	})
+	go expirer.Run(ctx)
+
+	ratingSyncer := core.NewRatingSyncer(log, storage, userservice, clock.Real{}, core.RatingSyncConfig{
+		Interval:  cfg.RatingSync.Interval,
+		BatchSize: cfg.RatingSync.BatchSize,
+		Lease:     cfg.RatingSync.Lease,
+	})
+	go ratingSyncer.Run(ctx)
+
+	scoring := cfg.Dispatch.Scoring
+	matcher := core.NewScoringMatcher(core.ScoringConfig{
+		Weights: core.MatchWeights{
+			Language: scoring.LanguageWeight,
+			Timezone: scoring.TimezoneWeight,
+			Daytime:  scoring.DaytimeWeight,
+			Load:     scoring.LoadWeight,
+			Rating:   scoring.RatingWeight,
+			Fairness: scoring.FairnessWeight,
+		},
+		DayStart:     scoring.DayStart,
+		DayEnd:       scoring.DayEnd,
+		UnratedScore: scoring.UnratedScore,
+	})
+
+	dispatcher := core.NewDispatcher(log, storage, userservice, kafkaClient, matcher, clock.Real{}, core.DispatchConfig{
+		WaveSizes:     cfg.Dispatch.WaveSizes,
+		WaveDelays:    cfg.Dispatch.WaveDelays,
+		Interval:      cfg.Dispatch.Interval,
+		BatchSize:     cfg.Dispatch.BatchSize,
+		Lease:         cfg.Dispatch.Lease,
+		CandidatePool: cfg.Dispatch.CandidatePool,
+		LoadWindow:    cfg.Dispatch.LoadWindow,
+		AckTimeout:    cfg.Dispatch.AckTimeout,
+		Fairness:      fairness,
+	})
+	go dispatcher.Run(ctx)
+
+	go func() {
+		<-ctx.Done()
+		log.Debug("shutting down server")
+		s.GracefulStop()
+	}()
+
+	if err := s.Serve(listener); err != nil {
+		log.Error("failed to serve", "error", err)
+		return
+	}
+}
+
+func mustMakeLogger(logLevel string) *slog.Logger {
+	var level slog.Level
+	switch logLevel {
+	case "DEBUG":
+		level = slog.LevelDebug
+	case "INFO":
+		level = slog.LevelInfo
+	case "ERROR":
+		level = slog.LevelError
+	default:
+		panic("unknown log level: " + logLevel)
+	}
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	return slog.New(handler)
+}
@@ -0,0 +1,32 @@
+package ru.seeforme.notification.service.api.dto;
+
+import com.fasterxml.jackson.annotation.JsonInclude;
+import lombok.AllArgsConstructor;
+import lombok.Builder;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+import lombok.extern.jackson.Jacksonized;
+
+import java.util.List;
+
+/**
+ * help.offered v1, см. proto/events/events.proto
+ */
+@Data
+@Builder
+@AllArgsConstructor
+@NoArgsConstructor
+@Jacksonized
+@JsonInclude(JsonInclude.Include.NON_NULL)
+public class KafkaHelpOffered {
+
+    private Long helpRequestId;
+
+    private Long requesterId;
+
+    private String question;
+
+    private List<Long> volunteerIds;
+
+    private Integer wave;
+}
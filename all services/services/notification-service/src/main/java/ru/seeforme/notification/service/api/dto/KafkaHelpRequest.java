@@ -1,5 +1,6 @@
 package ru.seeforme.notification.service.api.dto;
 
+import com.fasterxml.jackson.annotation.JsonInclude;
 import lombok.AllArgsConstructor;
 import lombok.Builder;
 import lombok.Data;
@@ -8,14 +9,22 @@ import lombok.extern.jackson.Jacksonized;
 
 import java.time.Instant;
 
+/**
+ * help.requested v1, см. proto/events/events.proto
+ */
 @Data
 @Builder
 @AllArgsConstructor
 @NoArgsConstructor
 @Jacksonized
+@JsonInclude(JsonInclude.Include.NON_NULL)
 public class KafkaHelpRequest {
 
+    private Long helpRequestId;
+
     private Long requestCreatorId;
 
+    private String question;
+
     private Instant createdAt;
 }
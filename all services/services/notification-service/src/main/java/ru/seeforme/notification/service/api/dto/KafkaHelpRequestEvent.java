@@ -0,0 +1,61 @@
+package ru.seeforme.notification.service.api.dto;
+
+import lombok.AllArgsConstructor;
+import lombok.Builder;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+import lombok.extern.jackson.Jacksonized;
+
+import java.time.Instant;
+
+/**
+ * Конверт события из proto/events/events.proto в JSON-представлении protobuf
+ */
+@Data
+@Builder
+@AllArgsConstructor
+@NoArgsConstructor
+@Jacksonized
+public class KafkaHelpRequestEvent {
+
+    public static final String TYPE = "help.requested";
+
+    public static final String OFFERED_TYPE = "help.offered";
+
+    public static final String WITHDRAWN_TYPE = "help.withdrawn";
+
+    public static final int LATEST_VERSION = 1;
+
+    private String id;
+
+    private String type;
+
+    private Integer version;
+
+    private Instant timestamp;
+
+    private KafkaHelpRequest helpRequested;
+
+    private KafkaHelpOffered helpOffered;
+
+    private KafkaHelpWithdrawn helpWithdrawn;
+
+    public boolean isSupported() {
+        if (version == null || version < 1 || version > LATEST_VERSION) {
+            return false;
+        }
+        return isHelpRequested() || isHelpOffered() || isHelpWithdrawn();
+    }
+
+    public boolean isHelpOffered() {
+        return OFFERED_TYPE.equals(type) && helpOffered != null;
+    }
+
+    public boolean isHelpRequested() {
+        return TYPE.equals(type) && helpRequested != null;
+    }
+
+    public boolean isHelpWithdrawn() {
+        return WITHDRAWN_TYPE.equals(type) && helpWithdrawn != null;
+    }
+}
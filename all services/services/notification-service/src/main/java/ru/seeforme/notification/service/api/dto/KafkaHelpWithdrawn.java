@@ -0,0 +1,31 @@
+package ru.seeforme.notification.service.api.dto;
+
+import com.fasterxml.jackson.annotation.JsonInclude;
+import lombok.AllArgsConstructor;
+import lombok.Builder;
+import lombok.Data;
+import lombok.NoArgsConstructor;
+import lombok.extern.jackson.Jacksonized;
+
+/**
+ * help.withdrawn v1, см. proto/events/events.proto
+ */
+@Data
+@Builder
+@AllArgsConstructor
+@NoArgsConstructor
+@Jacksonized
+@JsonInclude(JsonInclude.Include.NON_NULL)
+public class KafkaHelpWithdrawn {
+
+    /**
+     * Значение поля type в data-сообщении FCM: по нему приложение убирает уведомление о запросе
+     */
+    public static final String NOTIFICATION_TYPE = "help_withdrawn";
+
+    private Long helpRequestId;
+
+    private Long requesterId;
+
+    private String reason;
+}
@@ -12,7 +12,7 @@ import org.springframework.kafka.core.ConsumerFactory;
 import org.springframework.kafka.core.DefaultKafkaConsumerFactory;
 import org.springframework.kafka.listener.ConcurrentMessageListenerContainer;
 import org.springframework.kafka.support.serializer.JsonDeserializer;
-import ru.seeforme.notification.service.api.dto.KafkaHelpRequest;
+import ru.seeforme.notification.service.api.dto.KafkaHelpRequestEvent;
 
 import java.util.HashMap;
 import java.util.Map;
@@ -25,20 +25,20 @@ public class KafkaConsumerConfig {
     private final KafkaBootstrapServersConfig kafkaBootstrapServersConfig;
 
     @Bean
-    public ConsumerFactory<String, KafkaHelpRequest> helpRequestConsumerFactory() {
+    public ConsumerFactory<String, KafkaHelpRequestEvent> helpRequestConsumerFactory() {
         Map<String, Object> props = new HashMap<>();
         props.put(ConsumerConfig.BOOTSTRAP_SERVERS_CONFIG, kafkaBootstrapServersConfig.getBootstrapServers());
         props.put(ConsumerConfig.KEY_DESERIALIZER_CLASS_CONFIG, StringDeserializer.class);
         props.put(ConsumerConfig.VALUE_DESERIALIZER_CLASS_CONFIG, JsonDeserializer.class);
-        props.put(JsonDeserializer.VALUE_DEFAULT_TYPE, KafkaHelpRequest.class.getName());
+        props.put(JsonDeserializer.VALUE_DEFAULT_TYPE, KafkaHelpRequestEvent.class.getName());
         return new DefaultKafkaConsumerFactory<>(props);
     }
 
     @Bean
-    public KafkaListenerContainerFactory<ConcurrentMessageListenerContainer<String, KafkaHelpRequest>> helpRequestListenerContainerFactory(
-            ConsumerFactory<String, KafkaHelpRequest> consumerFactory
+    public KafkaListenerContainerFactory<ConcurrentMessageListenerContainer<String, KafkaHelpRequestEvent>> helpRequestListenerContainerFactory(
+            ConsumerFactory<String, KafkaHelpRequestEvent> consumerFactory
     ) {
-        ConcurrentKafkaListenerContainerFactory<String, KafkaHelpRequest> listenerContainerFactory =
+        ConcurrentKafkaListenerContainerFactory<String, KafkaHelpRequestEvent> listenerContainerFactory =
                 new ConcurrentKafkaListenerContainerFactory<>();
         listenerContainerFactory.setConsumerFactory(consumerFactory);
         return listenerContainerFactory;
@@ -1,8 +1,17 @@
 package ru.seeforme.notification.service.core.service;
 
-import ru.seeforme.notification.service.api.dto.KafkaHelpRequest;
+import ru.seeforme.notification.service.api.dto.KafkaHelpOffered;
+import ru.seeforme.notification.service.api.dto.KafkaHelpWithdrawn;
 
 public interface NotificationService {
 
-    void sendHelpRequestNotification(KafkaHelpRequest kafkaHelpRequest);
+    /**
+     * Отправляет запрос каждому волонтёру волны в его личный топик FCM
+     */
+    void sendHelpOfferedNotification(KafkaHelpOffered kafkaHelpOffered);
+
+    /**
+     * Запрос отменён или истёк: волонтёрам уходит data-сообщение, чтобы приложение убрало уведомление
+     */
+    void sendHelpWithdrawnNotification(KafkaHelpWithdrawn kafkaHelpWithdrawn);
 }
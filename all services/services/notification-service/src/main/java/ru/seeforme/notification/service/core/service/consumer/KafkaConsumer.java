@@ -1,13 +1,15 @@
 package ru.seeforme.notification.service.core.service.consumer;
 
 import lombok.RequiredArgsConstructor;
+import lombok.extern.slf4j.Slf4j;
 import org.springframework.kafka.annotation.KafkaListener;
 import org.springframework.messaging.handler.annotation.Payload;
 import org.springframework.stereotype.Component;
-import ru.seeforme.notification.service.api.dto.KafkaHelpRequest;
+import ru.seeforme.notification.service.api.dto.KafkaHelpRequestEvent;
 import ru.seeforme.notification.service.core.service.NotificationService;
 import ru.seeforme.notification.service.core.util.KafkaUtil;
 
+@Slf4j
 @RequiredArgsConstructor
 @Component
 public class KafkaConsumer {
@@ -19,7 +21,20 @@ public class KafkaConsumer {
             groupId = "help-request-group1",
             containerFactory = "helpRequestListenerContainerFactory"
     )
-    public void listenHelpRequestTopic(@Payload KafkaHelpRequest kafkaHelpRequest) {
-        notificationService.sendHelpRequestNotification(kafkaHelpRequest);
+    public void listenHelpRequestTopic(@Payload KafkaHelpRequestEvent event) {
+        if (!event.isSupported()) {
+            log.warn("Skipping unsupported event {} {} v{}", event.getId(), event.getType(), event.getVersion());
+            return;
+        }
+        if (event.isHelpWithdrawn()) {
+            notificationService.sendHelpWithdrawnNotification(event.getHelpWithdrawn());
+            return;
+        }
+        if (event.isHelpOffered()) {
+            notificationService.sendHelpOfferedNotification(event.getHelpOffered());
+            return;
+        }
+        // сам запрос больше не рассылается всем: волонтёры получают его волнами через help.offered
+        log.debug("Help request {} created", event.getHelpRequested().getHelpRequestId());
     }
 }
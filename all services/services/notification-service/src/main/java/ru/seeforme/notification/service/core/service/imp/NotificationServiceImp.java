@@ -6,10 +6,13 @@ import com.google.firebase.messaging.Message;
 import lombok.RequiredArgsConstructor;
 import lombok.extern.slf4j.Slf4j;
 import org.springframework.stereotype.Service;
+import ru.seeforme.notification.service.api.dto.KafkaHelpOffered;
 import ru.seeforme.notification.service.api.dto.KafkaHelpRequest;
+import ru.seeforme.notification.service.api.dto.KafkaHelpWithdrawn;
 import ru.seeforme.notification.service.core.service.NotificationService;
 
 import static ru.seeforme.notification.service.core.util.KafkaUtil.HELP_REQUEST_TOPIC;
+import static ru.seeforme.notification.service.core.util.KafkaUtil.VOLUNTEER_TOPIC_PREFIX;
 import static ru.seeforme.notification.service.core.util.ObjectMapperUtil.objectToMap;
 
 @Slf4j
@@ -20,10 +23,33 @@ public class NotificationServiceImp implements NotificationService {
     private final FirebaseMessaging firebaseMessaging;
 
     @Override
-    public void sendHelpRequestNotification(KafkaHelpRequest kafkaHelpRequest) {
+    public void sendHelpOfferedNotification(KafkaHelpOffered kafkaHelpOffered) {
+        // приложение получает тот же набор полей, что и при прежней общей рассылке
+        KafkaHelpRequest kafkaHelpRequest = KafkaHelpRequest.builder()
+                .helpRequestId(kafkaHelpOffered.getHelpRequestId())
+                .requestCreatorId(kafkaHelpOffered.getRequesterId())
+                .question(kafkaHelpOffered.getQuestion())
+                .build();
+        try {
+            for (Long volunteerId : kafkaHelpOffered.getVolunteerIds()) {
+                Message message = Message.builder()
+                        .putAllData(objectToMap(kafkaHelpRequest))
+                        .setTopic(VOLUNTEER_TOPIC_PREFIX + volunteerId)
+                        .build();
+                String response = firebaseMessaging.send(message);
+                log.info(response);
+            }
+        } catch (FirebaseMessagingException e) {
+            throw new RuntimeException(e);
+        }
+    }
+
+    @Override
+    public void sendHelpWithdrawnNotification(KafkaHelpWithdrawn kafkaHelpWithdrawn) {
         try {
             Message message = Message.builder()
-                    .putAllData(objectToMap(kafkaHelpRequest))
+                    .putAllData(objectToMap(kafkaHelpWithdrawn))
+                    .putData("type", KafkaHelpWithdrawn.NOTIFICATION_TYPE)
                     .setTopic(HELP_REQUEST_TOPIC)
                     .build();
             String response = firebaseMessaging.send(message);
@@ -3,4 +3,9 @@ package ru.seeforme.notification.service.core.util;
 public final class KafkaUtil {
 
     public static final String HELP_REQUEST_TOPIC = "help-request";
+
+    /**
+     * Топик FCM конкретного волонтёра, приложение подписывается на него после входа
+     */
+    public static final String VOLUNTEER_TOPIC_PREFIX = "volunteer-";
 }
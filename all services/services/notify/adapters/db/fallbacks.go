@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"seeforme/notify/core"
+	"time"
+)
+
+func (d *DB) SaveOffer(ctx context.Context, offer core.HelpOffer) error {
+	query := `
+		INSERT INTO offer_texts (help_request_id, requester_id, question) VALUES ($1, $2, $3)
+		ON CONFLICT (help_request_id) DO NOTHING`
+	if _, err := d.conn.ExecContext(ctx, query, offer.HelpRequestID, offer.RequesterID, offer.Question); err != nil {
+		d.log.Error("failed to save offer", "help_request", offer.HelpRequestID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) DeleteOffer(ctx context.Context, helpRequestID int64) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM offer_fallbacks WHERE help_request_id = $1`, helpRequestID); err != nil {
+		d.log.Error("failed to delete offer fallbacks", "help_request", helpRequestID, "error", err)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM offer_texts WHERE help_request_id = $1`, helpRequestID); err != nil {
+		d.log.Error("failed to delete offer", "help_request", helpRequestID, "error", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ClaimUnackedOffers забирает на время lease предложения, которые ушли push в промежутке
+// (sentAfter, sentBefore], но так и не были подтверждены приложением.
+// Законченные и занятые другим экземпляром переотправки пропускаются.
+func (d *DB) ClaimUnackedOffers(ctx context.Context, sentAfter, sentBefore time.Time, limit int, lease time.Duration) ([]core.UnackedOffer, error) {
+	query := `
+		WITH candidates AS (
+			SELECT DISTINCT d.help_request_id, d.user_id FROM deliveries d
+			WHERE d.kind = $1 AND d.channel = $2 AND d.status = $3 AND d.sent_at > $4 AND d.sent_at <= $5
+				AND EXISTS (SELECT 1 FROM offer_texts t WHERE t.help_request_id = d.help_request_id)
+				AND NOT EXISTS (
+					SELECT 1 FROM deliveries a
+					WHERE a.help_request_id = d.help_request_id AND a.user_id = d.user_id AND a.kind = d.kind
+						AND a.status IN ($6, $7, $8)
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM offer_fallbacks f
+					WHERE f.help_request_id = d.help_request_id AND f.user_id = d.user_id
+						AND (f.done_at IS NOT NULL OR f.locked_until >= NOW())
+				)
+			LIMIT $9
+		),
+		claimed AS (
+			INSERT INTO offer_fallbacks (help_request_id, user_id, locked_until)
+			SELECT help_request_id, user_id, NOW() + $10 * INTERVAL '1 millisecond' FROM candidates
+			ON CONFLICT (help_request_id, user_id) DO UPDATE
+			SET attempts = offer_fallbacks.attempts + 1, locked_until = EXCLUDED.locked_until
+			WHERE offer_fallbacks.done_at IS NULL
+				AND (offer_fallbacks.locked_until IS NULL OR offer_fallbacks.locked_until < NOW())
+			RETURNING help_request_id, user_id, attempts
+		)
+		SELECT c.help_request_id, c.user_id, c.attempts, t.requester_id, t.question
+		FROM claimed c
+		JOIN offer_texts t ON t.help_request_id = c.help_request_id`
+
+	offers := []core.UnackedOffer{}
+	err := d.conn.SelectContext(ctx, &offers, query, core.KindHelpOffered, core.ChannelPush, core.DeliverySent,
+		sentAfter, sentBefore, core.DeliveryDelivered, core.DeliveryOpened, core.DeliveryExpired, limit, lease.Milliseconds())
+	if err != nil {
+		d.log.Error("failed to claim unacked offers", "error", err)
+		return nil, err
+	}
+
+	return offers, nil
+}
+
+func (d *DB) CompleteFallback(ctx context.Context, helpRequestID, userID int64) error {
+	query := `UPDATE offer_fallbacks SET done_at = NOW(), locked_until = NULL WHERE help_request_id = $1 AND user_id = $2`
+	if _, err := d.conn.ExecContext(ctx, query, helpRequestID, userID); err != nil {
+		d.log.Error("failed to complete fallback", "help_request", helpRequestID, "user", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
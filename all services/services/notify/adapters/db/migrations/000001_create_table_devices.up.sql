@@ -0,0 +1,8 @@
+CREATE TABLE devices (
+	token TEXT PRIMARY KEY,
+	user_id BIGINT NOT NULL,
+	platform VARCHAR(16) NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX devices_user_id_idx ON devices (user_id);
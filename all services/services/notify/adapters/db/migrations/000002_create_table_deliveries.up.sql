@@ -0,0 +1,9 @@
+CREATE TABLE deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	help_request_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+	token TEXT NOT NULL,
+	kind VARCHAR(32) NOT NULL,
+	sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	UNIQUE (help_request_id, kind, token)
+);
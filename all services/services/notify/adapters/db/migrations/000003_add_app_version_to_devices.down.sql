@@ -0,0 +1,3 @@
+ALTER TABLE devices DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE devices DROP COLUMN IF EXISTS app_version;
+ALTER TABLE devices DROP COLUMN IF EXISTS id;
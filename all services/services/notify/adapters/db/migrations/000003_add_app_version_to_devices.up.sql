@@ -0,0 +1,3 @@
+ALTER TABLE devices ADD COLUMN id BIGSERIAL UNIQUE;
+ALTER TABLE devices ADD COLUMN app_version VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE devices ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS channel_preferences;
+
+DROP INDEX IF EXISTS deliveries_user_id_idx;
+DELETE FROM deliveries WHERE channel <> 'push';
+ALTER TABLE deliveries DROP CONSTRAINT IF EXISTS deliveries_help_request_id_kind_channel_address_key;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS attempts;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS error;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS status;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS channel;
+ALTER TABLE deliveries RENAME COLUMN address TO token;
+ALTER TABLE deliveries ADD CONSTRAINT deliveries_help_request_id_kind_token_key UNIQUE (help_request_id, kind, token);
@@ -0,0 +1,18 @@
+ALTER TABLE deliveries RENAME COLUMN token TO address;
+ALTER TABLE deliveries ADD COLUMN channel VARCHAR(16) NOT NULL DEFAULT 'push';
+ALTER TABLE deliveries ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'sent';
+ALTER TABLE deliveries ADD COLUMN error TEXT NOT NULL DEFAULT '';
+ALTER TABLE deliveries ADD COLUMN attempts INT NOT NULL DEFAULT 1;
+ALTER TABLE deliveries DROP CONSTRAINT deliveries_help_request_id_kind_token_key;
+ALTER TABLE deliveries ADD CONSTRAINT deliveries_help_request_id_kind_channel_address_key
+	UNIQUE (help_request_id, kind, channel, address);
+
+CREATE INDEX deliveries_user_id_idx ON deliveries (user_id);
+
+CREATE TABLE channel_preferences (
+	user_id BIGINT PRIMARY KEY,
+	channels TEXT NOT NULL,
+	phone VARCHAR(16) NOT NULL DEFAULT '',
+	email VARCHAR(254) NOT NULL DEFAULT '',
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
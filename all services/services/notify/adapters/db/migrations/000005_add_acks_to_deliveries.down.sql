@@ -0,0 +1,2 @@
+ALTER TABLE deliveries DROP COLUMN IF EXISTS opened_at;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS delivered_at;
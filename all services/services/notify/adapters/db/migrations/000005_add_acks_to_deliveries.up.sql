@@ -0,0 +1,2 @@
+ALTER TABLE deliveries ADD COLUMN delivered_at TIMESTAMPTZ;
+ALTER TABLE deliveries ADD COLUMN opened_at TIMESTAMPTZ;
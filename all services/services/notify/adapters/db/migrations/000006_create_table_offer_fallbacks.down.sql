@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS deliveries_unacked_offers_idx;
+DROP TABLE IF EXISTS offer_fallbacks;
+DROP TABLE IF EXISTS offer_texts;
@@ -0,0 +1,26 @@
+-- текст предложения помочь: по нему запасные каналы получают то же, что ушло push
+CREATE TABLE offer_texts (
+	help_request_id BIGINT PRIMARY KEY,
+	requester_id BIGINT NOT NULL,
+	question TEXT NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX offer_texts_requester_id_idx ON offer_texts (requester_id);
+
+-- переотправка предложения по каналам после push, если приложение не подтвердило push вовремя.
+-- Строка появляется с первой попыткой, done_at - переотправка закончена и больше не повторяется
+CREATE TABLE offer_fallbacks (
+	help_request_id BIGINT NOT NULL,
+	user_id BIGINT NOT NULL,
+	attempts INT NOT NULL DEFAULT 1,
+	locked_until TIMESTAMPTZ,
+	done_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (help_request_id, user_id)
+);
+
+CREATE INDEX offer_fallbacks_user_id_idx ON offer_fallbacks (user_id);
+
+CREATE INDEX deliveries_unacked_offers_idx ON deliveries (sent_at)
+	WHERE kind = 'help_offered' AND channel = 'push' AND status = 'sent';
@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"seeforme/notify/core"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+type DB struct {
+	log  *slog.Logger
+	conn *sqlx.DB
+}
+
+func New(log *slog.Logger, address string) (*DB, error) {
+	db, err := sqlx.Connect("pgx", address)
+	if err != nil {
+		log.Error("connection problem", "address", address, "error", err)
+		return nil, err
+	}
+
+	return &DB{
+		log:  log,
+		conn: db,
+	}, nil
+}
+
+const deviceColumns = `id, user_id, token, platform, app_version, created_at, updated_at`
+
+func (d *DB) ListDevices(ctx context.Context, userIDs []int64) ([]core.Device, error) {
+	devices := []core.Device{}
+	query := `SELECT ` + deviceColumns + ` FROM devices WHERE user_id = ANY($1) ORDER BY user_id, updated_at DESC`
+	if err := d.conn.SelectContext(ctx, &devices, query, userIDs); err != nil {
+		d.log.Error("failed to list devices", "error", err)
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+func (d *DB) SaveDevice(ctx context.Context, device core.Device) (core.Device, error) {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return core.Device{}, err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO devices (user_id, token, platform, app_version) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (token) DO UPDATE
+		SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, app_version = EXCLUDED.app_version, updated_at = NOW()
+		RETURNING ` + deviceColumns
+	var saved core.Device
+	if err := tx.GetContext(ctx, &saved, query, device.UserID, device.Token, device.Platform, device.AppVersion); err != nil {
+		d.log.Error("failed to save device", "user", device.UserID, "error", err)
+		return core.Device{}, err
+	}
+
+	query = `
+		DELETE FROM devices WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM devices WHERE user_id = $1 ORDER BY updated_at DESC LIMIT $2
+		)`
+	if _, err := tx.ExecContext(ctx, query, device.UserID, core.MaxDevices); err != nil {
+		d.log.Error("failed to trim devices", "user", device.UserID, "error", err)
+		return core.Device{}, err
+	}
+
+	return saved, tx.Commit()
+}
+
+func (d *DB) DeleteDevice(ctx context.Context, userID, id int64) error {
+	result, err := d.conn.ExecContext(ctx, `DELETE FROM devices WHERE user_id = $1 AND id = $2`, userID, id)
+	if err != nil {
+		d.log.Error("failed to delete device", "user", userID, "device", id, "error", err)
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return core.ErrDeviceNotFound
+	}
+
+	return nil
+}
+
+func (d *DB) DeleteDeviceByToken(ctx context.Context, token string) error {
+	if _, err := d.conn.ExecContext(ctx, `DELETE FROM devices WHERE token = $1`, token); err != nil {
+		d.log.Error("failed to delete device", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) DeleteUserData(ctx context.Context, userID int64) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM devices WHERE user_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete devices", "user", userID, "error", err)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM deliveries WHERE user_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete deliveries", "user", userID, "error", err)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM channel_preferences WHERE user_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete preferences", "user", userID, "error", err)
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM offer_fallbacks WHERE user_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete offer fallbacks", "user", userID, "error", err)
+		return err
+	}
+	// текст вопроса - данные автора запроса
+	if _, err := tx.ExecContext(ctx, `DELETE FROM offer_texts WHERE requester_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete offers", "user", userID, "error", err)
+		return err
+	}
+
+	return tx.Commit()
+}
+
+const deliveryColumns = `id, help_request_id, user_id, kind, channel, address, status, error, attempts, sent_at, delivered_at, opened_at`
+
+func (d *DB) GetDeliveries(ctx context.Context, helpRequestID int64, kind string) ([]core.Delivery, error) {
+	deliveries := []core.Delivery{}
+	query := `SELECT ` + deliveryColumns + ` FROM deliveries WHERE help_request_id = $1 AND kind = $2 ORDER BY id`
+	if err := d.conn.SelectContext(ctx, &deliveries, query, helpRequestID, kind); err != nil {
+		d.log.Error("failed to get deliveries", "help_request", helpRequestID, "error", err)
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (d *DB) StartDelivery(ctx context.Context, delivery core.Delivery) (int64, error) {
+	var id int64
+	// пустой DO UPDATE нужен, чтобы RETURNING вернул id уже существующей записи
+	query := `
+		INSERT INTO deliveries (help_request_id, user_id, kind, channel, address, status, attempts)
+		VALUES ($1, $2, $3, $4, $5, $6, 0)
+		ON CONFLICT (help_request_id, kind, channel, address) DO UPDATE SET status = deliveries.status
+		RETURNING id`
+	err := d.conn.GetContext(ctx, &id, query, delivery.HelpRequestID, delivery.UserID, delivery.Kind, delivery.Channel,
+		delivery.Address, core.DeliveryPending)
+	if err != nil {
+		d.log.Error("failed to start delivery", "help_request", delivery.HelpRequestID, "error", err)
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (d *DB) SaveDelivery(ctx context.Context, delivery core.Delivery) error {
+	query := `
+		INSERT INTO deliveries (help_request_id, user_id, kind, channel, address, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (help_request_id, kind, channel, address) DO UPDATE
+		SET status = EXCLUDED.status, error = EXCLUDED.error, attempts = deliveries.attempts + 1, sent_at = NOW()
+		WHERE deliveries.status IN ($8, $9)`
+	_, err := d.conn.ExecContext(ctx, query, delivery.HelpRequestID, delivery.UserID, delivery.Kind, delivery.Channel,
+		delivery.Address, delivery.Status, delivery.Error, core.DeliveryPending, core.DeliveryFailed)
+	if err != nil {
+		d.log.Error("failed to save delivery", "help_request", delivery.HelpRequestID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) AckDelivery(ctx context.Context, userID, id int64, status string) (core.Delivery, error) {
+	var delivery core.Delivery
+	// подтверждение может обогнать ответ FCM, поэтому pending тоже повышается
+	query := `
+		UPDATE deliveries SET
+			status = CASE
+				WHEN status IN ($4, $5) OR status = $6 AND $3 = $7 THEN $3
+				ELSE status
+			END,
+			delivered_at = COALESCE(delivered_at, NOW()),
+			opened_at = CASE WHEN $3 = $7 THEN COALESCE(opened_at, NOW()) ELSE opened_at END
+		WHERE id = $1 AND user_id = $2 AND channel = $8
+		RETURNING ` + deliveryColumns
+	err := d.conn.GetContext(ctx, &delivery, query, id, userID, status,
+		core.DeliveryPending, core.DeliverySent, core.DeliveryDelivered, core.DeliveryOpened, core.ChannelPush)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Delivery{}, core.ErrNotificationNotFound
+		}
+		d.log.Error("failed to ack delivery", "id", id, "error", err)
+		return core.Delivery{}, err
+	}
+
+	return delivery, nil
+}
+
+func (d *DB) ExpireDeliveries(ctx context.Context, helpRequestID int64, kind string) error {
+	query := `UPDATE deliveries SET status = $3 WHERE help_request_id = $1 AND kind = $2 AND status IN ($4, $5, $6)`
+	_, err := d.conn.ExecContext(ctx, query, helpRequestID, kind, core.DeliveryExpired,
+		core.DeliveryPending, core.DeliverySent, core.DeliveryDelivered)
+	if err != nil {
+		d.log.Error("failed to expire deliveries", "help_request", helpRequestID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DB) GetPreferences(ctx context.Context, userIDs []int64) (map[int64]core.Preferences, error) {
+	var rows []struct {
+		UserID   int64  `db:"user_id"`
+		Channels string `db:"channels"`
+		Phone    string `db:"phone"`
+		Email    string `db:"email"`
+	}
+	query := `SELECT user_id, channels, phone, email FROM channel_preferences WHERE user_id = ANY($1)`
+	if err := d.conn.SelectContext(ctx, &rows, query, userIDs); err != nil {
+		d.log.Error("failed to get preferences", "error", err)
+		return nil, err
+	}
+
+	preferences := make(map[int64]core.Preferences, len(rows))
+	for _, row := range rows {
+		preferences[row.UserID] = core.Preferences{
+			UserID:   row.UserID,
+			Channels: strings.Split(row.Channels, ","),
+			Phone:    row.Phone,
+			Email:    row.Email,
+		}
+	}
+	return preferences, nil
+}
+
+func (d *DB) SavePreferences(ctx context.Context, preferences core.Preferences) error {
+	query := `
+		INSERT INTO channel_preferences (user_id, channels, phone, email) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET channels = EXCLUDED.channels, phone = EXCLUDED.phone, email = EXCLUDED.email, updated_at = NOW()`
+	_, err := d.conn.ExecContext(ctx, query, preferences.UserID, strings.Join(preferences.Channels, ","), preferences.Phone, preferences.Email)
+	if err != nil {
+		d.log.Error("failed to save preferences", "user", preferences.UserID, "error", err)
+		return err
+	}
+
+	return nil
+}
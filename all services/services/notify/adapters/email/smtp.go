@@ -0,0 +1,78 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"seeforme/notify/core"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client отправляет письма через SMTP-сервер с аутентификацией PLAIN
+type Client struct {
+	log     *slog.Logger
+	address string
+	auth    smtp.Auth
+	from    mail.Address
+}
+
+func NewClient(host string, port int, username, password, from string, log *slog.Logger) (*Client, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", from, err)
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &Client{
+		log:     log,
+		address: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth:    auth,
+		from:    *sender,
+	}, nil
+}
+
+func (c *Client) Send(ctx context.Context, address string, message core.Message) error {
+	to, err := mail.ParseAddress(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", core.ErrInvalidNotification, err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", c.from.String())
+	fmt.Fprintf(&b, "To: %s\r\n", to.String())
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(message.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	// net/smtp не принимает контекст, поэтому отправка идёт в горутине и прерывается только ожидание
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(c.address, c.auth, c.from.Address, []string{to.Address}, []byte(b.String()))
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+	}
+
+	c.log.Debug("email sent")
+	return nil
+}
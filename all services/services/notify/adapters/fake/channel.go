@@ -0,0 +1,67 @@
+package fake
+
+import (
+	"context"
+	"log/slog"
+	"seeforme/notify/core"
+	"sync"
+	"time"
+)
+
+// Message - сообщение, принятое фейковым каналом
+type Message struct {
+	core.Message
+	Channel string    `json:"channel"`
+	Address string    `json:"address"`
+	SentAt  time.Time `json:"sentAt"`
+}
+
+// Channel - фейковый SMS- или email-канал
+type Channel struct {
+	log      *slog.Logger
+	name     string
+	path     string
+	mu       sync.Mutex
+	sent     []Message
+	failures map[string]error
+}
+
+// NewChannel - name попадает в лог и в файл, path может быть пустым
+func NewChannel(name, path string, log *slog.Logger) *Channel {
+	return &Channel{log: log, name: name, path: path, failures: map[string]error{}}
+}
+
+func (c *Channel) Send(_ context.Context, address string, message core.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err, ok := c.failures[address]; ok {
+		return err
+	}
+
+	sent := Message{Message: message, Channel: c.name, Address: address, SentAt: time.Now()}
+	c.sent = append(c.sent, sent)
+	c.log.Debug("fake message sent", "channel", c.name, "address", address)
+
+	return appendLine(c.log, c.path, sent)
+}
+
+func (c *Channel) Sent() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.sent...)
+}
+
+// Fail - отправки на address будут возвращать err, пока не вызван Reset
+func (c *Channel) Fail(address string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures[address] = err
+}
+
+func (c *Channel) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = nil
+	c.failures = map[string]error{}
+}
@@ -0,0 +1,23 @@
+package fake
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+)
+
+// appendLine дописывает v строкой JSON в файл path; пустой path - ничего не делать
+func appendLine(log *slog.Logger, path string, v interface{}) error {
+	if path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Error("failed to open fake channel file", "path", path, "error", err)
+		return err
+	}
+	defer f.Close()
+
+	return json.NewEncoder(f).Encode(v)
+}
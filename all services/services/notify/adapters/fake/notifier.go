@@ -0,0 +1,84 @@
+// Package fake - каналы доставки без внешних сервисов: уведомления остаются в памяти
+// и при необходимости дописываются в файл JSON Lines, чтобы рассылку можно было проверить офлайн
+package fake
+
+import (
+	"context"
+	"log/slog"
+	"seeforme/notify/core"
+	"sync"
+	"time"
+)
+
+// Sent - уведомление, принятое фейком
+type Sent struct {
+	core.Notification
+	SentAt time.Time `json:"sentAt"`
+}
+
+type Notifier struct {
+	log  *slog.Logger
+	path string
+
+	mu           sync.Mutex
+	sent         []Sent
+	unregistered map[string]bool
+	failures     map[string]error
+}
+
+// NewNotifier - path может быть пустым, тогда уведомления хранятся только в памяти
+func NewNotifier(path string, log *slog.Logger) *Notifier {
+	return &Notifier{
+		log:          log,
+		path:         path,
+		unregistered: map[string]bool{},
+		failures:     map[string]error{},
+	}
+}
+
+func (n *Notifier) Send(_ context.Context, notification core.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.unregistered[notification.Token] {
+		return core.ErrUnregistered
+	}
+	if err, ok := n.failures[notification.Token]; ok {
+		return err
+	}
+
+	sent := Sent{Notification: notification, SentAt: time.Now()}
+	n.sent = append(n.sent, sent)
+	n.log.Debug("fake notification sent", "token", notification.Token, "data", notification.Data)
+
+	return appendLine(n.log, n.path, sent)
+}
+
+// Sent - все принятые уведомления в порядке отправки
+func (n *Notifier) Sent() []Sent {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Sent(nil), n.sent...)
+}
+
+// Unregister - дальнейшие отправки на token вернут core.ErrUnregistered, как FCM для удалённого приложения
+func (n *Notifier) Unregister(token string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.unregistered[token] = true
+}
+
+// Fail - отправки на token будут возвращать err, пока не вызван Reset
+func (n *Notifier) Fail(token string, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failures[token] = err
+}
+
+func (n *Notifier) Reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = nil
+	n.unregistered = map[string]bool{}
+	n.failures = map[string]error{}
+}
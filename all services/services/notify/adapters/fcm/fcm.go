@@ -0,0 +1,90 @@
+package fcm
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"seeforme/notify/core"
+	"strconv"
+	"time"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"google.golang.org/api/option"
+)
+
+// Notifier отправляет уведомления через Firebase Cloud Messaging на токены устройств
+type Notifier struct {
+	log    *slog.Logger
+	client *messaging.Client
+}
+
+// NewNotifier - credentialsFile может быть пустым, тогда используются учётные данные
+// из окружения (GOOGLE_APPLICATION_CREDENTIALS)
+func NewNotifier(ctx context.Context, credentialsFile string, log *slog.Logger) (*Notifier, error) {
+	var opts []option.ClientOption
+	if credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+
+	app, err := firebase.NewApp(ctx, nil, opts...)
+	if err != nil {
+		log.Error("failed to init firebase app", "error", err)
+		return nil, err
+	}
+	client, err := app.Messaging(ctx)
+	if err != nil {
+		log.Error("failed to init firebase messaging", "error", err)
+		return nil, err
+	}
+
+	return &Notifier{log: log, client: client}, nil
+}
+
+func (n *Notifier) Send(ctx context.Context, notification core.Notification) error {
+	id, err := n.client.Send(ctx, message(notification))
+	if err != nil {
+		switch {
+		case messaging.IsUnregistered(err):
+			return fmt.Errorf("%w: %v", core.ErrUnregistered, err)
+		case messaging.IsInvalidArgument(err), messaging.IsSenderIDMismatch(err):
+			return fmt.Errorf("%w: %v", core.ErrInvalidNotification, err)
+		}
+		return err
+	}
+
+	n.log.Debug("notification sent", "message", id)
+	return nil
+}
+
+func message(notification core.Notification) *messaging.Message {
+	m := &messaging.Message{
+		Token: notification.Token,
+		Data:  notification.Data,
+		// уведомление о запросе помощи должно разбудить устройство сразу
+		Android: &messaging.AndroidConfig{Priority: "high"},
+		APNS: &messaging.APNSConfig{
+			Headers: map[string]string{"apns-priority": "10"},
+			Payload: &messaging.APNSPayload{Aps: &messaging.Aps{}},
+		},
+	}
+
+	if notification.Alert != nil {
+		m.Notification = &messaging.Notification{
+			Title: notification.Alert.Title,
+			Body:  notification.Alert.Body,
+		}
+	} else {
+		// data-only: на iOS доставляется только как фоновое, с низким приоритетом
+		m.APNS.Headers["apns-priority"] = "5"
+		m.APNS.Headers["apns-push-type"] = "background"
+		m.APNS.Payload.Aps.ContentAvailable = true
+	}
+
+	if notification.TTL > 0 {
+		ttl := notification.TTL
+		m.Android.TTL = &ttl
+		m.APNS.Headers["apns-expiration"] = strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	}
+	return m
+}
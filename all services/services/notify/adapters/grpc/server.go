@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"seeforme/notify/core"
+	notifypb "seeforme/proto/notify"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Server struct {
+	notifypb.UnimplementedNotifyServer
+	notifyService core.NotifyService
+}
+
+func NewServer(service core.NotifyService) *Server {
+	return &Server{
+		notifyService: service,
+	}
+}
+
+func toDevice(d core.Device) *notifypb.Device {
+	return &notifypb.Device{
+		Id:         d.ID,
+		Token:      d.Token,
+		Platform:   d.Platform,
+		AppVersion: d.AppVersion,
+		CreatedAt:  timestamppb.New(d.CreatedAt),
+		UpdatedAt:  timestamppb.New(d.UpdatedAt),
+	}
+}
+
+func (s *Server) RegisterDevice(ctx context.Context, req *notifypb.RegisterDeviceRequest) (*notifypb.Device, error) {
+	device, err := s.notifyService.RegisterDevice(ctx, core.Device{
+		UserID:     req.GetUserId(),
+		Token:      req.GetToken(),
+		Platform:   req.GetPlatform(),
+		AppVersion: req.GetAppVersion(),
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrBadArguments) {
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		}
+		return nil, status.Error(codes.Internal, "failed to register device")
+	}
+
+	return toDevice(device), nil
+}
+
+func (s *Server) UnregisterDevice(ctx context.Context, req *notifypb.UnregisterDeviceRequest) (*emptypb.Empty, error) {
+	if err := s.notifyService.UnregisterDevice(ctx, req.GetUserId(), req.GetId()); err != nil {
+		if errors.Is(err, core.ErrDeviceNotFound) {
+			return nil, status.Error(codes.NotFound, "device not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to unregister device")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListDevices(ctx context.Context, req *notifypb.ListDevicesRequest) (*notifypb.ListDevicesResponse, error) {
+	devices, err := s.notifyService.ListDevices(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list devices")
+	}
+
+	response := &notifypb.ListDevicesResponse{}
+	for _, d := range devices {
+		response.Devices = append(response.Devices, toDevice(d))
+	}
+	return response, nil
+}
+
+func (s *Server) GetPreferences(ctx context.Context, req *notifypb.GetPreferencesRequest) (*notifypb.Preferences, error) {
+	preferences, err := s.notifyService.GetPreferences(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get preferences")
+	}
+
+	return &notifypb.Preferences{
+		Channels: preferences.Channels,
+		Phone:    preferences.Phone,
+		Email:    preferences.Email,
+	}, nil
+}
+
+func (s *Server) SetPreferences(ctx context.Context, req *notifypb.SetPreferencesRequest) (*emptypb.Empty, error) {
+	err := s.notifyService.SetPreferences(ctx, core.Preferences{
+		UserID:   req.GetUserId(),
+		Channels: req.GetPreferences().GetChannels(),
+		Phone:    req.GetPreferences().GetPhone(),
+		Email:    req.GetPreferences().GetEmail(),
+	})
+	if err != nil {
+		if errors.Is(err, core.ErrBadArguments) {
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		}
+		return nil, status.Error(codes.Internal, "failed to set preferences")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) AckNotification(ctx context.Context, req *notifypb.AckNotificationRequest) (*emptypb.Empty, error) {
+	if err := s.notifyService.AckNotification(ctx, req.GetUserId(), req.GetId(), req.GetStatus()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrBadArguments):
+			return nil, status.Error(codes.InvalidArgument, "bad arguments")
+		case errors.Is(err, core.ErrNotificationNotFound):
+			return nil, status.Error(codes.NotFound, "notification not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to acknowledge notification")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) DeleteUserData(ctx context.Context, req *notifypb.DeleteUserDataRequest) (*emptypb.Empty, error) {
+	if err := s.notifyService.DeleteUserData(ctx, req.GetUserId()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to delete user data")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,40 @@
+package help
+
+import (
+	"context"
+	"log/slog"
+
+	helppb "seeforme/proto/help"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client helppb.HelpClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: helppb.NewHelpClient(conn),
+	}, nil
+}
+
+func (c *Client) AcknowledgeOffer(ctx context.Context, helpRequestID, volunteerID int64, opened bool) error {
+	_, err := c.client.AcknowledgeOffer(ctx, &helppb.AcknowledgeOfferRequest{
+		HelpRequestId: helpRequestID,
+		VolunteerId:   volunteerID,
+		Opened:        opened,
+	})
+	if err != nil {
+		c.log.Error("failed to acknowledge offer", "help_request", helpRequestID, "volunteer", volunteerID, "error", err)
+		return err
+	}
+	return nil
+}
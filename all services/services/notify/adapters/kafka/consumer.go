@@ -0,0 +1,55 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"seeforme/notify/core"
+	"seeforme/pkg/events"
+	"seeforme/pkg/kafkaretry"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Consumer читает события запросов помощи и рассылает по ним уведомления
+type Consumer struct {
+	*kafkaretry.Consumer
+	service *core.Service
+	log     *slog.Logger
+}
+
+func NewConsumer(cfg kafkaretry.Config, service *core.Service, log *slog.Logger) *Consumer {
+	c := &Consumer{
+		service: service,
+		log:     log,
+	}
+	c.Consumer = kafkaretry.NewConsumer(cfg, c.handle, log)
+	return c
+}
+
+func (c *Consumer) handle(ctx context.Context, message kafka.Message) error {
+	envelope, err := events.Unmarshal(message.Value)
+	if err != nil {
+		return kafkaretry.Permanent(fmt.Errorf("decode help event: %w", err))
+	}
+
+	switch {
+	case envelope.GetHelpOffered() != nil:
+		event := envelope.GetHelpOffered()
+		return c.service.NotifyHelpOffered(ctx, core.HelpOffer{
+			HelpRequestID: event.GetHelpRequestId(),
+			RequesterID:   event.GetRequesterId(),
+			Question:      event.GetQuestion(),
+			VolunteerIDs:  event.GetVolunteerIds(),
+		})
+	case envelope.GetHelpWithdrawn() != nil:
+		event := envelope.GetHelpWithdrawn()
+		return c.service.NotifyHelpWithdrawn(ctx, core.HelpWithdrawal{
+			HelpRequestID: event.GetHelpRequestId(),
+			RequesterID:   event.GetRequesterId(),
+			Reason:        event.GetReason(),
+		})
+	}
+
+	return nil
+}
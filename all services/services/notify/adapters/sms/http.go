@@ -0,0 +1,74 @@
+// Package sms отправляет SMS через HTTP API провайдера:
+// POST JSON {"from", "to", "text"} с токеном в заголовке Authorization
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"seeforme/notify/core"
+	"time"
+)
+
+// MaxLength - длиннее провайдер разобьёт сообщение на части, и платить придётся за каждую
+const MaxLength = 320
+
+type Client struct {
+	log    *slog.Logger
+	url    string
+	token  string
+	from   string
+	client *http.Client
+}
+
+func NewClient(url, token, from string, timeout time.Duration, log *slog.Logger) *Client {
+	return &Client{
+		log:    log,
+		url:    url,
+		token:  token,
+		from:   from,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *Client) Send(ctx context.Context, address string, message core.Message) error {
+	text := []rune(message.Text)
+	if len(text) > MaxLength {
+		text = append(text[:MaxLength-1], '…')
+	}
+
+	body, err := json.Marshal(map[string]string{"from": c.from, "to": address, "text": string(text)})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+c.token)
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		reason, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		err := fmt.Errorf("sms provider responded %d: %s", response.StatusCode, reason)
+		// 4xx - проблема в самом сообщении или номере, повтор не поможет (кроме 429)
+		if response.StatusCode < 500 && response.StatusCode != http.StatusTooManyRequests {
+			return fmt.Errorf("%w: %v", core.ErrInvalidNotification, err)
+		}
+		return err
+	}
+
+	c.log.Debug("sms sent")
+	return nil
+}
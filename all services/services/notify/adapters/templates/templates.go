@@ -0,0 +1,132 @@
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"seeforme/notify/core"
+)
+
+//go:embed locales
+var locales embed.FS
+
+// blocks - что должен определять шаблон каждого вида: заголовок и текст push, тема и текст SMS и email
+var blocks = []string{"title", "body", "subject", "text"}
+
+// samples - данные, на которых шаблоны проверяются при запуске, по одному на каждый вид уведомления
+var samples = map[string]any{
+	core.KindHelpOffered: core.HelpOffer{HelpRequestID: 1, RequesterID: 1, Question: "Какого цвета эта рубашка?", VolunteerIDs: []int64{1}},
+}
+
+var funcs = template.FuncMap{
+	"truncate": truncate,
+}
+
+// Templates - тексты уведомлений на разных языках: locales/<язык>/<вид>.tmpl
+type Templates struct {
+	defaultLocale string
+	templates     map[string]map[string]*template.Template
+}
+
+// New разбирает встроенные шаблоны и проверяет, что у каждого языка есть все виды уведомлений
+// и каждый шаблон выполняется на примере данных без ошибок и пустых блоков
+func New(defaultLocale string) (*Templates, error) {
+	return parse(locales, defaultLocale)
+}
+
+func parse(locales fs.FS, defaultLocale string) (*Templates, error) {
+	dirs, err := fs.ReadDir(locales, "locales")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{defaultLocale: defaultLocale, templates: map[string]map[string]*template.Template{}}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		locale := dir.Name()
+		t.templates[locale] = map[string]*template.Template{}
+		for kind, sample := range samples {
+			file := path.Join("locales", locale, kind+".tmpl")
+			tmpl, err := template.New(kind).Option("missingkey=error").Funcs(funcs).ParseFS(locales, file)
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %w", file, err)
+			}
+			for _, block := range blocks {
+				text, err := execute(tmpl, block, sample)
+				if err != nil {
+					return nil, fmt.Errorf("template %s: %w", file, err)
+				}
+				if text == "" {
+					return nil, fmt.Errorf("template %s: block %q is empty", file, block)
+				}
+			}
+			t.templates[locale][kind] = tmpl
+		}
+	}
+
+	if _, ok := t.templates[defaultLocale]; !ok {
+		return nil, fmt.Errorf("no templates for default locale %q", defaultLocale)
+	}
+	return t, nil
+}
+
+// Render берёт первый из языков получателя, для которого есть шаблоны, иначе язык по умолчанию
+func (t *Templates) Render(kind string, languages []string, data any) (core.Alert, core.Message, error) {
+	locale := t.defaultLocale
+	for _, language := range languages {
+		if _, ok := t.templates[normalize(language)]; ok {
+			locale = normalize(language)
+			break
+		}
+	}
+
+	tmpl, ok := t.templates[locale][kind]
+	if !ok {
+		return core.Alert{}, core.Message{}, fmt.Errorf("no template for %s", kind)
+	}
+
+	texts := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		text, err := execute(tmpl, block, data)
+		if err != nil {
+			return core.Alert{}, core.Message{}, err
+		}
+		texts[block] = text
+	}
+
+	alert := core.Alert{Title: texts["title"], Body: texts["body"]}
+	message := core.Message{Subject: texts["subject"], Text: texts["text"]}
+	return alert, message, nil
+}
+
+func execute(tmpl *template.Template, block string, data any) (string, error) {
+	if tmpl.Lookup(block) == nil {
+		return "", fmt.Errorf("block %q is not defined", block)
+	}
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, block, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// normalize сводит ru-RU и RU_ru к ru
+func normalize(language string) string {
+	language, _, _ = strings.Cut(strings.ToLower(language), "-")
+	language, _, _ = strings.Cut(language, "_")
+	return language
+}
+
+func truncate(n int, s string) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
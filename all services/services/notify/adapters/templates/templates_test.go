@@ -0,0 +1,124 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"seeforme/notify/core"
+)
+
+var offer = core.HelpOffer{HelpRequestID: 1, RequesterID: 100, Question: "Какого цвета рубашка?", VolunteerIDs: []int64{1}}
+
+func TestEmbeddedTemplatesParse(t *testing.T) {
+	tmpl, err := New("ru")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for _, locale := range []string{"ru", "en"} {
+		alert, message, err := tmpl.Render(core.KindHelpOffered, []string{locale}, offer)
+		if err != nil {
+			t.Fatalf("Render(%s) error = %v", locale, err)
+		}
+		if alert.Title == "" || alert.Body != offer.Question || message.Subject == "" || !strings.Contains(message.Text, offer.Question) {
+			t.Errorf("Render(%s) = %+v, %+v", locale, alert, message)
+		}
+	}
+}
+
+func TestRenderPicksFirstKnownLanguage(t *testing.T) {
+	tmpl, err := New("ru")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	title := func(languages ...string) string {
+		alert, _, err := tmpl.Render(core.KindHelpOffered, languages, offer)
+		if err != nil {
+			t.Fatalf("Render(%v) error = %v", languages, err)
+		}
+		return alert.Title
+	}
+
+	if got, want := title("de", "en-GB", "ru"), title("en"); got != want {
+		t.Errorf("title for [de en-GB ru] = %q, want English %q", got, want)
+	}
+	if got, want := title("EN_us"), title("en"); got != want {
+		t.Errorf("title for EN_us = %q, want English %q", got, want)
+	}
+	// неизвестные языки и пустой список - язык по умолчанию
+	if got, want := title("de"), title("ru"); got != want {
+		t.Errorf("title for [de] = %q, want default %q", got, want)
+	}
+	if got, want := title(), title("ru"); got != want {
+		t.Errorf("title without languages = %q, want default %q", got, want)
+	}
+}
+
+func TestRenderTruncatesLongQuestion(t *testing.T) {
+	tmpl, err := New("ru")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	long := offer
+	long.Question = strings.Repeat("я", 300)
+
+	alert, _, err := tmpl.Render(core.KindHelpOffered, nil, long)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if runes := []rune(alert.Body); len(runes) != 200 || runes[199] != '…' {
+		t.Fatalf("body has %d runes ending with %q, want 200 ending with …", len(runes), runes[len(runes)-1])
+	}
+}
+
+const validTemplate = `{{define "title"}}T{{end}}{{define "body"}}{{.Question}}{{end}}{{define "subject"}}S{{end}}{{define "text"}}{{.Question}}{{end}}`
+
+func TestNewRejectsBrokenTemplates(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  string
+	}{
+		{
+			name: "missing kind",
+			files: fstest.MapFS{
+				"locales/ru/help_offered.tmpl": {Data: []byte(validTemplate)},
+				"locales/en/other.tmpl":        {Data: []byte(validTemplate)},
+			},
+			want: "locales/en/help_offered.tmpl",
+		},
+		{
+			name:  "missing block",
+			files: fstest.MapFS{"locales/ru/help_offered.tmpl": {Data: []byte(`{{define "title"}}T{{end}}`)}},
+			want:  `block "body" is not defined`,
+		},
+		{
+			name:  "empty block",
+			files: fstest.MapFS{"locales/ru/help_offered.tmpl": {Data: []byte(strings.Replace(validTemplate, "S", " ", 1))}},
+			want:  `block "subject" is empty`,
+		},
+		{
+			name:  "unknown field",
+			files: fstest.MapFS{"locales/ru/help_offered.tmpl": {Data: []byte(strings.Replace(validTemplate, ".Question", ".Name", 1))}},
+			want:  "Name",
+		},
+		{
+			name:  "syntax error",
+			files: fstest.MapFS{"locales/ru/help_offered.tmpl": {Data: []byte(`{{define "title"}}`)}},
+			want:  "locales/ru/help_offered.tmpl",
+		},
+		{
+			name:  "no default locale",
+			files: fstest.MapFS{"locales/en/help_offered.tmpl": {Data: []byte(validTemplate)}},
+			want:  `default locale "ru"`,
+		},
+	}
+
+	for _, tt := range tests {
+		_, err := parse(tt.files, "ru")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: parse() error = %v, want one mentioning %q", tt.name, err, tt.want)
+		}
+	}
+}
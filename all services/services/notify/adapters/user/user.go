@@ -0,0 +1,36 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+
+	userpb "seeforme/proto/user"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client userpb.UserClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: userpb.NewUserClient(conn),
+	}, nil
+}
+
+func (c *Client) GetLanguages(ctx context.Context, userID int64) ([]string, error) {
+	response, err := c.client.GetUser(ctx, &userpb.GetUserRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to get user", "user", userID, "error", err)
+		return nil, err
+	}
+	return response.GetLanguages(), nil
+}
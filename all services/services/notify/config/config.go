@@ -0,0 +1,100 @@
+package config
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+type Kafka struct {
+	Brokers     []string        `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092"`
+	HelpTopic   string          `yaml:"help_topic" env:"KAFKA_HELP_TOPIC" env-default:"help-request"`
+	GroupID     string          `yaml:"group_id" env:"KAFKA_GROUP_ID" env-default:"notify"`
+	RetryDelays []time.Duration `yaml:"retry_delays" env:"KAFKA_RETRY_DELAYS" env-default:"10s,1m,10m"`
+}
+
+// Notifier - куда уходят уведомления: fcm или fake (в память и в FakeFile, для офлайн-проверки)
+type Notifier struct {
+	Driver          string `yaml:"driver" env:"NOTIFIER_DRIVER" env-default:"fcm"`
+	CredentialsFile string `yaml:"credentials_file" env:"GOOGLE_APPLICATION_CREDENTIALS"`
+	FakeFile        string `yaml:"fake_file" env:"NOTIFIER_FAKE_FILE"`
+	// OfferTTL - сколько FCM пытается доставить предложение помочь, не больше срока жизни запроса
+	OfferTTL time.Duration `yaml:"offer_ttl" env:"NOTIFIER_OFFER_TTL" env-default:"10m"`
+}
+
+// SMS - HTTP API провайдера SMS, см. adapters/sms
+type SMS struct {
+	Driver  string        `yaml:"driver" env:"SMS_DRIVER" env-default:"none"`
+	URL     string        `yaml:"url" env:"SMS_URL"`
+	Token   string        `yaml:"token" env:"SMS_TOKEN"`
+	From    string        `yaml:"from" env:"SMS_FROM" env-default:"SeeForMe"`
+	Timeout time.Duration `yaml:"timeout" env:"SMS_TIMEOUT" env-default:"10s"`
+}
+
+type Email struct {
+	Driver   string `yaml:"driver" env:"EMAIL_DRIVER" env-default:"none"`
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"EMAIL_FROM" env-default:"SeeForMe <noreply@seeforme.ru>"`
+}
+
+// Channels - запасные каналы: none - канал выключен, fake - сообщения пишутся в FakeFile
+type Channels struct {
+	SMS      SMS    `yaml:"sms"`
+	Email    Email  `yaml:"email"`
+	FakeFile string `yaml:"fake_file" env:"CHANNELS_FAKE_FILE"`
+}
+
+// Fallback - если приложение не подтвердило push за AckTimeout, предложение помочь
+// уходит по следующим каналам из настроек волонтёра
+type Fallback struct {
+	AckTimeout  time.Duration `yaml:"ack_timeout" env:"FALLBACK_ACK_TIMEOUT" env-default:"1m"`
+	Interval    time.Duration `yaml:"interval" env:"FALLBACK_INTERVAL" env-default:"10s"`
+	BatchSize   int           `yaml:"batch_size" env:"FALLBACK_BATCH_SIZE" env-default:"100"`
+	Lease       time.Duration `yaml:"lease" env:"FALLBACK_LEASE" env-default:"30s"`
+	MaxAttempts int           `yaml:"max_attempts" env:"FALLBACK_MAX_ATTEMPTS" env-default:"3"`
+}
+
+type Config struct {
+	LogLevel  string `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	Address   string `yaml:"notify_address" env:"NOTIFY_ADDRESS" env-default:"localhost:84"`
+	DBAddress string `yaml:"db_address" env:"DB_ADDRESS" env-default:"localhost:82"`
+	// HelpAddress - сервис помощи, ему передаются подтверждения доставки предложений
+	HelpAddress string `yaml:"help_address" env:"HELP_ADDRESS" env-default:"localhost:83"`
+	// UserAddress - сервис пользователей, из него берутся языки получателей
+	UserAddress string `yaml:"user_address" env:"USER_ADDRESS" env-default:"localhost:81"`
+	// DefaultLocale - язык уведомлений для пользователей, чьих языков нет среди шаблонов
+	DefaultLocale string   `yaml:"default_locale" env:"DEFAULT_LOCALE" env-default:"ru"`
+	Kafka         Kafka    `yaml:"kafka"`
+	Notifier      Notifier `yaml:"notifier"`
+	Channels      Channels `yaml:"channels"`
+	Fallback      Fallback `yaml:"fallback"`
+}
+
+func MustLoad(configPath string) Config {
+	var cfg Config
+	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+		if err := cleanenv.ReadEnv(&cfg); err != nil {
+			log.Fatalf("cannot read config %q: %s", configPath, err)
+		}
+	}
+
+	if len(cfg.Kafka.Brokers) == 1 && strings.Contains(cfg.Kafka.Brokers[0], ",") {
+		cfg.Kafka.Brokers = strings.Split(cfg.Kafka.Brokers[0], ",")
+	}
+	if cfg.Notifier.Driver != "fcm" && cfg.Notifier.Driver != "fake" {
+		log.Fatalf("unknown notifier driver %q", cfg.Notifier.Driver)
+	}
+	if !slices.Contains([]string{"none", "http", "fake"}, cfg.Channels.SMS.Driver) {
+		log.Fatalf("unknown sms driver %q", cfg.Channels.SMS.Driver)
+	}
+	if !slices.Contains([]string{"none", "smtp", "fake"}, cfg.Channels.Email.Driver) {
+		log.Fatalf("unknown email driver %q", cfg.Channels.Email.Driver)
+	}
+	return cfg
+}
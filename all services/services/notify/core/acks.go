@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// AckNotification принимает подтверждение от приложения: уведомление дошло (delivered) или открыто (opened).
+// Подтверждения предложений помочь передаются сервису помощи, чтобы он не ждал ответа от телефонов,
+// до которых уведомление не дошло.
+func (s *Service) AckNotification(ctx context.Context, userID, id int64, status string) error {
+	if userID <= 0 || id <= 0 || (status != DeliveryDelivered && status != DeliveryOpened) {
+		return ErrBadArguments
+	}
+
+	delivery, err := s.db.AckDelivery(ctx, userID, id, status)
+	if err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			return ErrNotificationNotFound
+		}
+		s.log.Error("failed to ack delivery", "user", userID, "notification", id, "error", err)
+		return ErrAck
+	}
+
+	s.log.Debug("notification acknowledged", "user", userID, "notification", id, "status", delivery.Status)
+
+	if delivery.Kind != KindHelpOffered {
+		return nil
+	}
+	// подтверждение идемпотентно, при ошибке приложение пришлёт его ещё раз
+	if err := s.help.AcknowledgeOffer(ctx, delivery.HelpRequestID, userID, status == DeliveryOpened); err != nil {
+		s.log.Error("failed to pass ack to help service", "help_request", delivery.HelpRequestID, "user", userID, "error", err)
+		return ErrAck
+	}
+
+	return nil
+}
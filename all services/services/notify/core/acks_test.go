@@ -0,0 +1,141 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"seeforme/notify/core"
+	"slices"
+	"testing"
+)
+
+// AckDelivery повторяет UPDATE в adapters/db: статус только растёт, expired не меняется
+func (d *fakeDB) AckDelivery(ctx context.Context, userID, id int64, status string) (core.Delivery, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := slices.IndexFunc(d.deliveries, func(delivery core.Delivery) bool {
+		return delivery.ID == id && delivery.UserID == userID && delivery.Channel == core.ChannelPush
+	})
+	if i < 0 {
+		return core.Delivery{}, core.ErrNotificationNotFound
+	}
+	delivery := &d.deliveries[i]
+	switch delivery.Status {
+	case core.DeliveryPending, core.DeliverySent:
+		delivery.Status = status
+	case core.DeliveryDelivered:
+		if status == core.DeliveryOpened {
+			delivery.Status = status
+		}
+	}
+	return *delivery, nil
+}
+
+// newAckTest - предложение ушло push волонтёрам 1 и 2, id доставок 1 и 2
+func newAckTest(t *testing.T) *notifyTest {
+	t.Helper()
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "phone-1")
+	nt.db.addDevice(2, "phone-2")
+	if err := nt.service.NotifyHelpOffered(context.Background(), offer); err != nil {
+		t.Fatalf("NotifyHelpOffered() error = %v", err)
+	}
+	return nt
+}
+
+func TestAckNotificationPassesOfferAckToHelp(t *testing.T) {
+	nt := newAckTest(t)
+
+	if err := nt.service.AckNotification(context.Background(), 1, 1, core.DeliveryDelivered); err != nil {
+		t.Fatalf("AckNotification(delivered) error = %v", err)
+	}
+	if err := nt.service.AckNotification(context.Background(), 1, 1, core.DeliveryOpened); err != nil {
+		t.Fatalf("AckNotification(opened) error = %v", err)
+	}
+
+	want := []fakeAck{
+		{helpRequestID: 1, volunteerID: 1, opened: false},
+		{helpRequestID: 1, volunteerID: 1, opened: true},
+	}
+	if !slices.Equal(nt.help.acks, want) {
+		t.Fatalf("acks = %+v, want %+v", nt.help.acks, want)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-1"); got.Status != core.DeliveryOpened {
+		t.Fatalf("delivery = %+v, want opened", got)
+	}
+}
+
+func TestAckNotificationNeverLowersStatus(t *testing.T) {
+	nt := newAckTest(t)
+
+	for _, status := range []string{core.DeliveryOpened, core.DeliveryDelivered} {
+		if err := nt.service.AckNotification(context.Background(), 1, 1, status); err != nil {
+			t.Fatalf("AckNotification(%s) error = %v", status, err)
+		}
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-1"); got.Status != core.DeliveryOpened {
+		t.Fatalf("delivery = %+v, want opened", got)
+	}
+}
+
+func TestAckNotificationRejectsForeignAndUnknownNotifications(t *testing.T) {
+	nt := newAckTest(t)
+
+	// доставка 2 принадлежит волонтёру 2
+	for _, id := range []int64{2, 99} {
+		if err := nt.service.AckNotification(context.Background(), 1, id, core.DeliveryDelivered); !errors.Is(err, core.ErrNotificationNotFound) {
+			t.Errorf("AckNotification(%d) error = %v, want %v", id, err, core.ErrNotificationNotFound)
+		}
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-2"); got.Status != core.DeliverySent {
+		t.Errorf("foreign delivery = %+v, want still sent", got)
+	}
+	if len(nt.help.acks) != 0 {
+		t.Fatalf("acks passed to help: %+v", nt.help.acks)
+	}
+}
+
+func TestAckNotificationRejectsBadArguments(t *testing.T) {
+	nt := newAckTest(t)
+
+	for _, tt := range []struct {
+		userID, id int64
+		status     string
+	}{
+		{0, 1, core.DeliveryDelivered},
+		{1, 0, core.DeliveryDelivered},
+		{1, 1, core.DeliverySent},
+		{1, 1, core.DeliveryExpired},
+	} {
+		if err := nt.service.AckNotification(context.Background(), tt.userID, tt.id, tt.status); !errors.Is(err, core.ErrBadArguments) {
+			t.Errorf("AckNotification(%d, %d, %q) error = %v, want %v", tt.userID, tt.id, tt.status, err, core.ErrBadArguments)
+		}
+	}
+}
+
+func TestAckNotificationReportsHelpFailure(t *testing.T) {
+	nt := newAckTest(t)
+	nt.help.err = errors.New("help is down")
+
+	// приложение повторит подтверждение, а статус уже сохранён
+	if err := nt.service.AckNotification(context.Background(), 1, 1, core.DeliveryOpened); !errors.Is(err, core.ErrAck) {
+		t.Fatalf("AckNotification() error = %v, want %v", err, core.ErrAck)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-1"); got.Status != core.DeliveryOpened {
+		t.Fatalf("delivery = %+v, want opened", got)
+	}
+}
+
+func TestAckNotificationOfWithdrawalStaysInNotify(t *testing.T) {
+	nt := newAckTest(t)
+	if err := nt.service.NotifyHelpWithdrawn(context.Background(), core.HelpWithdrawal{HelpRequestID: 1, RequesterID: 100, Reason: "cancelled"}); err != nil {
+		t.Fatalf("NotifyHelpWithdrawn() error = %v", err)
+	}
+	withdrawal := nt.db.delivery(1, core.KindHelpWithdrawn, "phone-1")
+
+	if err := nt.service.AckNotification(context.Background(), 1, withdrawal.ID, core.DeliveryDelivered); err != nil {
+		t.Fatalf("AckNotification() error = %v", err)
+	}
+	if len(nt.help.acks) != 0 {
+		t.Fatalf("withdrawal ack passed to help: %+v", nt.help.acks)
+	}
+}
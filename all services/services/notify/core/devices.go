@@ -0,0 +1,84 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+func (s *Service) RegisterDevice(ctx context.Context, device Device) (Device, error) {
+	if !device.Valid() {
+		return Device{}, ErrBadArguments
+	}
+
+	device, err := s.db.SaveDevice(ctx, device)
+	if err != nil {
+		s.log.Error("failed to save device", "user", device.UserID, "error", err)
+		return Device{}, ErrDevices
+	}
+
+	s.log.Info("device registered", "user", device.UserID, "device", device.ID, "platform", device.Platform)
+
+	return device, nil
+}
+
+func (s *Service) UnregisterDevice(ctx context.Context, userID, id int64) error {
+	if err := s.db.DeleteDevice(ctx, userID, id); err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			return ErrDeviceNotFound
+		}
+		s.log.Error("failed to delete device", "user", userID, "device", id, "error", err)
+		return ErrDevices
+	}
+
+	s.log.Info("device unregistered", "user", userID, "device", id)
+
+	return nil
+}
+
+func (s *Service) ListDevices(ctx context.Context, userID int64) ([]Device, error) {
+	devices, err := s.db.ListDevices(ctx, []int64{userID})
+	if err != nil {
+		s.log.Error("failed to list devices", "user", userID, "error", err)
+		return nil, ErrDevices
+	}
+
+	return devices, nil
+}
+
+// DeleteUserData удаляет устройства пользователя, настройки каналов и историю отправленных ему уведомлений
+func (s *Service) DeleteUserData(ctx context.Context, userID int64) error {
+	if err := s.db.DeleteUserData(ctx, userID); err != nil {
+		s.log.Error("failed to delete user data", "user", userID, "error", err)
+		return ErrDevices
+	}
+
+	return nil
+}
+
+func (s *Service) GetPreferences(ctx context.Context, userID int64) (Preferences, error) {
+	preferences, err := s.db.GetPreferences(ctx, []int64{userID})
+	if err != nil {
+		s.log.Error("failed to get preferences", "user", userID, "error", err)
+		return Preferences{}, ErrPreferences
+	}
+
+	if p, ok := preferences[userID]; ok {
+		return p, nil
+	}
+	return DefaultPreferences(userID), nil
+}
+
+func (s *Service) SetPreferences(ctx context.Context, preferences Preferences) error {
+	if preferences.UserID <= 0 || !preferences.Valid() {
+		return ErrBadArguments
+	}
+
+	if err := s.db.SavePreferences(ctx, preferences); err != nil {
+		s.log.Error("failed to save preferences", "user", preferences.UserID, "error", err)
+		return ErrPreferences
+	}
+
+	s.log.Info("notification preferences updated", "user", preferences.UserID, "channels", preferences.Channels)
+
+	return nil
+}
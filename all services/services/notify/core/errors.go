@@ -0,0 +1,17 @@
+package core
+
+import "errors"
+
+var (
+	// ErrUnregistered - токен больше не действует: приложение удалено или токен обновился
+	ErrUnregistered = errors.New("device token is unregistered")
+	// ErrInvalidNotification - уведомление отклонено как некорректное, повторять бессмысленно
+	ErrInvalidNotification  = errors.New("invalid notification")
+	ErrSend                 = errors.New("failed to send notification")
+	ErrBadArguments         = errors.New("bad arguments")
+	ErrDeviceNotFound       = errors.New("device not found")
+	ErrDevices              = errors.New("failed to update devices")
+	ErrPreferences          = errors.New("failed to update notification preferences")
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrAck                  = errors.New("failed to acknowledge notification")
+)
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+type FallbackConfig struct {
+	// AckTimeout - сколько ждать подтверждения push, прежде чем пробовать следующие каналы
+	AckTimeout  time.Duration
+	Interval    time.Duration
+	BatchSize   int
+	Lease       time.Duration
+	MaxAttempts int
+}
+
+// Rerouter переотправляет предложения помочь, которые ушли push, но не были подтверждены за AckTimeout:
+// FCM принял уведомление, а до телефона оно могло и не дойти. Предложение уходит по каналам,
+// которые стоят в настройках волонтёра после push, в том же порядке. Неудачная попытка
+// повторяется после lease, но не больше MaxAttempts раз.
+type Rerouter struct {
+	log     *slog.Logger
+	service *Service
+	clock   Clock
+	cfg     FallbackConfig
+}
+
+func NewRerouter(log *slog.Logger, service *Service, clock Clock, cfg FallbackConfig) *Rerouter {
+	return &Rerouter{log: log, service: service, clock: clock, cfg: cfg}
+}
+
+func (r *Rerouter) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.After(r.cfg.Interval):
+			for r.Tick(ctx) == r.cfg.BatchSize {
+			}
+		}
+	}
+}
+
+// Tick переотправляет одну пачку неподтверждённых предложений и возвращает её размер
+func (r *Rerouter) Tick(ctx context.Context) int {
+	now := r.clock.Now()
+	// после offerTTL запрос всё равно истёк, догонять его другими каналами поздно
+	offers, err := r.service.db.ClaimUnackedOffers(ctx, now.Add(-r.service.offerTTL), now.Add(-r.cfg.AckTimeout), r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		r.log.Error("failed to claim unacked offers", "error", err)
+		return 0
+	}
+
+	for _, offer := range offers {
+		if !r.reroute(ctx, offer) && offer.Attempts < r.cfg.MaxAttempts {
+			r.log.Info("fallback failed, will retry", "help_request", offer.HelpRequestID, "user", offer.UserID,
+				"attempt", offer.Attempts, "retry_in", r.cfg.Lease)
+			continue
+		}
+		if err := r.service.db.CompleteFallback(ctx, offer.HelpRequestID, offer.UserID); err != nil {
+			r.log.Error("failed to complete fallback", "help_request", offer.HelpRequestID, "user", offer.UserID, "error", err)
+		}
+	}
+
+	return len(offers)
+}
+
+// reroute отправляет предложение по каналам после push и сообщает, закончена ли переотправка
+func (r *Rerouter) reroute(ctx context.Context, offer UnackedOffer) bool {
+	preferences, err := r.service.db.GetPreferences(ctx, []int64{offer.UserID})
+	if err != nil {
+		r.log.Error("failed to get preferences", "user", offer.UserID, "error", err)
+		return false
+	}
+	p, ok := preferences[offer.UserID]
+	if !ok {
+		p = DefaultPreferences(offer.UserID)
+	}
+
+	i := slices.Index(p.Channels, ChannelPush)
+	if i < 0 || i == len(p.Channels)-1 {
+		r.log.Debug("no fallback channels after push", "help_request", offer.HelpRequestID, "user", offer.UserID)
+		return true
+	}
+	p.Channels = p.Channels[i+1:]
+
+	data := HelpOffer{
+		HelpRequestID: offer.HelpRequestID,
+		RequesterID:   offer.RequesterID,
+		Question:      offer.Question,
+		VolunteerIDs:  []int64{offer.UserID},
+	}
+	_, message, err := r.service.render(ctx, offer.UserID, KindHelpOffered, data)
+	if err != nil {
+		r.log.Error("failed to render notification", "help_request", offer.HelpRequestID, "user", offer.UserID, "error", err)
+		return false
+	}
+
+	r.log.Info("push is not acknowledged, trying fallback channels", "help_request", offer.HelpRequestID,
+		"user", offer.UserID, "channels", p.Channels, "attempt", offer.Attempts)
+	return r.service.route(ctx, offer.HelpRequestID, KindHelpOffered, p, nil, content{message: message}) == nil
+}
@@ -0,0 +1,245 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"seeforme/notify/adapters/fake"
+	"seeforme/notify/core"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fallbackKey struct {
+	helpRequestID, userID int64
+}
+
+// fakeFallback - строка offer_fallbacks
+type fakeFallback struct {
+	attempts    int
+	lockedUntil time.Time
+	done        bool
+}
+
+// ack отмечает доставку так, как её подтвердило бы приложение
+func (d *fakeDB) ack(helpRequestID int64, address, status string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, delivery := range d.deliveries {
+		if delivery.HelpRequestID == helpRequestID && delivery.Address == address {
+			d.deliveries[i].Status = status
+		}
+	}
+}
+
+func (d *fakeDB) ClaimUnackedOffers(ctx context.Context, sentAfter, sentBefore time.Time, limit int, lease time.Duration) ([]core.UnackedOffer, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.clock.Now()
+
+	acked := map[fallbackKey]bool{}
+	for _, delivery := range d.deliveries {
+		switch delivery.Status {
+		case core.DeliveryDelivered, core.DeliveryOpened, core.DeliveryExpired:
+			acked[fallbackKey{delivery.HelpRequestID, delivery.UserID}] = true
+		}
+	}
+
+	offers := []core.UnackedOffer{}
+	for _, delivery := range d.deliveries {
+		if len(offers) == limit {
+			break
+		}
+		key := fallbackKey{delivery.HelpRequestID, delivery.UserID}
+		text, ok := d.offers[delivery.HelpRequestID]
+		if !ok || acked[key] || delivery.Kind != core.KindHelpOffered || delivery.Channel != core.ChannelPush ||
+			delivery.Status != core.DeliverySent || !delivery.SentAt.After(sentAfter) || delivery.SentAt.After(sentBefore) {
+			continue
+		}
+		fallback, ok := d.fallbacks[key]
+		if !ok {
+			fallback = &fakeFallback{}
+			d.fallbacks[key] = fallback
+		}
+		if fallback.done || !fallback.lockedUntil.Before(now) {
+			continue
+		}
+		fallback.attempts++
+		fallback.lockedUntil = now.Add(lease)
+		offers = append(offers, core.UnackedOffer{
+			HelpRequestID: key.helpRequestID,
+			UserID:        key.userID,
+			RequesterID:   text.RequesterID,
+			Question:      text.Question,
+			Attempts:      fallback.attempts,
+		})
+	}
+	return offers, nil
+}
+
+func (d *fakeDB) CompleteFallback(ctx context.Context, helpRequestID, userID int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if fallback, ok := d.fallbacks[fallbackKey{helpRequestID, userID}]; ok {
+		fallback.done = true
+		fallback.lockedUntil = time.Time{}
+	}
+	return nil
+}
+
+const (
+	ackTimeout    = 30 * time.Second
+	fallbackLease = time.Minute
+)
+
+// newFallbackTest - волонтёр 1 с телефоном, которому предложение уже ушло push
+func newFallbackTest(t *testing.T, channels ...string) (*notifyTest, *core.Rerouter) {
+	t.Helper()
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "phone-1")
+	nt.db.preferences[1] = core.Preferences{UserID: 1, Channels: channels, Phone: "+79990000001", Email: "v1@example.com"}
+	if err := nt.service.NotifyHelpOffered(context.Background(), core.HelpOffer{HelpRequestID: 1, RequesterID: 100, Question: offer.Question, VolunteerIDs: []int64{1}}); err != nil {
+		t.Fatalf("NotifyHelpOffered() error = %v", err)
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	rerouter := core.NewRerouter(log, nt.service, nt.clock, core.FallbackConfig{
+		AckTimeout:  ackTimeout,
+		Interval:    time.Second,
+		BatchSize:   10,
+		Lease:       fallbackLease,
+		MaxAttempts: 2,
+	})
+	return nt, rerouter
+}
+
+func addresses(sent []fake.Message) []string {
+	var addresses []string
+	for _, message := range sent {
+		addresses = append(addresses, message.Address)
+	}
+	return addresses
+}
+
+func TestRerouterFallsBackAfterAckTimeout(t *testing.T) {
+	nt, rerouter := newFallbackTest(t, core.ChannelPush, core.ChannelSMS, core.ChannelEmail)
+
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d offers before ack timeout", n)
+	}
+
+	nt.clock.Advance(ackTimeout)
+	if n := rerouter.Tick(context.Background()); n != 1 {
+		t.Fatalf("rerouted %d offers, want 1", n)
+	}
+	sms := nt.sms.Sent()
+	if len(sms) != 1 || sms[0].Address != "+79990000001" || sms[0].Text != offer.Question {
+		t.Fatalf("sms = %+v, want the question to +79990000001", sms)
+	}
+	// первый сработавший канал после push - дальше не идём
+	if email := nt.email.Sent(); len(email) != 0 {
+		t.Fatalf("email sent %+v after sms succeeded", email)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "+79990000001"); got.Channel != core.ChannelSMS || got.Status != core.DeliverySent {
+		t.Fatalf("sms delivery = %+v, want sent", got)
+	}
+
+	nt.clock.Advance(fallbackLease)
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d offers again", n)
+	}
+}
+
+func TestRerouterTriesNextChannelWhenOneFails(t *testing.T) {
+	nt, rerouter := newFallbackTest(t, core.ChannelPush, core.ChannelSMS, core.ChannelEmail)
+	nt.sms.Fail("+79990000001", errors.New("sms gateway is down"))
+
+	nt.clock.Advance(ackTimeout)
+	rerouter.Tick(context.Background())
+
+	if got := addresses(nt.email.Sent()); !slices.Equal(got, []string{"v1@example.com"}) {
+		t.Fatalf("email sent to %v, want [v1@example.com]", got)
+	}
+}
+
+func TestRerouterSkipsAcknowledgedOffers(t *testing.T) {
+	nt, rerouter := newFallbackTest(t, core.ChannelPush, core.ChannelSMS)
+	nt.db.ack(1, "phone-1", core.DeliveryDelivered)
+
+	nt.clock.Advance(ackTimeout)
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d acknowledged offers", n)
+	}
+	if sms := nt.sms.Sent(); len(sms) != 0 {
+		t.Fatalf("sms sent %+v", sms)
+	}
+}
+
+func TestRerouterSkipsClosedAndStaleOffers(t *testing.T) {
+	// запрос закрыт: текст предложения удалён вместе с отзывом
+	nt, rerouter := newFallbackTest(t, core.ChannelPush, core.ChannelSMS)
+	if err := nt.service.NotifyHelpWithdrawn(context.Background(), core.HelpWithdrawal{HelpRequestID: 1, RequesterID: 100, Reason: "accepted"}); err != nil {
+		t.Fatalf("NotifyHelpWithdrawn() error = %v", err)
+	}
+	nt.clock.Advance(ackTimeout)
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d withdrawn offers", n)
+	}
+
+	// после offerTTL запрос всё равно истёк
+	nt, rerouter = newFallbackTest(t, core.ChannelPush, core.ChannelSMS)
+	nt.clock.Advance(offerTTL)
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d stale offers", n)
+	}
+	if sms := nt.sms.Sent(); len(sms) != 0 {
+		t.Fatalf("sms sent %+v", sms)
+	}
+}
+
+func TestRerouterRetriesFailuresUpToMaxAttempts(t *testing.T) {
+	nt, rerouter := newFallbackTest(t, core.ChannelPush, core.ChannelSMS)
+	nt.sms.Fail("+79990000001", errors.New("sms gateway is down"))
+
+	nt.clock.Advance(ackTimeout)
+	if n := rerouter.Tick(context.Background()); n != 1 {
+		t.Fatalf("rerouted %d offers, want 1", n)
+	}
+	// до конца lease неудачная попытка не повторяется
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d offers during lease", n)
+	}
+
+	nt.clock.Advance(fallbackLease + time.Second)
+	if n := rerouter.Tick(context.Background()); n != 1 {
+		t.Fatalf("rerouted %d offers after lease, want 1", n)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "+79990000001"); got.Status != core.DeliveryFailed || got.Attempts != 2 {
+		t.Fatalf("sms delivery = %+v, want failed twice", got)
+	}
+
+	// MaxAttempts исчерпан, переотправка закрыта
+	nt.clock.Advance(fallbackLease + time.Second)
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d offers after max attempts", n)
+	}
+}
+
+func TestRerouterCompletesWithoutChannelsAfterPush(t *testing.T) {
+	nt, rerouter := newFallbackTest(t, core.ChannelPush)
+
+	nt.clock.Advance(ackTimeout)
+	if n := rerouter.Tick(context.Background()); n != 1 {
+		t.Fatalf("rerouted %d offers, want 1", n)
+	}
+	// после push каналов нет: переотправлять некуда, и больше предложение не забирается
+	if len(nt.sms.Sent()) != 0 || len(nt.email.Sent()) != 0 {
+		t.Fatalf("sent sms %+v and email %+v", nt.sms.Sent(), nt.email.Sent())
+	}
+	nt.clock.Advance(fallbackLease)
+	if n := rerouter.Tick(context.Background()); n != 0 {
+		t.Fatalf("rerouted %d completed offers", n)
+	}
+}
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Типы уведомлений, приходят приложению в поле data "type"
+const (
+	KindHelpOffered   = "help_offered"
+	KindHelpWithdrawn = "help_withdrawn"
+)
+
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+const (
+	// MaxDevices - сколько устройств помнить на пользователя, самые давние вытесняются
+	MaxDevices       = 10
+	MaxTokenLength   = 4096
+	MaxVersionLength = 32
+)
+
+// Device - устройство пользователя с токеном FCM
+type Device struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	Token      string    `db:"token"`
+	Platform   string    `db:"platform"`
+	AppVersion string    `db:"app_version"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+func (d Device) Valid() bool {
+	switch d.Platform {
+	case PlatformAndroid, PlatformIOS, PlatformWeb:
+	default:
+		return false
+	}
+	return d.UserID > 0 && d.Token != "" && len(d.Token) <= MaxTokenLength && len(d.AppVersion) <= MaxVersionLength
+}
+
+// Alert - видимая часть уведомления. Без неё уведомление доставляется только приложению.
+type Alert struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Notification - одно push-уведомление на одно устройство
+type Notification struct {
+	Token string            `json:"token"`
+	Data  map[string]string `json:"data"`
+	// Alert - nil для data-only уведомления
+	Alert *Alert `json:"alert,omitempty"`
+	// TTL - сколько FCM хранит уведомление для недоступного устройства, 0 - по умолчанию FCM
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// Каналы доставки. Push уходит на все устройства пользователя, остальные - на один адрес.
+const (
+	ChannelPush  = "push"
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+)
+
+// Статусы доставки. pending - уведомление ещё отправляется, delivered и opened подтверждает приложение,
+// expired - запрос закрылся раньше, чем волонтёр открыл уведомление.
+const (
+	DeliveryPending   = "pending"
+	DeliverySent      = "sent"
+	DeliveryFailed    = "failed"
+	DeliveryDelivered = "delivered"
+	DeliveryOpened    = "opened"
+	DeliveryExpired   = "expired"
+)
+
+// Message - текст уведомления для каналов без приложения: SMS и email
+type Message struct {
+	Subject string `json:"subject,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Delivery - попытка доставить уведомление о запросе помощи по одному адресу канала.
+// ID приходит приложению в поле data "notificationId", по нему оно подтверждает доставку.
+type Delivery struct {
+	ID            int64  `db:"id"`
+	HelpRequestID int64  `db:"help_request_id"`
+	UserID        int64  `db:"user_id"`
+	Kind          string `db:"kind"`
+	Channel       string `db:"channel"`
+	// Address - токен устройства, номер телефона или email
+	Address     string     `db:"address"`
+	Status      string     `db:"status"`
+	Error       string     `db:"error"`
+	Attempts    int        `db:"attempts"`
+	SentAt      time.Time  `db:"sent_at"`
+	DeliveredAt *time.Time `db:"delivered_at"`
+	OpenedAt    *time.Time `db:"opened_at"`
+}
+
+// Reached - уведомление ушло адресату, повторять его не нужно
+func (d Delivery) Reached() bool {
+	switch d.Status {
+	case DeliverySent, DeliveryDelivered, DeliveryOpened, DeliveryExpired:
+		return true
+	}
+	return false
+}
+
+// Preferences - в каком порядке пробовать каналы и куда писать, если push не дошёл
+type Preferences struct {
+	UserID   int64
+	Channels []string
+	Phone    string
+	Email    string
+}
+
+var (
+	phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// DefaultPreferences - пока пользователь ничего не выбрал, уведомления идут только push
+func DefaultPreferences(userID int64) Preferences {
+	return Preferences{UserID: userID, Channels: []string{ChannelPush}}
+}
+
+func (p Preferences) Valid() bool {
+	if len(p.Channels) == 0 || len(p.Channels) > 3 {
+		return false
+	}
+	for i, channel := range p.Channels {
+		if slices.Contains(p.Channels[:i], channel) {
+			return false
+		}
+		switch channel {
+		case ChannelPush:
+		case ChannelSMS:
+			if !phonePattern.MatchString(p.Phone) {
+				return false
+			}
+		case ChannelEmail:
+			if !emailPattern.MatchString(p.Email) || len(p.Email) > 254 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return (p.Phone == "" || phonePattern.MatchString(p.Phone)) && (p.Email == "" || emailPattern.MatchString(p.Email))
+}
+
+// Address - куда писать в канал SMS или email
+func (p Preferences) Address(channel string) string {
+	switch channel {
+	case ChannelSMS:
+		return p.Phone
+	case ChannelEmail:
+		return p.Email
+	}
+	return ""
+}
+
+type HelpOffer struct {
+	HelpRequestID int64
+	RequesterID   int64
+	Question      string
+	VolunteerIDs  []int64
+}
+
+// UnackedOffer - предложение, которое ушло волонтёру push, но приложение не подтвердило его вовремя
+type UnackedOffer struct {
+	HelpRequestID int64  `db:"help_request_id"`
+	UserID        int64  `db:"user_id"`
+	RequesterID   int64  `db:"requester_id"`
+	Question      string `db:"question"`
+	// Attempts - номер попытки переотправки, с 1
+	Attempts int `db:"attempts"`
+}
+
+type HelpWithdrawal struct {
+	HelpRequestID int64
+	RequesterID   int64
+	Reason        string
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Notifier доставляет уведомление на одно устройство.
+// ErrUnregistered и ErrInvalidNotification означают, что повтор не поможет.
+type Notifier interface {
+	Send(ctx context.Context, notification Notification) error
+}
+
+// Channel - канал без приложения (SMS, email): одно сообщение на один адрес.
+// ErrInvalidNotification означает, что повтор не поможет.
+type Channel interface {
+	Send(ctx context.Context, address string, message Message) error
+}
+
+type DB interface {
+	ListDevices(ctx context.Context, userIDs []int64) ([]Device, error)
+	// SaveDevice сохраняет устройство или обновляет уже известный токен
+	// и оставляет пользователю не больше MaxDevices последних устройств
+	SaveDevice(ctx context.Context, device Device) (Device, error)
+	// DeleteDevice - ErrDeviceNotFound, если у пользователя нет такого устройства
+	DeleteDevice(ctx context.Context, userID, id int64) error
+	DeleteDeviceByToken(ctx context.Context, token string) error
+	DeleteUserData(ctx context.Context, userID int64) error
+	// GetDeliveries - все попытки доставить уведомления вида kind по запросу помощи
+	GetDeliveries(ctx context.Context, helpRequestID int64, kind string) ([]Delivery, error)
+	// StartDelivery заводит запись в статусе pending, если её ещё нет, и возвращает её id
+	StartDelivery(ctx context.Context, delivery Delivery) (int64, error)
+	// SaveDelivery записывает попытку; меняет только записи в статусе pending и failed,
+	// чтобы не затереть успешную доставку или подтверждение, пришедшее раньше ответа FCM
+	SaveDelivery(ctx context.Context, delivery Delivery) error
+	// AckDelivery отмечает доставку уведомления пользователя статусом delivered или opened.
+	// Статус только растёт: sent < delivered < opened, expired не меняется.
+	// ErrNotificationNotFound - у пользователя нет такого уведомления.
+	AckDelivery(ctx context.Context, userID, id int64, status string) (Delivery, error)
+	// ExpireDeliveries переводит в expired ещё не открытые уведомления вида kind по запросу помощи
+	ExpireDeliveries(ctx context.Context, helpRequestID int64, kind string) error
+	// GetPreferences - настройки каналов; пользователей без настроек в ответе нет
+	GetPreferences(ctx context.Context, userIDs []int64) (map[int64]Preferences, error)
+	SavePreferences(ctx context.Context, preferences Preferences) error
+	// SaveOffer запоминает текст предложения для запасных каналов; повторное сохранение ничего не меняет
+	SaveOffer(ctx context.Context, offer HelpOffer) error
+	// DeleteOffer забывает текст предложения и его переотправки, когда запрос закрыт
+	DeleteOffer(ctx context.Context, helpRequestID int64) error
+	// ClaimUnackedOffers забирает на время lease предложения, ушедшие push в (sentAfter, sentBefore],
+	// которые приложение не подтвердило и которые ещё не переотправлены
+	ClaimUnackedOffers(ctx context.Context, sentAfter, sentBefore time.Time, limit int, lease time.Duration) ([]UnackedOffer, error)
+	// CompleteFallback отмечает переотправку законченной, больше она не повторяется
+	CompleteFallback(ctx context.Context, helpRequestID, userID int64) error
+}
+
+// Users - языки, на которых говорит пользователь, в порядке предпочтения
+type Users interface {
+	GetLanguages(ctx context.Context, userID int64) ([]string, error)
+}
+
+// Templates - тексты уведомлений на языке получателя
+type Templates interface {
+	// Render выбирает первый язык из languages, для которого есть шаблоны, иначе язык по умолчанию
+	Render(kind string, languages []string, data any) (Alert, Message, error)
+}
+
+// Help - сервис запросов помощи, ему передаются подтверждения доставки предложений
+type Help interface {
+	AcknowledgeOffer(ctx context.Context, helpRequestID, volunteerID int64, opened bool) error
+}
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type NotifyService interface {
+	RegisterDevice(ctx context.Context, device Device) (Device, error)
+	UnregisterDevice(ctx context.Context, userID, id int64) error
+	ListDevices(ctx context.Context, userID int64) ([]Device, error)
+	GetPreferences(ctx context.Context, userID int64) (Preferences, error)
+	SetPreferences(ctx context.Context, preferences Preferences) error
+	AckNotification(ctx context.Context, userID, id int64, status string) error
+	DeleteUserData(ctx context.Context, userID int64) error
+}
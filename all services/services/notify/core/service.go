@@ -0,0 +1,258 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"strconv"
+	"time"
+)
+
+// Service рассылает уведомления о запросах помощи волонтёрам.
+// Каждый волонтёр получает предложение по первому сработавшему каналу из своих настроек.
+// Все попытки записываются, поэтому повторная обработка события не дублирует уведомления.
+type Service struct {
+	log       *slog.Logger
+	db        DB
+	notifier  Notifier
+	help      Help
+	users     Users
+	templates Templates
+	// channels - каналы кроме push; если канал не настроен, он пропускается
+	channels map[string]Channel
+	// offerTTL - предложение помочь бессмысленно доставлять после того, как запрос истёк
+	offerTTL time.Duration
+}
+
+func NewService(log *slog.Logger, db DB, notifier Notifier, help Help, users Users, templates Templates, channels map[string]Channel, offerTTL time.Duration) *Service {
+	return &Service{
+		log:       log,
+		db:        db,
+		notifier:  notifier,
+		help:      help,
+		users:     users,
+		templates: templates,
+		channels:  channels,
+		offerTTL:  offerTTL,
+	}
+}
+
+// content - одно и то же уведомление в виде для каждого канала
+type content struct {
+	push    Notification
+	message Message
+}
+
+// NotifyHelpOffered доставляет предложение каждому волонтёру волны
+func (s *Service) NotifyHelpOffered(ctx context.Context, offer HelpOffer) error {
+	// текст нужен запасным каналам, если push не подтвердят, см. Rerouter
+	if err := s.db.SaveOffer(ctx, offer); err != nil {
+		s.log.Error("failed to save offer", "help_request", offer.HelpRequestID, "error", err)
+		return err
+	}
+
+	devices, err := s.db.ListDevices(ctx, offer.VolunteerIDs)
+	if err != nil {
+		s.log.Error("failed to list devices", "help_request", offer.HelpRequestID, "error", err)
+		return err
+	}
+	tokens := make(map[int64][]string, len(offer.VolunteerIDs))
+	for _, device := range devices {
+		tokens[device.UserID] = append(tokens[device.UserID], device.Token)
+	}
+
+	preferences, err := s.db.GetPreferences(ctx, offer.VolunteerIDs)
+	if err != nil {
+		s.log.Error("failed to get preferences", "help_request", offer.HelpRequestID, "error", err)
+		return err
+	}
+
+	sent, err := s.db.GetDeliveries(ctx, offer.HelpRequestID, KindHelpOffered)
+	if err != nil {
+		s.log.Error("failed to get deliveries", "help_request", offer.HelpRequestID, "error", err)
+		return err
+	}
+	reached := map[int64]bool{}
+	for _, delivery := range sent {
+		if delivery.Reached() {
+			reached[delivery.UserID] = true
+		}
+	}
+
+	// поля те же, что приложение получало от прежней рассылки по топику
+	data := map[string]string{
+		"type":             KindHelpOffered,
+		"helpRequestId":    strconv.FormatInt(offer.HelpRequestID, 10),
+		"requestCreatorId": strconv.FormatInt(offer.RequesterID, 10),
+		"question":         offer.Question,
+	}
+
+	var retry error
+	for _, userID := range offer.VolunteerIDs {
+		if reached[userID] {
+			continue
+		}
+		p, ok := preferences[userID]
+		if !ok {
+			p = DefaultPreferences(userID)
+		}
+
+		alert, message, err := s.render(ctx, userID, KindHelpOffered, offer)
+		if err != nil {
+			s.log.Error("failed to render notification", "help_request", offer.HelpRequestID, "user", userID, "error", err)
+			retry = ErrSend
+			continue
+		}
+		c := content{
+			push:    Notification{Data: data, Alert: &alert, TTL: s.offerTTL},
+			message: message,
+		}
+		if err := s.route(ctx, offer.HelpRequestID, KindHelpOffered, p, tokens[userID], c); err != nil {
+			retry = err
+		}
+	}
+	return retry
+}
+
+// NotifyHelpWithdrawn сообщает устройствам, получившим предложение, что запрос больше не ждёт ответа.
+// По SMS и email отзыв не отправляется: он бы только мешал.
+func (s *Service) NotifyHelpWithdrawn(ctx context.Context, withdrawal HelpWithdrawal) error {
+	offers, err := s.db.GetDeliveries(ctx, withdrawal.HelpRequestID, KindHelpOffered)
+	if err != nil {
+		s.log.Error("failed to get deliveries", "help_request", withdrawal.HelpRequestID, "error", err)
+		return err
+	}
+	withdrawn, err := s.db.GetDeliveries(ctx, withdrawal.HelpRequestID, KindHelpWithdrawn)
+	if err != nil {
+		s.log.Error("failed to get deliveries", "help_request", withdrawal.HelpRequestID, "error", err)
+		return err
+	}
+	done := map[string]bool{}
+	for _, delivery := range withdrawn {
+		if delivery.Reached() {
+			done[delivery.Address] = true
+		}
+	}
+
+	notification := Notification{Data: map[string]string{
+		"type":          KindHelpWithdrawn,
+		"helpRequestId": strconv.FormatInt(withdrawal.HelpRequestID, 10),
+		"requesterId":   strconv.FormatInt(withdrawal.RequesterID, 10),
+		"reason":        withdrawal.Reason,
+	}}
+
+	var retry error
+	for _, offer := range offers {
+		if offer.Channel != ChannelPush || !offer.Reached() || done[offer.Address] {
+			continue
+		}
+		done[offer.Address] = true
+		if _, transient := s.sendPush(ctx, withdrawal.HelpRequestID, KindHelpWithdrawn, offer.UserID, offer.Address, notification); transient {
+			retry = ErrSend
+		}
+	}
+
+	if err := s.db.ExpireDeliveries(ctx, withdrawal.HelpRequestID, KindHelpOffered); err != nil {
+		s.log.Error("failed to expire deliveries", "help_request", withdrawal.HelpRequestID, "error", err)
+		return err
+	}
+	if err := s.db.DeleteOffer(ctx, withdrawal.HelpRequestID); err != nil {
+		s.log.Error("failed to delete offer", "help_request", withdrawal.HelpRequestID, "error", err)
+		return err
+	}
+	return retry
+}
+
+// render - текст уведомления на языке пользователя. Если языки узнать не удалось,
+// уведомление всё равно уходит, на языке по умолчанию.
+func (s *Service) render(ctx context.Context, userID int64, kind string, data any) (Alert, Message, error) {
+	languages, err := s.users.GetLanguages(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to get user languages, using default locale", "user", userID, "error", err)
+	}
+	return s.templates.Render(kind, languages, data)
+}
+
+// route пробует каналы пользователя по порядку, пока один из них не сработает.
+// Ошибка возвращается, если не сработал ни один, но хотя бы один сбой стоит повторить.
+func (s *Service) route(ctx context.Context, helpRequestID int64, kind string, preferences Preferences, tokens []string, c content) error {
+	retry := false
+	for _, channel := range preferences.Channels {
+		var delivered, transient bool
+		if channel == ChannelPush {
+			for _, token := range tokens {
+				ok, t := s.sendPush(ctx, helpRequestID, kind, preferences.UserID, token, c.push)
+				delivered = delivered || ok
+				transient = transient || t
+			}
+		} else {
+			delivered, transient = s.send(ctx, helpRequestID, kind, preferences.UserID, channel, preferences.Address(channel), c.message)
+		}
+
+		if delivered {
+			s.log.Info("notification delivered", "help_request", helpRequestID, "kind", kind, "user", preferences.UserID, "channel", channel)
+			return nil
+		}
+		retry = retry || transient
+		s.log.Debug("channel failed, trying next", "help_request", helpRequestID, "user", preferences.UserID, "channel", channel)
+	}
+
+	s.log.Info("user is unreachable on all channels", "help_request", helpRequestID, "kind", kind, "user", preferences.UserID, "retry", retry)
+	if retry {
+		return ErrSend
+	}
+	return nil
+}
+
+// sendPush отправляет уведомление на одно устройство и удаляет устройство, если его токен больше не действует.
+// Запись о доставке заводится до отправки: её id приложение присылает в подтверждении.
+func (s *Service) sendPush(ctx context.Context, helpRequestID int64, kind string, userID int64, token string, notification Notification) (bool, bool) {
+	delivery := Delivery{HelpRequestID: helpRequestID, UserID: userID, Kind: kind, Channel: ChannelPush, Address: token}
+	id, err := s.db.StartDelivery(ctx, delivery)
+	if err != nil {
+		s.log.Error("failed to start delivery", "help_request", helpRequestID, "user", userID, "error", err)
+		return false, true
+	}
+
+	notification.Token = token
+	data := make(map[string]string, len(notification.Data)+1)
+	maps.Copy(data, notification.Data)
+	data["notificationId"] = strconv.FormatInt(id, 10)
+	notification.Data = data
+	err = s.notifier.Send(ctx, notification)
+	if errors.Is(err, ErrUnregistered) {
+		s.log.Info("device token is unregistered, removing device", "user", userID)
+		if err := s.db.DeleteDeviceByToken(ctx, token); err != nil {
+			s.log.Error("failed to remove unregistered device", "user", userID, "error", err)
+		}
+	}
+	return s.record(ctx, delivery, err)
+}
+
+func (s *Service) send(ctx context.Context, helpRequestID int64, kind string, userID int64, channel, address string, message Message) (bool, bool) {
+	sender, ok := s.channels[channel]
+	if !ok || sender == nil || address == "" {
+		return false, false
+	}
+	err := sender.Send(ctx, address, message)
+	return s.record(ctx, Delivery{HelpRequestID: helpRequestID, UserID: userID, Kind: kind, Channel: channel, Address: address}, err)
+}
+
+// record сохраняет попытку и сообщает, дошло ли уведомление и стоит ли его повторить
+func (s *Service) record(ctx context.Context, delivery Delivery, err error) (bool, bool) {
+	delivery.Status = DeliverySent
+	transient := false
+	if err != nil {
+		delivery.Status = DeliveryFailed
+		delivery.Error = err.Error()
+		transient = !errors.Is(err, ErrUnregistered) && !errors.Is(err, ErrInvalidNotification)
+		s.log.Error("failed to send notification", "help_request", delivery.HelpRequestID, "kind", delivery.Kind,
+			"user", delivery.UserID, "channel", delivery.Channel, "error", err)
+	}
+
+	if err := s.db.SaveDelivery(ctx, delivery); err != nil {
+		s.log.Error("failed to save delivery", "help_request", delivery.HelpRequestID, "user", delivery.UserID, "error", err)
+	}
+	return err == nil, transient
+}
@@ -0,0 +1,395 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"seeforme/notify/adapters/fake"
+	"seeforme/notify/core"
+	"seeforme/pkg/clock"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeDB повторяет в памяти то, что сервису нужно от базы; остальные методы не вызываются
+type fakeDB struct {
+	core.DB
+
+	clock       core.Clock
+	mu          sync.Mutex
+	devices     []core.Device
+	deliveries  []core.Delivery
+	preferences map[int64]core.Preferences
+	offers      map[int64]core.HelpOffer
+	fallbacks   map[fallbackKey]*fakeFallback
+}
+
+func newFakeDB(c core.Clock) *fakeDB {
+	return &fakeDB{
+		clock:       c,
+		preferences: map[int64]core.Preferences{},
+		offers:      map[int64]core.HelpOffer{},
+		fallbacks:   map[fallbackKey]*fakeFallback{},
+	}
+}
+
+func (d *fakeDB) addDevice(userID int64, token string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.devices = append(d.devices, core.Device{ID: int64(len(d.devices) + 1), UserID: userID, Token: token, Platform: core.PlatformAndroid})
+}
+
+// delivery - запись о доставке по адресу или пустая запись, если её нет
+func (d *fakeDB) delivery(helpRequestID int64, kind, address string) core.Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, delivery := range d.deliveries {
+		if delivery.HelpRequestID == helpRequestID && delivery.Kind == kind && delivery.Address == address {
+			return delivery
+		}
+	}
+	return core.Delivery{}
+}
+
+func (d *fakeDB) ListDevices(ctx context.Context, userIDs []int64) ([]core.Device, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	devices := []core.Device{}
+	for _, device := range d.devices {
+		if slices.Contains(userIDs, device.UserID) {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (d *fakeDB) DeleteDeviceByToken(ctx context.Context, token string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.devices = slices.DeleteFunc(d.devices, func(device core.Device) bool { return device.Token == token })
+	return nil
+}
+
+func (d *fakeDB) GetDeliveries(ctx context.Context, helpRequestID int64, kind string) ([]core.Delivery, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	deliveries := []core.Delivery{}
+	for _, delivery := range d.deliveries {
+		if delivery.HelpRequestID == helpRequestID && delivery.Kind == kind {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (d *fakeDB) find(delivery core.Delivery) int {
+	return slices.IndexFunc(d.deliveries, func(saved core.Delivery) bool {
+		return saved.HelpRequestID == delivery.HelpRequestID && saved.Kind == delivery.Kind &&
+			saved.Channel == delivery.Channel && saved.Address == delivery.Address
+	})
+}
+
+func (d *fakeDB) StartDelivery(ctx context.Context, delivery core.Delivery) (int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i := d.find(delivery); i >= 0 {
+		return d.deliveries[i].ID, nil
+	}
+	delivery.ID = int64(len(d.deliveries) + 1)
+	delivery.Status = core.DeliveryPending
+	d.deliveries = append(d.deliveries, delivery)
+	return delivery.ID, nil
+}
+
+func (d *fakeDB) SaveDelivery(ctx context.Context, delivery core.Delivery) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	i := d.find(delivery)
+	if i < 0 {
+		delivery.ID = int64(len(d.deliveries) + 1)
+		delivery.Attempts = 1
+		delivery.SentAt = d.clock.Now()
+		d.deliveries = append(d.deliveries, delivery)
+		return nil
+	}
+	saved := &d.deliveries[i]
+	if saved.Status != core.DeliveryPending && saved.Status != core.DeliveryFailed {
+		return nil
+	}
+	saved.Status, saved.Error = delivery.Status, delivery.Error
+	saved.Attempts++
+	saved.SentAt = d.clock.Now()
+	return nil
+}
+
+func (d *fakeDB) ExpireDeliveries(ctx context.Context, helpRequestID int64, kind string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, delivery := range d.deliveries {
+		if delivery.HelpRequestID != helpRequestID || delivery.Kind != kind {
+			continue
+		}
+		switch delivery.Status {
+		case core.DeliveryPending, core.DeliverySent, core.DeliveryDelivered:
+			d.deliveries[i].Status = core.DeliveryExpired
+		}
+	}
+	return nil
+}
+
+func (d *fakeDB) GetPreferences(ctx context.Context, userIDs []int64) (map[int64]core.Preferences, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	preferences := map[int64]core.Preferences{}
+	for _, id := range userIDs {
+		if p, ok := d.preferences[id]; ok {
+			preferences[id] = p
+		}
+	}
+	return preferences, nil
+}
+
+func (d *fakeDB) SavePreferences(ctx context.Context, preferences core.Preferences) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.preferences[preferences.UserID] = preferences
+	return nil
+}
+
+func (d *fakeDB) SaveOffer(ctx context.Context, offer core.HelpOffer) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.offers[offer.HelpRequestID]; !ok {
+		d.offers[offer.HelpRequestID] = offer
+	}
+	return nil
+}
+
+func (d *fakeDB) DeleteOffer(ctx context.Context, helpRequestID int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.offers, helpRequestID)
+	return nil
+}
+
+type fakeUsers struct {
+	languages map[int64][]string
+}
+
+func (u fakeUsers) GetLanguages(ctx context.Context, userID int64) ([]string, error) {
+	return u.languages[userID], nil
+}
+
+// fakeTemplates - вопрос запроса без перевода, язык попадает в заголовок
+type fakeTemplates struct{}
+
+func (fakeTemplates) Render(kind string, languages []string, data any) (core.Alert, core.Message, error) {
+	offer, ok := data.(core.HelpOffer)
+	if !ok {
+		return core.Alert{}, core.Message{}, errors.New("unexpected data")
+	}
+	title := kind
+	if len(languages) > 0 {
+		title += " " + languages[0]
+	}
+	return core.Alert{Title: title, Body: offer.Question}, core.Message{Subject: title, Text: offer.Question}, nil
+}
+
+type fakeHelp struct {
+	mu   sync.Mutex
+	acks []fakeAck
+	err  error
+}
+
+type fakeAck struct {
+	helpRequestID, volunteerID int64
+	opened                     bool
+}
+
+func (h *fakeHelp) AcknowledgeOffer(ctx context.Context, helpRequestID, volunteerID int64, opened bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.err != nil {
+		return h.err
+	}
+	h.acks = append(h.acks, fakeAck{helpRequestID: helpRequestID, volunteerID: volunteerID, opened: opened})
+	return nil
+}
+
+// notifyTest - сервис уведомлений с фейковыми каналами доставки
+type notifyTest struct {
+	clock    *clock.Fake
+	db       *fakeDB
+	notifier *fake.Notifier
+	sms      *fake.Channel
+	email    *fake.Channel
+	help     *fakeHelp
+	users    fakeUsers
+	service  *core.Service
+}
+
+const offerTTL = 5 * time.Minute
+
+func newNotifyTest() *notifyTest {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := clock.NewFake(start)
+	nt := &notifyTest{
+		clock:    c,
+		db:       newFakeDB(c),
+		notifier: fake.NewNotifier("", log),
+		sms:      fake.NewChannel(core.ChannelSMS, "", log),
+		email:    fake.NewChannel(core.ChannelEmail, "", log),
+		help:     &fakeHelp{},
+		users:    fakeUsers{languages: map[int64][]string{}},
+	}
+	channels := map[string]core.Channel{core.ChannelSMS: nt.sms, core.ChannelEmail: nt.email}
+	nt.service = core.NewService(log, nt.db, nt.notifier, nt.help, nt.users, fakeTemplates{}, channels, offerTTL)
+	return nt
+}
+
+// tokens - адресаты отправленных push в порядке отправки
+func (nt *notifyTest) tokens() []string {
+	var tokens []string
+	for _, sent := range nt.notifier.Sent() {
+		tokens = append(tokens, sent.Token)
+	}
+	return tokens
+}
+
+var offer = core.HelpOffer{HelpRequestID: 1, RequesterID: 100, Question: "Какого цвета рубашка?", VolunteerIDs: []int64{1, 2}}
+
+func TestNotifyHelpOfferedSendsToEveryDevice(t *testing.T) {
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "phone-1")
+	nt.db.addDevice(1, "tablet-1")
+	nt.db.addDevice(2, "phone-2")
+	nt.db.addDevice(3, "phone-3")
+
+	if err := nt.service.NotifyHelpOffered(context.Background(), offer); err != nil {
+		t.Fatalf("NotifyHelpOffered() error = %v", err)
+	}
+
+	if got, want := nt.tokens(), []string{"phone-1", "tablet-1", "phone-2"}; !slices.Equal(got, want) {
+		t.Fatalf("sent to %v, want %v", got, want)
+	}
+	sent := nt.notifier.Sent()[0]
+	delivery := nt.db.delivery(1, core.KindHelpOffered, "phone-1")
+	want := map[string]string{
+		"type":             core.KindHelpOffered,
+		"helpRequestId":    "1",
+		"requestCreatorId": "100",
+		"question":         offer.Question,
+		"notificationId":   "1",
+	}
+	for key, value := range want {
+		if sent.Data[key] != value {
+			t.Errorf("data[%q] = %q, want %q", key, sent.Data[key], value)
+		}
+	}
+	if sent.Alert == nil || sent.Alert.Body != offer.Question {
+		t.Errorf("alert = %+v, want body %q", sent.Alert, offer.Question)
+	}
+	if sent.TTL != offerTTL {
+		t.Errorf("ttl = %v, want %v", sent.TTL, offerTTL)
+	}
+	if delivery.ID != 1 || delivery.Status != core.DeliverySent || delivery.Attempts != 1 {
+		t.Errorf("delivery = %+v, want sent once with id 1", delivery)
+	}
+}
+
+func TestNotifyHelpOfferedRedeliveryDoesNotDuplicate(t *testing.T) {
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "phone-1")
+	nt.db.addDevice(2, "phone-2")
+
+	for range 2 {
+		if err := nt.service.NotifyHelpOffered(context.Background(), offer); err != nil {
+			t.Fatalf("NotifyHelpOffered() error = %v", err)
+		}
+	}
+
+	if got, want := nt.tokens(), []string{"phone-1", "phone-2"}; !slices.Equal(got, want) {
+		t.Fatalf("sent to %v, want %v", got, want)
+	}
+}
+
+func TestNotifyHelpOfferedRetriesTransientFailures(t *testing.T) {
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "phone-1")
+	nt.db.addDevice(2, "phone-2")
+	nt.notifier.Fail("phone-2", errors.New("fcm unavailable"))
+
+	if err := nt.service.NotifyHelpOffered(context.Background(), offer); !errors.Is(err, core.ErrSend) {
+		t.Fatalf("NotifyHelpOffered() error = %v, want %v", err, core.ErrSend)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-2"); got.Status != core.DeliveryFailed || got.Error == "" {
+		t.Fatalf("failed delivery = %+v, want failed with error", got)
+	}
+
+	// событие пришло повторно: уходит только то, что не дошло
+	nt.notifier.Reset()
+	if err := nt.service.NotifyHelpOffered(context.Background(), offer); err != nil {
+		t.Fatalf("NotifyHelpOffered() error = %v", err)
+	}
+	if got, want := nt.tokens(), []string{"phone-2"}; !slices.Equal(got, want) {
+		t.Fatalf("resent to %v, want %v", got, want)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-2"); got.Status != core.DeliverySent || got.Attempts != 2 {
+		t.Fatalf("delivery = %+v, want sent on second attempt", got)
+	}
+}
+
+func TestNotifyHelpOfferedDropsUnregisteredDevice(t *testing.T) {
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "old-phone")
+	nt.db.addDevice(1, "phone-1")
+	nt.notifier.Unregister("old-phone")
+
+	// второй токен дошёл, повторять нечего
+	if err := nt.service.NotifyHelpOffered(context.Background(), core.HelpOffer{HelpRequestID: 1, RequesterID: 100, VolunteerIDs: []int64{1}}); err != nil {
+		t.Fatalf("NotifyHelpOffered() error = %v", err)
+	}
+
+	devices, _ := nt.db.ListDevices(context.Background(), []int64{1})
+	if len(devices) != 1 || devices[0].Token != "phone-1" {
+		t.Fatalf("devices = %+v, want only phone-1", devices)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "old-phone"); got.Status != core.DeliveryFailed {
+		t.Fatalf("delivery = %+v, want failed", got)
+	}
+}
+
+func TestNotifyHelpWithdrawnReachesOfferedDevicesOnce(t *testing.T) {
+	nt := newNotifyTest()
+	nt.db.addDevice(1, "phone-1")
+	nt.db.addDevice(2, "phone-2")
+	nt.notifier.Fail("phone-2", core.ErrInvalidNotification)
+	if err := nt.service.NotifyHelpOffered(context.Background(), offer); err != nil {
+		t.Fatalf("NotifyHelpOffered() error = %v", err)
+	}
+	nt.notifier.Reset()
+
+	withdrawal := core.HelpWithdrawal{HelpRequestID: 1, RequesterID: 100, Reason: "cancelled"}
+	for range 2 {
+		if err := nt.service.NotifyHelpWithdrawn(context.Background(), withdrawal); err != nil {
+			t.Fatalf("NotifyHelpWithdrawn() error = %v", err)
+		}
+	}
+
+	// на phone-2 предложение не дошло, отзывать там нечего
+	sent := nt.notifier.Sent()
+	if len(sent) != 1 || sent[0].Token != "phone-1" {
+		t.Fatalf("withdrawals = %+v, want one to phone-1", sent)
+	}
+	if sent[0].Alert != nil || sent[0].Data["type"] != core.KindHelpWithdrawn || sent[0].Data["reason"] != "cancelled" {
+		t.Errorf("withdrawal = %+v, want data-only %s", sent[0], core.KindHelpWithdrawn)
+	}
+	if got := nt.db.delivery(1, core.KindHelpOffered, "phone-1"); got.Status != core.DeliveryExpired {
+		t.Errorf("offer delivery = %+v, want expired", got)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"seeforme/notify/adapters/db"
+	"seeforme/notify/adapters/email"
+	"seeforme/notify/adapters/fake"
+	"seeforme/notify/adapters/fcm"
+	notifygrpc "seeforme/notify/adapters/grpc"
+	"seeforme/notify/adapters/help"
+	"seeforme/notify/adapters/kafka"
+	"seeforme/notify/adapters/sms"
+	"seeforme/notify/adapters/templates"
+	"seeforme/notify/adapters/user"
+	"seeforme/notify/config"
+	"seeforme/notify/core"
+	"seeforme/pkg/clock"
+	eventspkg "seeforme/pkg/events"
+	"seeforme/pkg/kafkaretry"
+	notifypb "seeforme/proto/notify"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	var configPath string
+	flag.StringVar(&configPath, "config", "config.yaml", "server configuration file")
+	flag.Parse()
+	cfg := config.MustLoad(configPath)
+
+	log := mustMakeLogger(cfg.LogLevel)
+
+	log.Info("starting notify service")
+	log.Debug("debug messages are enabled")
+
+	storage, err := db.New(log, cfg.DBAddress)
+	if err != nil {
+		log.Error("failed to connect to database", "error", err)
+		return
+	}
+
+	if err := storage.Migrate(); err != nil {
+		log.Error("failed to migrate db", "error", err)
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var notifier core.Notifier
+	switch cfg.Notifier.Driver {
+	case "fake":
+		log.Info("using fake notifier", "file", cfg.Notifier.FakeFile)
+		notifier = fake.NewNotifier(cfg.Notifier.FakeFile, log)
+	default:
+		notifier, err = fcm.NewNotifier(ctx, cfg.Notifier.CredentialsFile, log)
+		if err != nil {
+			log.Error("failed to init fcm notifier", "error", err)
+			return
+		}
+	}
+
+	channels, err := makeChannels(cfg.Channels, log)
+	if err != nil {
+		log.Error("failed to init notification channels", "error", err)
+		return
+	}
+
+	helpservice, err := help.NewClient(cfg.HelpAddress, log)
+	if err != nil {
+		log.Error("failed to init help adapter", "error", err)
+		return
+	}
+
+	userservice, err := user.NewClient(cfg.UserAddress, log)
+	if err != nil {
+		log.Error("failed to init user adapter", "error", err)
+		return
+	}
+
+	// шаблоны встроены в бинарник, ошибка в них не даст сервису запуститься
+	texts, err := templates.New(cfg.DefaultLocale)
+	if err != nil {
+		log.Error("invalid notification templates", "error", err)
+		return
+	}
+
+	service := core.NewService(log, storage, notifier, helpservice, userservice, texts, channels, cfg.Notifier.OfferTTL)
+
+	rerouter := core.NewRerouter(log, service, clock.Real{}, core.FallbackConfig{
+		AckTimeout:  cfg.Fallback.AckTimeout,
+		Interval:    cfg.Fallback.Interval,
+		BatchSize:   cfg.Fallback.BatchSize,
+		Lease:       cfg.Fallback.Lease,
+		MaxAttempts: cfg.Fallback.MaxAttempts,
+	})
+	go rerouter.Run(ctx)
+
+	consumer := kafka.NewConsumer(kafkaretry.Config{
+		Brokers: cfg.Kafka.Brokers,
+		Topic:   cfg.Kafka.HelpTopic,
+		GroupID: cfg.Kafka.GroupID,
+		Delays:  cfg.Kafka.RetryDelays,
+
+		MessageIDHeader: eventspkg.HeaderMessageID,
+	}, service, log)
+	defer consumer.Close()
+	if err := consumer.CheckTopics(ctx); err != nil {
+		log.Error("failed to check kafka topics", "error", err)
+		return
+	}
+	go consumer.Run(ctx)
+
+	listener, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		log.Error("failed to listen", "error", err)
+		return
+	}
+
+	s := grpc.NewServer()
+	notifypb.RegisterNotifyServer(s, notifygrpc.NewServer(service))
+	reflection.Register(s)
+
+	go func() {
+		<-ctx.Done()
+		log.Debug("shutting down server")
+		s.GracefulStop()
+	}()
+
+	log.Info("notify service is running", "address", cfg.Address)
+	if err := s.Serve(listener); err != nil {
+		log.Error("failed to serve", "error", err)
+		return
+	}
+}
+
+// makeChannels собирает каналы кроме push; выключенных каналов в ответе нет
+func makeChannels(cfg config.Channels, log *slog.Logger) (map[string]core.Channel, error) {
+	channels := map[string]core.Channel{}
+
+	switch cfg.SMS.Driver {
+	case "http":
+		channels[core.ChannelSMS] = sms.NewClient(cfg.SMS.URL, cfg.SMS.Token, cfg.SMS.From, cfg.SMS.Timeout, log)
+	case "fake":
+		channels[core.ChannelSMS] = fake.NewChannel(core.ChannelSMS, cfg.FakeFile, log)
+	}
+
+	switch cfg.Email.Driver {
+	case "smtp":
+		client, err := email.NewClient(cfg.Email.Host, cfg.Email.Port, cfg.Email.Username, cfg.Email.Password, cfg.Email.From, log)
+		if err != nil {
+			return nil, err
+		}
+		channels[core.ChannelEmail] = client
+	case "fake":
+		channels[core.ChannelEmail] = fake.NewChannel(core.ChannelEmail, cfg.FakeFile, log)
+	}
+
+	for name := range channels {
+		log.Info("notification channel enabled", "channel", name)
+	}
+	return channels, nil
+}
+
+func mustMakeLogger(logLevel string) *slog.Logger {
+	var level slog.Level
+	switch logLevel {
+	case "DEBUG":
+		level = slog.LevelDebug
+	case "INFO":
+		level = slog.LevelInfo
+	case "ERROR":
+		level = slog.LevelError
+	default:
+		panic("unknown log level: " + logLevel)
+	}
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	return slog.New(handler)
+}
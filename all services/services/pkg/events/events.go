@@ -0,0 +1,135 @@
+// Package events собирает и разбирает конверты событий Kafka из proto/events.
+// Продюсеры и потребители всех Go-сервисов должны работать с событиями только через него.
+package events
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	eventspb "seeforme/proto/events"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	TypeHelpRequested = "help.requested"
+	TypeHelpOffered   = "help.offered"
+	TypeHelpResponded = "help.responded"
+	TypeHelpWithdrawn = "help.withdrawn"
+	TypeUserSuspended = "user.suspended"
+)
+
+// versions - последняя версия схемы каждого типа, которую пишут и понимают наши сервисы
+var versions = map[string]int32{
+	TypeHelpRequested: 1,
+	TypeHelpOffered:   1,
+	TypeHelpResponded: 1,
+	TypeHelpWithdrawn: 1,
+	TypeUserSuspended: 1,
+}
+
+// HeaderMessageID - заголовок Kafka с id конверта, по нему потребители отсеивают повторы
+const HeaderMessageID = "message-id"
+
+var (
+	ErrUnknownType        = errors.New("unknown event type")
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+	ErrPayloadMismatch    = errors.New("event payload does not match its type")
+)
+
+var (
+	marshaler   = protojson.MarshalOptions{}
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// New заворачивает событие в конверт с новым id и текущим временем
+func New(payload interface{}) (*eventspb.Envelope, error) {
+	envelope := &eventspb.Envelope{
+		Id:        NewID(),
+		Timestamp: timestamppb.New(time.Now()),
+	}
+
+	switch p := payload.(type) {
+	case *eventspb.HelpRequested:
+		envelope.Type = TypeHelpRequested
+		envelope.Payload = &eventspb.Envelope_HelpRequested{HelpRequested: p}
+	case *eventspb.HelpOffered:
+		envelope.Type = TypeHelpOffered
+		envelope.Payload = &eventspb.Envelope_HelpOffered{HelpOffered: p}
+	case *eventspb.HelpResponded:
+		envelope.Type = TypeHelpResponded
+		envelope.Payload = &eventspb.Envelope_HelpResponded{HelpResponded: p}
+	case *eventspb.HelpWithdrawn:
+		envelope.Type = TypeHelpWithdrawn
+		envelope.Payload = &eventspb.Envelope_HelpWithdrawn{HelpWithdrawn: p}
+	case *eventspb.UserSuspended:
+		envelope.Type = TypeUserSuspended
+		envelope.Payload = &eventspb.Envelope_UserSuspended{UserSuspended: p}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnknownType, payload)
+	}
+	envelope.Version = versions[envelope.Type]
+
+	return envelope, nil
+}
+
+// Marshal возвращает готовое к отправке в Kafka значение
+func Marshal(payload interface{}) (*eventspb.Envelope, []byte, error) {
+	envelope, err := New(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := marshaler.Marshal(envelope)
+	if err != nil {
+		return nil, nil, err
+	}
+	return envelope, data, nil
+}
+
+// Unmarshal разбирает конверт и проверяет, что мы умеем читать его тип и версию.
+// Неизвестные поля отбрасываются: так продюсер может добавлять поля, не ломая нас.
+func Unmarshal(data []byte) (*eventspb.Envelope, error) {
+	envelope := &eventspb.Envelope{}
+	if err := unmarshaler.Unmarshal(data, envelope); err != nil {
+		return nil, err
+	}
+
+	latest, ok := versions[envelope.GetType()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, envelope.GetType())
+	}
+	if envelope.GetVersion() < 1 || envelope.GetVersion() > latest {
+		return nil, fmt.Errorf("%w: %s v%d", ErrUnsupportedVersion, envelope.GetType(), envelope.GetVersion())
+	}
+
+	var matches bool
+	switch envelope.GetType() {
+	case TypeHelpRequested:
+		matches = envelope.GetHelpRequested() != nil
+	case TypeHelpOffered:
+		matches = envelope.GetHelpOffered() != nil
+	case TypeHelpResponded:
+		matches = envelope.GetHelpResponded() != nil
+	case TypeHelpWithdrawn:
+		matches = envelope.GetHelpWithdrawn() != nil
+	case TypeUserSuspended:
+		matches = envelope.GetUserSuspended() != nil
+	}
+	if !matches {
+		return nil, fmt.Errorf("%w: %s", ErrPayloadMismatch, envelope.GetType())
+	}
+
+	return envelope, nil
+}
+
+// NewID возвращает случайный uuid v4
+func NewID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
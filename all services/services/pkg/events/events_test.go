@@ -0,0 +1,227 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	eventspb "seeforme/proto/events"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	fixtureID   = "5f0c6f52-7c1e-4a55-9a40-0d3c2f7e8b11"
+	fixtureTime = time.Date(2025, 5, 3, 13, 4, 18, 0, time.UTC)
+)
+
+// payloads - по одному событию каждого типа, те же значения, что в testdata
+var payloads = []struct {
+	golden  string
+	payload proto.Message
+}{
+	{"help_requested.json", &eventspb.HelpRequested{
+		HelpRequestId:    1,
+		RequestCreatorId: 2,
+		Question:         "Какого цвета футболка?",
+		CreatedAt:        timestamppb.New(fixtureTime),
+	}},
+	{"help_offered.json", &eventspb.HelpOffered{
+		HelpRequestId: 1,
+		RequesterId:   2,
+		Question:      "Какого цвета футболка?",
+		VolunteerIds:  []int64{3, 4},
+		Wave:          1,
+	}},
+	{"help_responded.json", &eventspb.HelpResponded{
+		HelpRequestId: 1,
+		RequesterId:   2,
+		VolunteerId:   3,
+		Answer:        "accept",
+	}},
+	{"help_withdrawn.json", &eventspb.HelpWithdrawn{
+		HelpRequestId: 1,
+		RequesterId:   2,
+		Reason:        "cancelled",
+	}},
+	{"user_suspended.json", &eventspb.UserSuspended{
+		UserId: 2,
+		Reason: "spam",
+		Until:  timestamppb.New(time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC)),
+	}},
+}
+
+// javaFields - поля DTO notification-service (api/dto/Kafka*.java), которые разбирают наши конверты
+var javaFields = map[string][]string{
+	"envelope":      {"id", "type", "version", "timestamp"},
+	"helpRequested": {"helpRequestId", "requestCreatorId", "question", "createdAt"},
+	"helpOffered":   {"helpRequestId", "requesterId", "question", "volunteerIds", "wave"},
+	"helpWithdrawn": {"helpRequestId", "requesterId", "reason"},
+}
+
+func payloadOf(envelope *eventspb.Envelope) proto.Message {
+	switch p := envelope.GetPayload().(type) {
+	case *eventspb.Envelope_HelpRequested:
+		return p.HelpRequested
+	case *eventspb.Envelope_HelpOffered:
+		return p.HelpOffered
+	case *eventspb.Envelope_HelpResponded:
+		return p.HelpResponded
+	case *eventspb.Envelope_HelpWithdrawn:
+		return p.HelpWithdrawn
+	case *eventspb.Envelope_UserSuspended:
+		return p.UserSuspended
+	}
+	return nil
+}
+
+func readJSON(t *testing.T, data []byte) map[string]any {
+	t.Helper()
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("invalid json %s: %v", data, err)
+	}
+	return value
+}
+
+func readGolden(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, tc := range payloads {
+		t.Run(tc.golden, func(t *testing.T) {
+			sent, data, err := Marshal(tc.payload)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if sent.GetVersion() != 1 || sent.GetId() == "" || sent.GetTimestamp() == nil {
+				t.Fatalf("Marshal() envelope = %v, want id, timestamp and version 1", sent)
+			}
+
+			received, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !proto.Equal(received, sent) {
+				t.Fatalf("Unmarshal() = %v, want %v", received, sent)
+			}
+			if !proto.Equal(payloadOf(received), tc.payload) {
+				t.Fatalf("payload = %v, want %v", payloadOf(received), tc.payload)
+			}
+		})
+	}
+}
+
+// protojson нарочно не даёт стабильных пробелов, поэтому JSON сравнивается после разбора
+func TestGolden(t *testing.T) {
+	for _, tc := range payloads {
+		t.Run(tc.golden, func(t *testing.T) {
+			envelope, err := New(tc.payload)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			envelope.Id = fixtureID
+			envelope.Timestamp = timestamppb.New(fixtureTime)
+			data, err := marshaler.Marshal(envelope)
+			if err != nil {
+				t.Fatalf("marshal error = %v", err)
+			}
+
+			golden := readGolden(t, tc.golden)
+			got, want := readJSON(t, data), readJSON(t, golden)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("json = %s\nwant %s", data, golden)
+			}
+
+			parsed, err := Unmarshal(golden)
+			if err != nil {
+				t.Fatalf("Unmarshal(golden) error = %v", err)
+			}
+			if !proto.Equal(parsed, envelope) {
+				t.Fatalf("Unmarshal(golden) = %v, want %v", parsed, envelope)
+			}
+		})
+	}
+}
+
+func TestGoldenMatchesJavaDTOs(t *testing.T) {
+	for _, tc := range payloads {
+		envelope := readJSON(t, readGolden(t, tc.golden))
+		for key, value := range envelope {
+			fields, ok := javaFields[key]
+			if !ok {
+				continue
+			}
+			got := slices.Sorted(maps.Keys(value.(map[string]any)))
+			if want := slices.Sorted(slices.Values(fields)); !slices.Equal(got, want) {
+				t.Errorf("%s: %s fields = %v, Java DTO has %v", tc.golden, key, got, want)
+			}
+			// конверт, который понимает Java, несёт только свои поля и одно событие
+			got = slices.Sorted(maps.Keys(envelope))
+			if want := slices.Sorted(slices.Values(append(slices.Clone(javaFields["envelope"]), key))); !slices.Equal(got, want) {
+				t.Errorf("%s: envelope fields = %v, Java DTO has %v", tc.golden, got, want)
+			}
+		}
+	}
+}
+
+func TestUnmarshalIgnoresUnknownFields(t *testing.T) {
+	envelope, err := Unmarshal(readGolden(t, "help_offered_unknown_fields.json"))
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := &eventspb.HelpOffered{
+		HelpRequestId: 1,
+		RequesterId:   2,
+		Question:      "Какого цвета футболка?",
+		VolunteerIds:  []int64{3, 4},
+		Wave:          1,
+	}
+	if !proto.Equal(envelope.GetHelpOffered(), want) {
+		t.Fatalf("payload = %v, want %v", envelope.GetHelpOffered(), want)
+	}
+	if got := envelope.GetTimestamp().AsTime(); !got.Equal(fixtureTime.Add(123 * time.Millisecond)) {
+		t.Fatalf("timestamp = %v", got)
+	}
+}
+
+func TestUnmarshalRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"unknown type", `{"type":"help.unknown","version":1}`, ErrUnknownType},
+		{"newer version", `{"type":"help.withdrawn","version":2,"helpWithdrawn":{"helpRequestId":"1"}}`, ErrUnsupportedVersion},
+		{"no version", `{"type":"help.withdrawn","helpWithdrawn":{"helpRequestId":"1"}}`, ErrUnsupportedVersion},
+		{"payload of another type", `{"type":"help.withdrawn","version":1,"helpOffered":{"helpRequestId":"1"}}`, ErrPayloadMismatch},
+		{"no payload", `{"type":"help.offered","version":1}`, ErrPayloadMismatch},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Unmarshal([]byte(tc.data)); !errors.Is(err, tc.want) {
+				t.Fatalf("Unmarshal() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewRejectsUnknownPayload(t *testing.T) {
+	if _, err := New(&eventspb.Envelope{}); !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("New() error = %v, want %v", err, ErrUnknownType)
+	}
+}
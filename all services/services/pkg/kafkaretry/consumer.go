@@ -0,0 +1,272 @@
+package kafkaretry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"seeforme/pkg/clock"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	forwardMinBackoff = time.Second
+	forwardMaxBackoff = time.Minute
+)
+
+// Handler обрабатывает сообщение. Ошибка отправляет сообщение на повтор.
+type Handler func(ctx context.Context, message kafka.Message) error
+
+type Config struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+	// Delays - задержка для каждого retry-топика, len(Delays) - число повторов
+	Delays []time.Duration
+	// MessageIDHeader - заголовок с id сообщения. Если задан, повторно доставленные
+	// сообщения с уже обработанным id пропускаются.
+	MessageIDHeader string
+	// DedupeSize - сколько id помнить, по умолчанию DefaultDedupeSize
+	DedupeSize int
+	// StartOffset - откуда читать новой группе: kafka.FirstOffset (по умолчанию) или kafka.LastOffset
+	StartOffset int64
+	// TopicGroup - группа в именах retry- и DLQ-топиков, по умолчанию GroupID.
+	// Нужна, когда у каждой реплики своя группа: топики заводятся заранее, поэтому реплики делят одни.
+	TopicGroup string
+}
+
+func (cfg Config) topicGroup() string {
+	if cfg.TopicGroup != "" {
+		return cfg.TopicGroup
+	}
+	return cfg.GroupID
+}
+
+// Topics - основной топик, retry-топики и DLQ; все они должны существовать до запуска
+func (cfg Config) Topics() []string {
+	topics := []string{cfg.Topic}
+	for stage := 1; stage <= len(cfg.Delays); stage++ {
+		topics = append(topics, RetryTopic(cfg.Topic, cfg.topicGroup(), stage))
+	}
+	return append(topics, DeadLetterTopic(cfg.Topic, cfg.topicGroup()))
+}
+
+type reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+type writer interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+// Consumer читает основной топик и все его retry-топики в одной группе.
+// Смещение фиксируется только после обработки или пересылки сообщения дальше.
+type Consumer struct {
+	cfg     Config
+	handler Handler
+	readers []reader
+	writer  writer
+	seen    *seenSet
+	clock   clock.Clock
+	log     *slog.Logger
+}
+
+func NewConsumer(cfg Config, handler Handler, log *slog.Logger) *Consumer {
+	addresses := brokerAddresses(cfg.Brokers)
+
+	// последний топик - DLQ, его читает только Replay
+	topics := cfg.Topics()
+	readers := make([]reader, 0, len(topics)-1)
+	for _, topic := range topics[:len(topics)-1] {
+		readers = append(readers, kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     addresses,
+			Topic:       topic,
+			GroupID:     cfg.GroupID,
+			StartOffset: cfg.StartOffset,
+		}))
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(addresses...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	return newConsumer(cfg, handler, readers, writer, clock.Real{}, log)
+}
+
+// newConsumer - readers по этапам: основной топик, затем retry-топики по порядку
+func newConsumer(cfg Config, handler Handler, readers []reader, writer writer, clock clock.Clock, log *slog.Logger) *Consumer {
+	var seen *seenSet
+	if cfg.MessageIDHeader != "" {
+		size := cfg.DedupeSize
+		if size <= 0 {
+			size = DefaultDedupeSize
+		}
+		seen = newSeenSet(size)
+	}
+
+	return &Consumer{
+		cfg:     cfg,
+		handler: handler,
+		readers: readers,
+		writer:  writer,
+		seen:    seen,
+		clock:   clock,
+		log:     log.With("topic", cfg.Topic, "group", cfg.GroupID),
+	}
+}
+
+// CheckTopics проверяет, что все топики потребителя уже есть: брокер не создаёт их сам,
+// а без retry-топика или DLQ пересылка остановила бы этап навсегда
+func (c *Consumer) CheckTopics(ctx context.Context) error {
+	addresses := brokerAddresses(c.cfg.Brokers)
+	client := &kafka.Client{Addr: kafka.TCP(addresses...)}
+	metadata, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: c.cfg.Topics()})
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, topic := range metadata.Topics {
+		if topic.Error != nil {
+			missing = append(missing, topic.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("kafka topics are missing: %v", missing)
+	}
+	return nil
+}
+
+// Run блокируется, пока не отменён ctx
+func (c *Consumer) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for stage, reader := range c.readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.runStage(ctx, stage, reader)
+		}()
+	}
+	wg.Wait()
+}
+
+func (c *Consumer) runStage(ctx context.Context, stage int, reader reader) {
+	for {
+		message, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, io.EOF) {
+				return
+			}
+			c.log.Error("failed to fetch message from kafka", "stage", stage, "error", err)
+			continue
+		}
+
+		// в retry-топике сообщения лежат по порядку, поэтому ждём только первое неготовое
+		if retryAt, err := time.Parse(time.RFC3339Nano, Header(message, HeaderRetryAt)); err == nil {
+			select {
+			case <-c.clock.After(retryAt.Sub(c.clock.Now())):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err := c.process(ctx, stage, message); err != nil {
+			// пересылка повторяется до успеха, сюда попадаем только при остановке
+			return
+		}
+
+		if err := reader.CommitMessages(ctx, message); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			c.log.Error("failed to commit message", "stage", stage, "offset", message.Offset, "error", err)
+		}
+	}
+}
+
+func (c *Consumer) process(ctx context.Context, stage int, message kafka.Message) error {
+	var id string
+	if c.seen != nil {
+		id = Header(message, c.cfg.MessageIDHeader)
+		if id != "" && c.seen.contains(id) {
+			c.log.Info("duplicate message skipped", "stage", stage, "message_id", id)
+			return nil
+		}
+	}
+
+	err := c.handler(ctx, message)
+	if err == nil {
+		if id != "" {
+			c.seen.add(id)
+		}
+		return nil
+	}
+
+	attempt := stage + 1
+	headers := append([]kafka.Header(nil), message.Headers...)
+	if stage == 0 {
+		headers = setHeader(headers, HeaderOriginalTopic, message.Topic)
+		headers = setHeader(headers, HeaderOriginalPartition, strconv.Itoa(message.Partition))
+		headers = setHeader(headers, HeaderOriginalOffset, strconv.FormatInt(message.Offset, 10))
+	}
+	headers = setHeader(headers, HeaderAttempt, strconv.Itoa(attempt))
+	headers = setHeader(headers, HeaderError, err.Error())
+	headers = setHeader(headers, HeaderFailedAt, c.clock.Now().UTC().Format(time.RFC3339Nano))
+	headers = setHeader(headers, HeaderConsumerGroup, c.cfg.GroupID)
+
+	next := kafka.Message{Key: message.Key, Value: message.Value}
+	if stage < len(c.cfg.Delays) && !IsPermanent(err) {
+		next.Topic = RetryTopic(c.cfg.Topic, c.cfg.topicGroup(), stage+1)
+		retryAt := c.clock.Now().Add(c.cfg.Delays[stage])
+		next.Headers = setHeader(headers, HeaderRetryAt, retryAt.UTC().Format(time.RFC3339Nano))
+		c.log.Info("message scheduled for retry", "attempt", attempt, "retry_at", retryAt, "error", err)
+	} else {
+		next.Topic = DeadLetterTopic(c.cfg.Topic, c.cfg.topicGroup())
+		next.Headers = withoutHeaders(headers, HeaderRetryAt)
+		c.log.Error("message moved to dead letter topic", "attempt", attempt, "error", err)
+	}
+
+	return c.forward(ctx, stage, message, next)
+}
+
+// forward пересылает сообщение в retry-топик или DLQ, повторяя запись с экспоненциальной задержкой.
+// Смещение не фиксируется, пока пересылка не удалась, поэтому обработка этапа стоит, но не теряет сообщений.
+func (c *Consumer) forward(ctx context.Context, stage int, message, next kafka.Message) error {
+	delay := forwardMinBackoff
+	for {
+		err := c.writer.WriteMessages(ctx, next)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.log.Error("failed to forward message", "stage", stage, "offset", message.Offset, "to", next.Topic, "retry_in", delay, "error", err)
+
+		select {
+		case <-c.clock.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		delay = min(delay*2, forwardMaxBackoff)
+	}
+}
+
+func (c *Consumer) Close() error {
+	var errs []error
+	for _, reader := range c.readers {
+		errs = append(errs, reader.Close())
+	}
+	errs = append(errs, c.writer.Close())
+	return errors.Join(errs...)
+}
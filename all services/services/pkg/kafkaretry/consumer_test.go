@@ -0,0 +1,328 @@
+package kafkaretry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"seeforme/pkg/clock"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeReader отдаёт сообщения из очереди и запоминает зафиксированные смещения
+type fakeReader struct {
+	messages chan kafka.Message
+
+	mu        sync.Mutex
+	committed []int64
+}
+
+func newFakeReader(messages ...kafka.Message) *fakeReader {
+	r := &fakeReader{messages: make(chan kafka.Message, 16)}
+	for _, message := range messages {
+		r.messages <- message
+	}
+	return r
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case message := <-r.messages:
+		return message, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, message := range messages {
+		r.committed = append(r.committed, message.Offset)
+	}
+	return nil
+}
+
+func (r *fakeReader) commits() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.committed)
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+// fakeWriter запоминает записанные сообщения; первые failures записей завершаются ошибкой
+type fakeWriter struct {
+	mu       sync.Mutex
+	written  []kafka.Message
+	failures int
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		return kafka.UnknownTopicOrPartition
+	}
+	w.written = append(w.written, messages...)
+	return nil
+}
+
+func (w *fakeWriter) messages() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.written)
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+// fakeHandler отвечает ошибками по очереди, потом nil, и запоминает значения обработанных сообщений
+type fakeHandler struct {
+	mu      sync.Mutex
+	errs    []error
+	handled []string
+}
+
+func (h *fakeHandler) handle(ctx context.Context, message kafka.Message) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, string(message.Value))
+	if len(h.errs) == 0 {
+		return nil
+	}
+	err := h.errs[0]
+	h.errs = h.errs[1:]
+	return err
+}
+
+func (h *fakeHandler) calls() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.handled)
+}
+
+type consumerTest struct {
+	clock *clock.Fake
+	// readers - по одному на этап: основной топик, затем retry-топики
+	readers  []*fakeReader
+	writer   *fakeWriter
+	handler  *fakeHandler
+	consumer *Consumer
+}
+
+func newConsumerTest(cfg Config, errs ...error) *consumerTest {
+	ct := &consumerTest{
+		clock:   clock.NewFake(start),
+		writer:  &fakeWriter{},
+		handler: &fakeHandler{errs: errs},
+	}
+	readers := []reader{}
+	for range len(cfg.Delays) + 1 {
+		r := newFakeReader()
+		ct.readers = append(ct.readers, r)
+		readers = append(readers, r)
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ct.consumer = newConsumer(cfg, ct.handler.handle, readers, ct.writer, ct.clock, log)
+	return ct
+}
+
+// run запускает потребителя до конца теста
+func (ct *consumerTest) run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ct.consumer.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+var testConfig = Config{
+	Topic:   "help-request",
+	GroupID: "notify",
+	Delays:  []time.Duration{10 * time.Second, time.Minute},
+
+	MessageIDHeader: "x-message-id",
+}
+
+func message(topic string, offset int64, value string, headers ...kafka.Header) kafka.Message {
+	return kafka.Message{Topic: topic, Partition: 0, Offset: offset, Value: []byte(value), Headers: headers}
+}
+
+func header(key, value string) kafka.Header {
+	return kafka.Header{Key: key, Value: []byte(value)}
+}
+
+func TestTopicsAreNamedAfterGroup(t *testing.T) {
+	want := []string{"help-request", "help-request.notify.retry.1", "help-request.notify.retry.2", "help-request.notify.dlq"}
+	if got := testConfig.Topics(); !slices.Equal(got, want) {
+		t.Fatalf("Topics() = %v, want %v", got, want)
+	}
+
+	// у реплик свои группы, но топики общие
+	cfg := Config{Topic: "help-response", GroupID: "api-host1", TopicGroup: "api", Delays: []time.Duration{time.Second}}
+	want = []string{"help-response", "help-response.api.retry.1", "help-response.api.dlq"}
+	if got := cfg.Topics(); !slices.Equal(got, want) {
+		t.Fatalf("Topics() = %v, want %v", got, want)
+	}
+}
+
+func TestConsumerSchedulesRetryOnFailure(t *testing.T) {
+	ct := newConsumerTest(testConfig, errors.New("db is down"))
+	ct.readers[0].messages <- message("help-request", 7, "offer", header("x-message-id", "m1"))
+	ct.run(t)
+
+	waitFor(t, func() bool { return len(ct.readers[0].commits()) == 1 })
+
+	written := ct.writer.messages()
+	if len(written) != 1 {
+		t.Fatalf("written %d messages, want 1", len(written))
+	}
+	next := written[0]
+	if next.Topic != "help-request.notify.retry.1" || string(next.Value) != "offer" {
+		t.Fatalf("forwarded %q to %s, want offer to help-request.notify.retry.1", next.Value, next.Topic)
+	}
+	want := map[string]string{
+		"x-message-id":          "m1",
+		HeaderOriginalTopic:     "help-request",
+		HeaderOriginalOffset:    "7",
+		HeaderAttempt:           "1",
+		HeaderError:             "db is down",
+		HeaderConsumerGroup:     "notify",
+		HeaderRetryAt:           start.Add(10 * time.Second).Format(time.RFC3339Nano),
+		HeaderOriginalPartition: "0",
+	}
+	for key, value := range want {
+		if got := Header(next, key); got != value {
+			t.Errorf("header %s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestConsumerWaitsForStageDelay(t *testing.T) {
+	ct := newConsumerTest(testConfig)
+	retryAt := start.Add(10 * time.Second).Format(time.RFC3339Nano)
+	ct.readers[1].messages <- message("help-request.notify.retry.1", 3, "offer", header(HeaderRetryAt, retryAt))
+	ct.run(t)
+
+	waitFor(t, func() bool { return ct.clock.Waiters() == 1 })
+	ct.clock.Advance(9 * time.Second)
+	if calls := ct.handler.calls(); len(calls) != 0 {
+		t.Fatalf("handled %v before retry time", calls)
+	}
+
+	ct.clock.Advance(time.Second)
+	waitFor(t, func() bool { return len(ct.readers[1].commits()) == 1 })
+	if calls := ct.handler.calls(); !slices.Equal(calls, []string{"offer"}) {
+		t.Fatalf("handled %v, want [offer]", calls)
+	}
+	if written := ct.writer.messages(); len(written) != 0 {
+		t.Fatalf("forwarded %d messages after success", len(written))
+	}
+}
+
+func TestConsumerMovesToDeadLetterAfterLastStage(t *testing.T) {
+	ct := newConsumerTest(testConfig, errors.New("still down"))
+	ct.readers[2].messages <- message("help-request.notify.retry.2", 1, "offer",
+		header(HeaderOriginalTopic, "help-request"), header(HeaderAttempt, "2"), header(HeaderRetryAt, start.Format(time.RFC3339Nano)))
+	ct.run(t)
+
+	waitFor(t, func() bool { return len(ct.readers[2].commits()) == 1 })
+	written := ct.writer.messages()
+	if len(written) != 1 || written[0].Topic != "help-request.notify.dlq" {
+		t.Fatalf("written %+v, want one message to help-request.notify.dlq", written)
+	}
+	if got := Header(written[0], HeaderAttempt); got != "3" {
+		t.Errorf("attempt = %q, want 3", got)
+	}
+	if got := Header(written[0], HeaderRetryAt); got != "" {
+		t.Errorf("dead letter keeps retry time %q", got)
+	}
+	if got := Header(written[0], HeaderOriginalTopic); got != "help-request" {
+		t.Errorf("original topic = %q, want help-request", got)
+	}
+}
+
+func TestConsumerSendsPermanentErrorsToDeadLetter(t *testing.T) {
+	ct := newConsumerTest(testConfig, Permanent(errors.New("bad json")))
+	ct.readers[0].messages <- message("help-request", 1, "{")
+	ct.run(t)
+
+	waitFor(t, func() bool { return len(ct.readers[0].commits()) == 1 })
+	written := ct.writer.messages()
+	if len(written) != 1 || written[0].Topic != "help-request.notify.dlq" {
+		t.Fatalf("written %+v, want one message to help-request.notify.dlq", written)
+	}
+}
+
+func TestConsumerSkipsDuplicateMessages(t *testing.T) {
+	ct := newConsumerTest(testConfig)
+	ct.readers[0].messages <- message("help-request", 1, "first", header("x-message-id", "m1"))
+	ct.readers[0].messages <- message("help-request", 2, "again", header("x-message-id", "m1"))
+	ct.readers[0].messages <- message("help-request", 3, "other", header("x-message-id", "m2"))
+	ct.readers[0].messages <- message("help-request", 4, "no id")
+	ct.readers[0].messages <- message("help-request", 5, "no id")
+	ct.run(t)
+
+	waitFor(t, func() bool { return len(ct.readers[0].commits()) == 5 })
+	if calls, want := ct.handler.calls(), []string{"first", "other", "no id", "no id"}; !slices.Equal(calls, want) {
+		t.Fatalf("handled %v, want %v", calls, want)
+	}
+}
+
+func TestConsumerRetriesFailedDuplicate(t *testing.T) {
+	// неудачная обработка не запоминает id: повтор того же сообщения обрабатывается
+	ct := newConsumerTest(Config{Topic: "t", GroupID: "g", MessageIDHeader: "x-message-id"}, Permanent(errors.New("bad")))
+	ct.readers[0].messages <- message("t", 1, "first", header("x-message-id", "m1"))
+	ct.readers[0].messages <- message("t", 2, "again", header("x-message-id", "m1"))
+	ct.run(t)
+
+	waitFor(t, func() bool { return len(ct.readers[0].commits()) == 2 })
+	if calls, want := ct.handler.calls(), []string{"first", "again"}; !slices.Equal(calls, want) {
+		t.Fatalf("handled %v, want %v", calls, want)
+	}
+}
+
+func TestConsumerKeepsForwardingUntilTopicAccepts(t *testing.T) {
+	ct := newConsumerTest(testConfig, errors.New("db is down"))
+	ct.writer.failures = 2
+	ct.readers[0].messages <- message("help-request", 1, "offer")
+	ct.run(t)
+
+	// смещение не фиксируется, пока сообщение не переслано
+	for _, backoff := range []time.Duration{forwardMinBackoff, 2 * forwardMinBackoff} {
+		waitFor(t, func() bool { return ct.clock.Waiters() == 1 })
+		if commits := ct.readers[0].commits(); len(commits) != 0 {
+			t.Fatalf("committed %v before forwarding", commits)
+		}
+		ct.clock.Advance(backoff)
+	}
+
+	waitFor(t, func() bool { return len(ct.readers[0].commits()) == 1 })
+	if written := ct.writer.messages(); len(written) != 1 || written[0].Topic != "help-request.notify.retry.1" {
+		t.Fatalf("written %+v, want one retry", written)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,43 @@
+package kafkaretry
+
+import "sync"
+
+// DefaultDedupeSize - сколько последних id сообщений помнит потребитель
+const DefaultDedupeSize = 10000
+
+// seenSet - ограниченное множество id уже обработанных сообщений.
+// Самые старые id вытесняются по кругу.
+type seenSet struct {
+	mu    sync.Mutex
+	ids   map[string]struct{}
+	order []string
+	next  int
+}
+
+func newSeenSet(size int) *seenSet {
+	return &seenSet{
+		ids:   make(map[string]struct{}, size),
+		order: make([]string, size),
+	}
+}
+
+func (s *seenSet) contains(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.ids[id]
+	return ok
+}
+
+func (s *seenSet) add(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ids[id]; ok {
+		return
+	}
+	if old := s.order[s.next]; old != "" {
+		delete(s.ids, old)
+	}
+	s.order[s.next] = id
+	s.ids[id] = struct{}{}
+	s.next = (s.next + 1) % len(s.order)
+}
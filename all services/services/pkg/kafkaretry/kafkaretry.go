@@ -0,0 +1,105 @@
+// Package kafkaretry - общая для наших потребителей Kafka схема повторов:
+// сообщение, которое не удалось обработать, уходит в retry-топики с растущей задержкой,
+// а после последней попытки - в dead-letter топик вместе с причиной ошибки.
+package kafkaretry
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Заголовки, которые добавляются к сообщению при повторе и в DLQ.
+// Исходные заголовки сохраняются.
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderAttempt           = "x-attempt"
+	HeaderRetryAt           = "x-retry-at"
+	HeaderError             = "x-error"
+	HeaderFailedAt          = "x-failed-at"
+	HeaderConsumerGroup     = "x-consumer-group"
+)
+
+// DefaultDelays - задержки retry-топиков по умолчанию
+var DefaultDelays = []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}
+
+// RetryTopic - retry-топик группы group: у каждой группы свои повторы,
+// иначе сбой одного потребителя заново обрабатывали бы все группы, читающие топик
+func RetryTopic(topic, group string, stage int) string {
+	return topic + "." + group + ".retry." + strconv.Itoa(stage)
+}
+
+func DeadLetterTopic(topic, group string) string {
+	return topic + "." + group + ".dlq"
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent помечает ошибку, которую бессмысленно повторять (например, битый JSON):
+// такое сообщение сразу уходит в DLQ
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+func Header(message kafka.Message, key string) string {
+	for _, h := range message.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// setHeader заменяет заголовок, если он уже есть, иначе добавляет
+func setHeader(headers []kafka.Header, key, value string) []kafka.Header {
+	for i, h := range headers {
+		if h.Key == key {
+			headers[i].Value = []byte(value)
+			return headers
+		}
+	}
+	return append(headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func withoutHeaders(headers []kafka.Header, keys ...string) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		drop := false
+		for _, key := range keys {
+			if h.Key == key {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+func brokerAddresses(brokers []string) []string {
+	var addresses []string
+	for _, broker := range brokers {
+		if !strings.Contains(broker, ":") {
+			broker = broker + ":9092"
+		}
+		addresses = append(addresses, broker)
+	}
+	return addresses
+}
@@ -0,0 +1,93 @@
+package kafkaretry
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type ReplayOptions struct {
+	Brokers []string
+	// Topic - исходный топик, DLQ которого переигрываем
+	Topic string
+	// ConsumerGroup - группа потребителя, чей DLQ переигрываем (TopicGroup, если он задан)
+	ConsumerGroup string
+	// GroupID - группа, в которой Replay запоминает прочитанные сообщения
+	GroupID string
+	Limit   int
+	DryRun  bool
+	// Idle - сколько ждать новых сообщений, прежде чем считать DLQ прочитанным
+	Idle time.Duration
+}
+
+// Replay возвращает сообщения из DLQ группы в её первый retry-топик со сброшенным счётчиком попыток.
+// В исходный топик сообщения не пишутся: их заново получили бы и все остальные группы.
+// Прочитанные сообщения фиксируются в группе GroupID и повторно не переигрываются.
+func Replay(ctx context.Context, opts ReplayOptions, log *slog.Logger) (int, error) {
+	addresses := brokerAddresses(opts.Brokers)
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     addresses,
+		Topic:       DeadLetterTopic(opts.Topic, opts.ConsumerGroup),
+		GroupID:     opts.GroupID,
+		StartOffset: kafka.FirstOffset,
+	})
+	defer reader.Close()
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(addresses...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+	defer writer.Close()
+
+	return replay(ctx, opts, reader, writer, log)
+}
+
+func replay(ctx context.Context, opts ReplayOptions, reader reader, writer writer, log *slog.Logger) (int, error) {
+	target := RetryTopic(opts.Topic, opts.ConsumerGroup, 1)
+
+	replayed := 0
+	for opts.Limit <= 0 || replayed < opts.Limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, opts.Idle)
+		message, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				break
+			}
+			return replayed, err
+		}
+
+		log.Info("replaying message",
+			"offset", message.Offset,
+			"target", target,
+			"attempt", Header(message, HeaderAttempt),
+			"error", Header(message, HeaderError),
+		)
+
+		if opts.DryRun {
+			replayed++
+			continue
+		}
+
+		err = writer.WriteMessages(ctx, kafka.Message{
+			Topic:   target,
+			Key:     message.Key,
+			Value:   message.Value,
+			Headers: withoutHeaders(message.Headers, HeaderAttempt, HeaderRetryAt, HeaderError, HeaderFailedAt),
+		})
+		if err != nil {
+			return replayed, err
+		}
+		if err := reader.CommitMessages(ctx, message); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
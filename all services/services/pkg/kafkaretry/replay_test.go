@@ -0,0 +1,96 @@
+package kafkaretry
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func dlqMessages() *fakeReader {
+	return newFakeReader(
+		message("help-request.notify.dlq", 0, "first",
+			header("x-message-id", "m1"), header(HeaderOriginalTopic, "help-request"),
+			header(HeaderAttempt, "3"), header(HeaderError, "db is down"), header(HeaderFailedAt, start.Format(time.RFC3339Nano))),
+		message("help-request.notify.dlq", 1, "second", header(HeaderAttempt, "1")),
+	)
+}
+
+var replayOptions = ReplayOptions{Topic: "help-request", ConsumerGroup: "notify", Idle: 10 * time.Millisecond}
+
+func TestReplayWritesToGroupRetryTopic(t *testing.T) {
+	reader, writer := dlqMessages(), &fakeWriter{}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	replayed, err := replay(context.Background(), replayOptions, reader, writer, log)
+	if err != nil {
+		t.Fatalf("replay() error = %v", err)
+	}
+	if replayed != 2 {
+		t.Fatalf("replayed %d, want 2", replayed)
+	}
+
+	written := writer.messages()
+	if len(written) != 2 {
+		t.Fatalf("written %d messages, want 2", len(written))
+	}
+	// в исходный топик не пишем: его читают и другие группы
+	for _, m := range written {
+		if m.Topic != "help-request.notify.retry.1" {
+			t.Errorf("replayed to %s, want help-request.notify.retry.1", m.Topic)
+		}
+	}
+	first := written[0]
+	for _, key := range []string{HeaderAttempt, HeaderError, HeaderFailedAt, HeaderRetryAt} {
+		if got := Header(first, key); got != "" {
+			t.Errorf("header %s = %q, want it dropped", key, got)
+		}
+	}
+	if Header(first, "x-message-id") != "m1" || Header(first, HeaderOriginalTopic) != "help-request" {
+		t.Errorf("headers = %+v, want message id and original topic kept", first.Headers)
+	}
+	if commits := reader.commits(); !slices.Equal(commits, []int64{0, 1}) {
+		t.Errorf("committed %v, want [0 1]", commits)
+	}
+}
+
+func TestReplayDryRunAndLimit(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	reader, writer := dlqMessages(), &fakeWriter{}
+	opts := replayOptions
+	opts.DryRun = true
+	replayed, err := replay(context.Background(), opts, reader, writer, log)
+	if err != nil || replayed != 2 {
+		t.Fatalf("replay() = %d, %v, want 2", replayed, err)
+	}
+	if len(writer.messages()) != 0 || len(reader.commits()) != 0 {
+		t.Fatalf("dry run wrote %d and committed %d messages", len(writer.messages()), len(reader.commits()))
+	}
+
+	reader, writer = dlqMessages(), &fakeWriter{}
+	opts = replayOptions
+	opts.Limit = 1
+	replayed, err = replay(context.Background(), opts, reader, writer, log)
+	if err != nil || replayed != 1 {
+		t.Fatalf("replay() = %d, %v, want 1", replayed, err)
+	}
+	if commits := reader.commits(); !slices.Equal(commits, []int64{0}) {
+		t.Fatalf("committed %v, want [0]", commits)
+	}
+}
+
+func TestReplayStopsOnWriteError(t *testing.T) {
+	reader, writer := dlqMessages(), &fakeWriter{failures: 1}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	replayed, err := replay(context.Background(), replayOptions, reader, writer, log)
+	if err == nil || replayed != 0 {
+		t.Fatalf("replay() = %d, %v, want error", replayed, err)
+	}
+	if commits := reader.commits(); len(commits) != 0 {
+		t.Fatalf("committed %v after failed write", commits)
+	}
+}
@@ -0,0 +1,596 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.28.2
+// source: proto/help/help.proto
+
+package help
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Help_CreateHelpRequest_FullMethodName   = "/help.Help/CreateHelpRequest"
+	Help_GetHelpRequest_FullMethodName      = "/help.Help/GetHelpRequest"
+	Help_CancelHelpRequest_FullMethodName   = "/help.Help/CancelHelpRequest"
+	Help_EscalateHelpRequest_FullMethodName = "/help.Help/EscalateHelpRequest"
+	Help_AcceptHelpRequest_FullMethodName   = "/help.Help/AcceptHelpRequest"
+	Help_AcknowledgeOffer_FullMethodName    = "/help.Help/AcknowledgeOffer"
+	Help_VolunteerConnecting_FullMethodName = "/help.Help/VolunteerConnecting"
+	Help_GetDispatchLog_FullMethodName      = "/help.Help/GetDispatchLog"
+	Help_GetVolunteerStats_FullMethodName   = "/help.Help/GetVolunteerStats"
+	Help_GetUserHistory_FullMethodName      = "/help.Help/GetUserHistory"
+	Help_DeleteUserData_FullMethodName      = "/help.Help/DeleteUserData"
+	Help_RecordCall_FullMethodName          = "/help.Help/RecordCall"
+	Help_SubmitRating_FullMethodName        = "/help.Help/SubmitRating"
+)
+
+// HelpClient is the client API for Help service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HelpClient interface {
+	CreateHelpRequest(ctx context.Context, in *CreateHelpRequestRequest, opts ...grpc.CallOption) (*CreateHelpRequestResponse, error)
+	GetHelpRequest(ctx context.Context, in *GetHelpRequestRequest, opts ...grpc.CallOption) (*HelpRequest, error)
+	// Отменить можно только свой запрос в статусе pending
+	CancelHelpRequest(ctx context.Context, in *CancelHelpRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Срочный запрос сразу уходит следующей волной и предлагается и тем, кто вне своих окон доступности
+	EscalateHelpRequest(ctx context.Context, in *EscalateHelpRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Принять запрос может волонтёр, которому он был предложен, пока запрос в статусе pending
+	AcceptHelpRequest(ctx context.Context, in *AcceptHelpRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Подтверждение доставки предложения. Если волну никто не подтвердил, следующая уходит раньше
+	AcknowledgeOffer(ctx context.Context, in *AcknowledgeOfferRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Вызывается сервисом signal, когда принявший запрос волонтёр вошёл в комнату звонка.
+	// Автор запроса получает ответ connecting.
+	VolunteerConnecting(ctx context.Context, in *VolunteerConnectingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Оценки кандидатов во всех волнах рассылки запроса, для администратора
+	GetDispatchLog(ctx context.Context, in *GetDispatchLogRequest, opts ...grpc.CallOption) (*GetDispatchLogResponse, error)
+	// Нагрузка волонтёра и лимиты, которые сейчас не дают предлагать ему запросы
+	GetVolunteerStats(ctx context.Context, in *GetVolunteerStatsRequest, opts ...grpc.CallOption) (*VolunteerStats, error)
+	GetUserHistory(ctx context.Context, in *GetUserHistoryRequest, opts ...grpc.CallOption) (*GetUserHistoryResponse, error)
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Завершённый звонок переводит запрос в статус completed
+	RecordCall(ctx context.Context, in *RecordCallRequest, opts ...grpc.CallOption) (*RecordCallResponse, error)
+	SubmitRating(ctx context.Context, in *SubmitRatingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type helpClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHelpClient(cc grpc.ClientConnInterface) HelpClient {
+	return &helpClient{cc}
+}
+
+func (c *helpClient) CreateHelpRequest(ctx context.Context, in *CreateHelpRequestRequest, opts ...grpc.CallOption) (*CreateHelpRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateHelpRequestResponse)
+	err := c.cc.Invoke(ctx, Help_CreateHelpRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) GetHelpRequest(ctx context.Context, in *GetHelpRequestRequest, opts ...grpc.CallOption) (*HelpRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HelpRequest)
+	err := c.cc.Invoke(ctx, Help_GetHelpRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) CancelHelpRequest(ctx context.Context, in *CancelHelpRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_CancelHelpRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) EscalateHelpRequest(ctx context.Context, in *EscalateHelpRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_EscalateHelpRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) AcceptHelpRequest(ctx context.Context, in *AcceptHelpRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_AcceptHelpRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) AcknowledgeOffer(ctx context.Context, in *AcknowledgeOfferRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_AcknowledgeOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) VolunteerConnecting(ctx context.Context, in *VolunteerConnectingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_VolunteerConnecting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) GetDispatchLog(ctx context.Context, in *GetDispatchLogRequest, opts ...grpc.CallOption) (*GetDispatchLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDispatchLogResponse)
+	err := c.cc.Invoke(ctx, Help_GetDispatchLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) GetVolunteerStats(ctx context.Context, in *GetVolunteerStatsRequest, opts ...grpc.CallOption) (*VolunteerStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VolunteerStats)
+	err := c.cc.Invoke(ctx, Help_GetVolunteerStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) GetUserHistory(ctx context.Context, in *GetUserHistoryRequest, opts ...grpc.CallOption) (*GetUserHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserHistoryResponse)
+	err := c.cc.Invoke(ctx, Help_GetUserHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) RecordCall(ctx context.Context, in *RecordCallRequest, opts ...grpc.CallOption) (*RecordCallResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordCallResponse)
+	err := c.cc.Invoke(ctx, Help_RecordCall_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *helpClient) SubmitRating(ctx context.Context, in *SubmitRatingRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Help_SubmitRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HelpServer is the server API for Help service.
+// All implementations must embed UnimplementedHelpServer
+// for forward compatibility.
+type HelpServer interface {
+	CreateHelpRequest(context.Context, *CreateHelpRequestRequest) (*CreateHelpRequestResponse, error)
+	GetHelpRequest(context.Context, *GetHelpRequestRequest) (*HelpRequest, error)
+	// Отменить можно только свой запрос в статусе pending
+	CancelHelpRequest(context.Context, *CancelHelpRequestRequest) (*emptypb.Empty, error)
+	// Срочный запрос сразу уходит следующей волной и предлагается и тем, кто вне своих окон доступности
+	EscalateHelpRequest(context.Context, *EscalateHelpRequestRequest) (*emptypb.Empty, error)
+	// Принять запрос может волонтёр, которому он был предложен, пока запрос в статусе pending
+	AcceptHelpRequest(context.Context, *AcceptHelpRequestRequest) (*emptypb.Empty, error)
+	// Подтверждение доставки предложения. Если волну никто не подтвердил, следующая уходит раньше
+	AcknowledgeOffer(context.Context, *AcknowledgeOfferRequest) (*emptypb.Empty, error)
+	// Вызывается сервисом signal, когда принявший запрос волонтёр вошёл в комнату звонка.
+	// Автор запроса получает ответ connecting.
+	VolunteerConnecting(context.Context, *VolunteerConnectingRequest) (*emptypb.Empty, error)
+	// Оценки кандидатов во всех волнах рассылки запроса, для администратора
+	GetDispatchLog(context.Context, *GetDispatchLogRequest) (*GetDispatchLogResponse, error)
+	// Нагрузка волонтёра и лимиты, которые сейчас не дают предлагать ему запросы
+	GetVolunteerStats(context.Context, *GetVolunteerStatsRequest) (*VolunteerStats, error)
+	GetUserHistory(context.Context, *GetUserHistoryRequest) (*GetUserHistoryResponse, error)
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*emptypb.Empty, error)
+	// Завершённый звонок переводит запрос в статус completed
+	RecordCall(context.Context, *RecordCallRequest) (*RecordCallResponse, error)
+	SubmitRating(context.Context, *SubmitRatingRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedHelpServer()
+}
+
+// UnimplementedHelpServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedHelpServer struct{}
+
+func (UnimplementedHelpServer) CreateHelpRequest(context.Context, *CreateHelpRequestRequest) (*CreateHelpRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHelpRequest not implemented")
+}
+func (UnimplementedHelpServer) GetHelpRequest(context.Context, *GetHelpRequestRequest) (*HelpRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHelpRequest not implemented")
+}
+func (UnimplementedHelpServer) CancelHelpRequest(context.Context, *CancelHelpRequestRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelHelpRequest not implemented")
+}
+func (UnimplementedHelpServer) EscalateHelpRequest(context.Context, *EscalateHelpRequestRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EscalateHelpRequest not implemented")
+}
+func (UnimplementedHelpServer) AcceptHelpRequest(context.Context, *AcceptHelpRequestRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptHelpRequest not implemented")
+}
+func (UnimplementedHelpServer) AcknowledgeOffer(context.Context, *AcknowledgeOfferRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeOffer not implemented")
+}
+func (UnimplementedHelpServer) VolunteerConnecting(context.Context, *VolunteerConnectingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolunteerConnecting not implemented")
+}
+func (UnimplementedHelpServer) GetDispatchLog(context.Context, *GetDispatchLogRequest) (*GetDispatchLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDispatchLog not implemented")
+}
+func (UnimplementedHelpServer) GetVolunteerStats(context.Context, *GetVolunteerStatsRequest) (*VolunteerStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolunteerStats not implemented")
+}
+func (UnimplementedHelpServer) GetUserHistory(context.Context, *GetUserHistoryRequest) (*GetUserHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserHistory not implemented")
+}
+func (UnimplementedHelpServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedHelpServer) RecordCall(context.Context, *RecordCallRequest) (*RecordCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordCall not implemented")
+}
+func (UnimplementedHelpServer) SubmitRating(context.Context, *SubmitRatingRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRating not implemented")
+}
+func (UnimplementedHelpServer) mustEmbedUnimplementedHelpServer() {}
+func (UnimplementedHelpServer) testEmbeddedByValue()              {}
+
+// UnsafeHelpServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HelpServer will
+// result in compilation errors.
+type UnsafeHelpServer interface {
+	mustEmbedUnimplementedHelpServer()
+}
+
+func RegisterHelpServer(s grpc.ServiceRegistrar, srv HelpServer) {
+	// If the following call pancis, it indicates UnimplementedHelpServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Help_ServiceDesc, srv)
+}
+
+func _Help_CreateHelpRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHelpRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).CreateHelpRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_CreateHelpRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).CreateHelpRequest(ctx, req.(*CreateHelpRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_GetHelpRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHelpRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).GetHelpRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_GetHelpRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).GetHelpRequest(ctx, req.(*GetHelpRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_CancelHelpRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelHelpRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).CancelHelpRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_CancelHelpRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).CancelHelpRequest(ctx, req.(*CancelHelpRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_EscalateHelpRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EscalateHelpRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).EscalateHelpRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_EscalateHelpRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).EscalateHelpRequest(ctx, req.(*EscalateHelpRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_AcceptHelpRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptHelpRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).AcceptHelpRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_AcceptHelpRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).AcceptHelpRequest(ctx, req.(*AcceptHelpRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_AcknowledgeOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).AcknowledgeOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_AcknowledgeOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).AcknowledgeOffer(ctx, req.(*AcknowledgeOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_VolunteerConnecting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolunteerConnectingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).VolunteerConnecting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_VolunteerConnecting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).VolunteerConnecting(ctx, req.(*VolunteerConnectingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_GetDispatchLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDispatchLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).GetDispatchLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_GetDispatchLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).GetDispatchLog(ctx, req.(*GetDispatchLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_GetVolunteerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolunteerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).GetVolunteerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_GetVolunteerStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).GetVolunteerStats(ctx, req.(*GetVolunteerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_GetUserHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).GetUserHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_GetUserHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).GetUserHistory(ctx, req.(*GetUserHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_RecordCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).RecordCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_RecordCall_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).RecordCall(ctx, req.(*RecordCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Help_SubmitRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HelpServer).SubmitRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Help_SubmitRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HelpServer).SubmitRating(ctx, req.(*SubmitRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Help_ServiceDesc is the grpc.ServiceDesc for Help service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Help_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "help.Help",
+	HandlerType: (*HelpServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateHelpRequest",
+			Handler:    _Help_CreateHelpRequest_Handler,
+		},
+		{
+			MethodName: "GetHelpRequest",
+			Handler:    _Help_GetHelpRequest_Handler,
+		},
+		{
+			MethodName: "CancelHelpRequest",
+			Handler:    _Help_CancelHelpRequest_Handler,
+		},
+		{
+			MethodName: "EscalateHelpRequest",
+			Handler:    _Help_EscalateHelpRequest_Handler,
+		},
+		{
+			MethodName: "AcceptHelpRequest",
+			Handler:    _Help_AcceptHelpRequest_Handler,
+		},
+		{
+			MethodName: "AcknowledgeOffer",
+			Handler:    _Help_AcknowledgeOffer_Handler,
+		},
+		{
+			MethodName: "VolunteerConnecting",
+			Handler:    _Help_VolunteerConnecting_Handler,
+		},
+		{
+			MethodName: "GetDispatchLog",
+			Handler:    _Help_GetDispatchLog_Handler,
+		},
+		{
+			MethodName: "GetVolunteerStats",
+			Handler:    _Help_GetVolunteerStats_Handler,
+		},
+		{
+			MethodName: "GetUserHistory",
+			Handler:    _Help_GetUserHistory_Handler,
+		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _Help_DeleteUserData_Handler,
+		},
+		{
+			MethodName: "RecordCall",
+			Handler:    _Help_RecordCall_Handler,
+		},
+		{
+			MethodName: "SubmitRating",
+			Handler:    _Help_SubmitRating_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/help/help.proto",
+}
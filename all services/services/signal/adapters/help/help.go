@@ -0,0 +1,75 @@
+package help
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	helppb "seeforme/proto/help"
+	"seeforme/signal/core"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client helppb.HelpClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: helppb.NewHelpClient(conn),
+	}, nil
+}
+
+func (c *Client) GetHelpRequest(ctx context.Context, id int64) (core.HelpRequest, error) {
+	response, err := c.client.GetHelpRequest(ctx, &helppb.GetHelpRequestRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return core.HelpRequest{}, core.ErrNotFound
+		}
+		c.log.Error("failed to get help request", "id", id, "error", err)
+		return core.HelpRequest{}, err
+	}
+	return core.HelpRequest{
+		ID:          response.GetId(),
+		RequesterID: response.GetRequesterId(),
+		VolunteerID: response.GetVolunteerId(),
+		Status:      response.GetStatus(),
+	}, nil
+}
+
+func (c *Client) VolunteerConnecting(ctx context.Context, helpRequestID, volunteerID int64) error {
+	_, err := c.client.VolunteerConnecting(ctx, &helppb.VolunteerConnectingRequest{
+		HelpRequestId: helpRequestID,
+		VolunteerId:   volunteerID,
+	})
+	if err != nil {
+		c.log.Error("failed to report volunteer connecting", "help_request", helpRequestID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) RecordCall(ctx context.Context, helpRequestID, volunteerID int64, startedAt, endedAt time.Time) error {
+	_, err := c.client.RecordCall(ctx, &helppb.RecordCallRequest{
+		HelpRequestId: helpRequestID,
+		VolunteerId:   volunteerID,
+		StartedAt:     timestamppb.New(startedAt),
+		EndedAt:       timestamppb.New(endedAt),
+	})
+	if err != nil {
+		c.log.Error("failed to record call", "help_request", helpRequestID, "error", err)
+		return err
+	}
+	return nil
+}
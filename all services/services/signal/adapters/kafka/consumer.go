@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"seeforme/pkg/events"
+	"seeforme/pkg/kafkaretry"
+	"seeforme/signal/core"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Consumer читает события пользователей и завершает звонки заблокированных
+type Consumer struct {
+	*kafkaretry.Consumer
+	hub *core.Hub
+	log *slog.Logger
+}
+
+func NewConsumer(cfg kafkaretry.Config, hub *core.Hub, log *slog.Logger) *Consumer {
+	c := &Consumer{
+		hub: hub,
+		log: log,
+	}
+	c.Consumer = kafkaretry.NewConsumer(cfg, c.handle, log)
+	return c
+}
+
+func (c *Consumer) handle(ctx context.Context, message kafka.Message) error {
+	envelope, err := events.Unmarshal(message.Value)
+	if err != nil {
+		return kafkaretry.Permanent(fmt.Errorf("decode user event: %w", err))
+	}
+
+	if event := envelope.GetUserSuspended(); event != nil {
+		c.log.Info("user suspended, ending calls", "user", event.GetUserId())
+		c.hub.DisconnectUser(ctx, event.GetUserId(), "Аккаунт заблокирован")
+	}
+
+	return nil
+}
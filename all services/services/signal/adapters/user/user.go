@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+
+	userpb "seeforme/proto/user"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client userpb.UserClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: userpb.NewUserClient(conn),
+	}, nil
+}
+
+func (c *Client) CheckJWT(ctx context.Context, userID int64, token string) error {
+	_, err := c.client.CheckJWT(ctx, &userpb.CheckJWTRequest{
+		UserId: userID,
+		Token:  token,
+	})
+	if err != nil {
+		c.log.Error("failed to check jwt", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+	response, err := c.client.IsBlocked(ctx, &userpb.IsBlockedRequest{
+		UserId:      userID,
+		OtherUserId: otherUserID,
+	})
+	if err != nil {
+		c.log.Error("failed to check block", "error", err)
+		return false, err
+	}
+	return response.GetBlocked(), nil
+}
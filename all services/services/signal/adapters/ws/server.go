@@ -0,0 +1,119 @@
+package ws
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"seeforme/signal/core"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const writeTimeout = 10 * time.Second
+
+type conn struct {
+	mu sync.Mutex
+	ws *websocket.Conn
+}
+
+func (c *conn) Send(msg core.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ws.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return c.ws.WriteJSON(msg)
+}
+
+func (c *conn) ping() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+}
+
+func (c *conn) Close() error {
+	return c.ws.Close()
+}
+
+type Handler struct {
+	log      *slog.Logger
+	hub      *core.Hub
+	upgrader websocket.Upgrader
+	// pingInterval - как часто проверять соединение. Без ответа за два интервала
+	// соединение считается оборванным, и у клиента начинается время на переподключение.
+	pingInterval time.Duration
+}
+
+func NewHandler(log *slog.Logger, hub *core.Hub, pingInterval time.Duration) *Handler {
+	return &Handler{
+		log:          log,
+		hub:          hub,
+		pingInterval: pingInterval,
+		upgrader: websocket.Upgrader{
+			// мобильные клиенты не присылают Origin
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.log.Error("failed to upgrade connection", "error", err)
+		return
+	}
+	h.log.Info("new client connected", "remote", r.RemoteAddr)
+
+	c := &conn{ws: wsConn}
+	session := core.NewSession(c)
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		h.hub.Disconnect(session)
+		c.Close()
+	}()
+
+	// мобильная сеть может пропасть без закрытия TCP, поэтому обрыв ловится по ping/pong
+	wait := 2 * h.pingInterval
+	wsConn.SetReadDeadline(time.Now().Add(wait))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(wait))
+	})
+	go h.keepalive(c, done)
+
+	for {
+		_, data, err := wsConn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				h.log.Error("connection error", "error", err)
+			}
+			return
+		}
+
+		var msg core.Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			h.log.Error("failed to decode message", "error", err)
+			continue
+		}
+
+		h.hub.Handle(r.Context(), session, msg)
+	}
+}
+
+func (h *Handler) keepalive(c *conn, done <-chan struct{}) {
+	ticker := time.NewTicker(h.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := c.ping(); err != nil {
+				h.log.Debug("failed to ping client", "error", err)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+type KafkaConfig struct {
+	Brokers         []string        `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092"`
+	UserEventsTopic string          `yaml:"user_events_topic" env:"KAFKA_USER_EVENTS_TOPIC" env-default:"user-events"`
+	GroupID         string          `yaml:"group_id" env:"KAFKA_GROUP_ID" env-default:"signal"`
+	RetryDelays     []time.Duration `yaml:"retry_delays" env:"KAFKA_RETRY_DELAYS" env-default:"10s,1m,10m"`
+}
+
+type Config struct {
+	LogLevel    string      `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
+	Address     string      `yaml:"signal_address" env:"SIGNAL_ADDRESS" env-default:"localhost:3000"`
+	UserAddress string      `yaml:"user_address" env:"USER_ADDRESS" env-default:"localhost:81"`
+	HelpAddress string      `yaml:"help_address" env:"HELP_ADDRESS" env-default:"localhost:83"`
+	KafkaConfig KafkaConfig `yaml:"kafka"`
+	// ReconnectGrace - сколько место участника в комнате ждёт его после обрыва соединения
+	ReconnectGrace time.Duration `yaml:"reconnect_grace" env:"RECONNECT_GRACE" env-default:"30s"`
+	PingInterval   time.Duration `yaml:"ping_interval" env:"PING_INTERVAL" env-default:"10s"`
+}
+
+func MustLoad(configPath string) Config {
+	var cfg Config
+	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+		if err := cleanenv.ReadEnv(&cfg); err != nil {
+			log.Fatalf("cannot read config %q: %s", configPath, err)
+		}
+	}
+
+	if len(cfg.KafkaConfig.Brokers) == 1 && strings.Contains(cfg.KafkaConfig.Brokers[0], ",") {
+		cfg.KafkaConfig.Brokers = strings.Split(cfg.KafkaConfig.Brokers[0], ",")
+	}
+
+	return cfg
+}
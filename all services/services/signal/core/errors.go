@@ -0,0 +1,16 @@
+package core
+
+import "errors"
+
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrNameTaken    = errors.New("username is taken")
+	ErrNotLoggedIn  = errors.New("not logged in")
+	ErrCallBlocked  = errors.New("call is not allowed")
+	ErrNoRoom       = errors.New("help request id is required")
+	ErrNotFound     = errors.New("help request not found")
+	ErrNotAccepted  = errors.New("help request is not accepted")
+	ErrNotMember    = errors.New("user is not a participant of the help request")
+	ErrRoomClosed   = errors.New("call room is closed")
+	ErrNoSession    = errors.New("session expired")
+)
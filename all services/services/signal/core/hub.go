@@ -0,0 +1,431 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Session - состояние одного подключения
+type Session struct {
+	conn     Conn
+	name     string
+	userID   int64
+	userType string
+	role     string
+	room     *Room
+	// token - по нему участник возвращается в комнату с нового соединения
+	token string
+	// online - соединение живо; после обрыва место в комнате ждёт участника grace
+	online bool
+}
+
+func NewSession(conn Conn) *Session {
+	return &Session{conn: conn}
+}
+
+// Hub держит комнаты звонков: по одной на принятый запрос помощи.
+// В комнату входят только автор запроса и взявший его волонтёр, сообщения пересылаются внутри комнаты.
+// Если соединение участника оборвалось, его место держится grace: за это время он может вернуться
+// по sessionToken, и звонок продолжится после ICE restart. Иначе комната закрывается.
+type Hub struct {
+	log    *slog.Logger
+	auth   Auth
+	blocks Blocks
+	help   Help
+	clock  Clock
+	grace  time.Duration
+
+	mu     sync.Mutex
+	rooms  map[int64]*Room
+	tokens map[string]*Session
+}
+
+func NewHub(log *slog.Logger, auth Auth, blocks Blocks, help Help, clock Clock, grace time.Duration) *Hub {
+	return &Hub{
+		log:    log,
+		auth:   auth,
+		blocks: blocks,
+		help:   help,
+		clock:  clock,
+		grace:  grace,
+		rooms:  make(map[int64]*Room),
+		tokens: make(map[string]*Session),
+	}
+}
+
+func (h *Hub) Handle(ctx context.Context, s *Session, msg Message) {
+	// комнату сессии меняют close и detach из других горутин, поэтому читаем её один раз под мьютексом
+	h.mu.Lock()
+	room := s.room
+	h.mu.Unlock()
+
+	if msg.Type != TypeLogin && msg.Type != TypeResume && room == nil {
+		h.send(s, errorMessage(ErrNotLoggedIn.Error()))
+		return
+	}
+
+	switch msg.Type {
+	case TypeLogin:
+		h.login(ctx, s, room, msg)
+	case TypeResume:
+		h.resume(s, room, msg)
+	case TypeOffer:
+		h.offer(ctx, s, room, msg)
+	case TypeRestart:
+		h.forward(s, room, Message{Type: TypeRestart, Offer: msg.Offer, Name: s.name}, true)
+	case TypeAnswer:
+		h.forward(s, room, Message{Type: TypeAnswer, Answer: msg.Answer, Name: s.name}, true)
+	case TypeCandidate:
+		h.forward(s, room, Message{Type: TypeCandidate, Candidate: msg.Candidate, Name: s.name}, false)
+	case TypeLeave:
+		h.close(ctx, room, s, "Другой участник завершил звонок")
+	default:
+		h.send(s, errorMessage("Unknown message type"))
+	}
+}
+
+// login проверяет токен и сажает пользователя в комнату запроса msg.HelpRequestID.
+// Повторный вход того же пользователя заменяет прежнее соединение: так клиент переподключается.
+func (h *Hub) login(ctx context.Context, s *Session, current *Room, msg Message) {
+	failed := false
+	if current != nil {
+		h.send(s, Message{Type: TypeLogin, Success: &failed, Message: "Already logged in"})
+		return
+	}
+	if err := h.auth.CheckJWT(ctx, msg.UserID, msg.Token); err != nil {
+		h.log.Error("login rejected", "user", msg.UserID, "error", err)
+		h.send(s, Message{Type: TypeLogin, Success: &failed, Message: ErrUnauthorized.Error()})
+		return
+	}
+
+	request, err := h.request(ctx, msg.HelpRequestID, msg.UserID)
+	if err != nil {
+		h.log.Info("room join rejected", "user", msg.UserID, "help_request", msg.HelpRequestID, "error", err)
+		h.send(s, Message{Type: TypeLogin, Success: &failed, Message: err.Error()})
+		return
+	}
+
+	token, err := newToken()
+	if err != nil {
+		h.log.Error("failed to generate session token", "error", err)
+		h.send(s, Message{Type: TypeLogin, Success: &failed, Message: "Internal error"})
+		return
+	}
+
+	s.name = msg.Name
+	s.userID = msg.UserID
+	s.userType = msg.UserType
+	if s.userType == "" {
+		s.userType = "user"
+	}
+	s.token = token
+	s.online = true
+
+	h.mu.Lock()
+	room, ok := h.rooms[request.ID]
+	if !ok {
+		room = newRoom(request)
+		h.rooms[request.ID] = room
+	}
+	s.role = room.role(s.userID)
+	replaced := room.sessions[s.userID]
+	room.sessions[s.userID] = s
+	s.room = room
+	h.tokens[s.token] = s
+	replacedOnline := false
+	if replaced != nil {
+		replacedOnline = replaced.online
+		h.detach(replaced)
+	}
+	peer := room.peer(s)
+	peerOnline := peer != nil && peer.online
+	h.mu.Unlock()
+
+	if replacedOnline {
+		h.log.Info("user reconnected, closing previous connection", "user", s.userID, "help_request", request.ID)
+		if err := replaced.conn.Close(); err != nil {
+			h.log.Error("failed to close connection", "user", s.userID, "error", err)
+		}
+	}
+
+	success := true
+	h.send(s, Message{Type: TypeLogin, Success: &success, Role: s.role, UserType: s.userType,
+		HelpRequestID: request.ID, SessionToken: s.token})
+	h.log.Info("user joined room", "user", s.userID, "help_request", request.ID, "role", s.role)
+
+	// автор запроса видит, что волонтёр уже подключается; при переподключении не повторяем
+	if s.userID == request.VolunteerID && replaced == nil {
+		if err := h.help.VolunteerConnecting(ctx, request.ID, s.userID); err != nil {
+			h.log.Error("failed to report volunteer connecting", "help_request", request.ID, "error", err)
+		}
+	}
+
+	if peerOnline && h.allowed(ctx, s, peer) {
+		h.mu.Lock()
+		room.start(h.clock.Now())
+		h.mu.Unlock()
+
+		h.log.Info("both participants are in the room, call can start", "help_request", request.ID)
+		h.send(s, Message{Type: TypeReady, HelpRequestID: request.ID})
+		h.send(peer, Message{Type: TypeReady, HelpRequestID: request.ID})
+	}
+}
+
+// request проверяет, что запрос принят и пользователь - его участник
+func (h *Hub) request(ctx context.Context, id, userID int64) (HelpRequest, error) {
+	if id <= 0 {
+		return HelpRequest{}, ErrNoRoom
+	}
+
+	request, err := h.help.GetHelpRequest(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return HelpRequest{}, ErrNotFound
+		}
+		return HelpRequest{}, err
+	}
+	if userID != request.RequesterID && userID != request.VolunteerID {
+		return HelpRequest{}, ErrNotMember
+	}
+	if request.Status != StatusAccepted {
+		return HelpRequest{}, ErrRoomClosed
+	}
+
+	return request, nil
+}
+
+// resume возвращает участника в комнату по sessionToken с нового соединения.
+// Токен одноразовый: в ответе приходит новый.
+func (h *Hub) resume(s *Session, current *Room, msg Message) {
+	failed := false
+	if current != nil {
+		h.send(s, Message{Type: TypeResume, Success: &failed, Message: "Already logged in"})
+		return
+	}
+	token, err := newToken()
+	if err != nil {
+		h.log.Error("failed to generate session token", "error", err)
+		h.send(s, Message{Type: TypeResume, Success: &failed, Message: "Internal error"})
+		return
+	}
+
+	h.mu.Lock()
+	old, ok := h.tokens[msg.SessionToken]
+	if !ok || old.room == nil {
+		h.mu.Unlock()
+		h.send(s, Message{Type: TypeResume, Success: &failed, Message: ErrNoSession.Error()})
+		return
+	}
+	room := old.room
+	oldOnline := old.online
+	h.detach(old)
+
+	s.name = old.name
+	s.userID = old.userID
+	s.userType = old.userType
+	s.role = old.role
+	s.room = room
+	s.token = token
+	s.online = true
+	room.sessions[s.userID] = s
+	h.tokens[s.token] = s
+	peer := room.peer(s)
+	peerOnline := peer != nil && peer.online
+	h.mu.Unlock()
+
+	if oldOnline {
+		// телефон сменил сеть раньше, чем сервер заметил обрыв старого соединения
+		if err := old.conn.Close(); err != nil {
+			h.log.Error("failed to close connection", "user", s.userID, "error", err)
+		}
+	}
+
+	success := true
+	h.send(s, Message{Type: TypeResume, Success: &success, Role: s.role, UserType: s.userType,
+		HelpRequestID: room.request.ID, SessionToken: s.token})
+	h.log.Info("user resumed session", "user", s.userID, "help_request", room.request.ID)
+
+	if peerOnline {
+		h.send(s, Message{Type: TypeReconnected, HelpRequestID: room.request.ID})
+		h.send(peer, Message{Type: TypeReconnected, Name: s.name, HelpRequestID: room.request.ID})
+	}
+}
+
+// detach отвязывает сессию от комнаты и токена. Вызывается под h.mu.
+func (h *Hub) detach(s *Session) {
+	delete(h.tokens, s.token)
+	s.room = nil
+	s.online = false
+}
+
+// allowed не даёт соединить пользователей, если один из них заблокировал другого
+func (h *Hub) allowed(ctx context.Context, a, b *Session) bool {
+	blocked, err := h.blocks.IsBlocked(ctx, a.userID, b.userID)
+	if err != nil {
+		h.log.Error("failed to check block list", "user", a.userID, "other", b.userID, "error", err)
+		return false
+	}
+	if blocked {
+		h.log.Info("users blocked each other", "user", a.userID, "other", b.userID)
+	}
+	return !blocked
+}
+
+func (h *Hub) offer(ctx context.Context, s *Session, room *Room, msg Message) {
+	h.mu.Lock()
+	peer := room.peer(s)
+	h.mu.Unlock()
+
+	if peer != nil && !h.allowed(ctx, s, peer) {
+		h.send(s, errorMessage(ErrCallBlocked.Error()))
+		return
+	}
+
+	h.forward(s, room, Message{Type: TypeOffer, Offer: msg.Offer, Name: s.name}, true)
+}
+
+// forward пересылает сообщение второму участнику комнаты
+func (h *Hub) forward(s *Session, room *Room, msg Message, startsCall bool) {
+	h.mu.Lock()
+	var peer *Session
+	if !room.closed {
+		peer = room.peer(s)
+	}
+	if peer != nil && !peer.online {
+		peer = nil
+	}
+	h.mu.Unlock()
+
+	if peer == nil {
+		if startsCall {
+			h.send(s, errorMessage("Target user not connected."))
+		}
+		h.log.Error("peer not connected", "type", msg.Type, "from", s.userID)
+		return
+	}
+
+	h.send(peer, msg)
+	h.log.Debug("message forwarded", "type", msg.Type, "from", s.userID, "to", peer.userID)
+}
+
+// close закрывает комнату и записывает состоявшийся звонок в сервис помощи.
+// Участникам, кроме from, приходит call_ended с reason.
+func (h *Hub) close(ctx context.Context, room *Room, from *Session, reason string) {
+	h.mu.Lock()
+	if room.closed {
+		h.mu.Unlock()
+		return
+	}
+	room.closed = true
+	delete(h.rooms, room.request.ID)
+	sessions := make([]*Session, 0, len(room.sessions))
+	for _, session := range room.sessions {
+		if session.online {
+			sessions = append(sessions, session)
+		}
+		h.detach(session)
+	}
+	startedAt := room.startedAt
+	h.mu.Unlock()
+
+	for _, session := range sessions {
+		if session == from {
+			continue
+		}
+		if from != nil {
+			h.send(session, Message{Type: TypeLeave, Name: from.name})
+		}
+		h.send(session, Message{Type: TypeCallEnded, Message: reason})
+	}
+	h.log.Info("room closed", "help_request", room.request.ID)
+
+	// звонок, который так и не начался, не записывается, и запрос остаётся принятым
+	if startedAt == nil {
+		return
+	}
+	err := h.help.RecordCall(ctx, room.request.ID, room.request.VolunteerID, *startedAt, h.clock.Now())
+	if err != nil {
+		h.log.Error("failed to record call", "help_request", room.request.ID, "error", err)
+	}
+}
+
+// Disconnect вызывается после закрытия соединения. Место участника в комнате держится grace,
+// чтобы он мог вернуться по sessionToken; если не вернулся, комната закрывается.
+func (h *Hub) Disconnect(s *Session) {
+	h.mu.Lock()
+	room := s.room
+	if room == nil || room.sessions[s.userID] != s {
+		h.mu.Unlock()
+		return
+	}
+	s.online = false
+	peer := room.peer(s)
+	peerOnline := peer != nil && peer.online
+	h.mu.Unlock()
+
+	if peerOnline {
+		h.send(peer, Message{Type: TypePeerDisconnected, Name: s.name, HelpRequestID: room.request.ID})
+	}
+	h.log.Info("client disconnected, waiting for reconnect", "user", s.userID, "help_request", room.request.ID, "grace", h.grace)
+
+	go h.expire(room, s)
+}
+
+// expire закрывает комнату, если участник не вернулся за grace
+func (h *Hub) expire(room *Room, s *Session) {
+	<-h.clock.After(h.grace)
+
+	h.mu.Lock()
+	gone := !room.closed && room.sessions[s.userID] == s && !s.online
+	h.mu.Unlock()
+	if !gone {
+		return
+	}
+
+	h.log.Info("user did not reconnect in time", "user", s.userID, "help_request", room.request.ID)
+	h.close(context.Background(), room, s, "Собеседник не переподключился")
+}
+
+// DisconnectUser завершает звонки и соединения пользователя, например при блокировке
+func (h *Hub) DisconnectUser(ctx context.Context, userID int64, reason string) {
+	h.mu.Lock()
+	var rooms []*Room
+	var sessions []*Session
+	for _, room := range h.rooms {
+		if s, ok := room.sessions[userID]; ok {
+			rooms = append(rooms, room)
+			if s.online {
+				sessions = append(sessions, s)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	for _, room := range rooms {
+		h.close(ctx, room, nil, reason)
+	}
+	for _, s := range sessions {
+		if err := s.conn.Close(); err != nil {
+			h.log.Error("failed to close connection", "user", s.userID, "error", err)
+		}
+	}
+}
+
+func (h *Hub) send(s *Session, msg Message) {
+	if err := s.conn.Send(msg); err != nil {
+		h.log.Error("failed to send message", "user", s.userID, "type", msg.Type, "error", err)
+	}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,351 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"seeforme/pkg/clock"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+const grace = 30 * time.Second
+
+// fakeConn запоминает отправленные клиенту сообщения
+type fakeConn struct {
+	mu       sync.Mutex
+	messages []Message
+	closed   bool
+}
+
+func (c *fakeConn) Send(msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+func (c *fakeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConn) types() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var types []string
+	for _, msg := range c.messages {
+		types = append(types, msg.Type)
+	}
+	return types
+}
+
+// last - последнее сообщение типа typ
+func (c *fakeConn) last(t *testing.T, typ string) Message {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := len(c.messages) - 1; i >= 0; i-- {
+		if c.messages[i].Type == typ {
+			return c.messages[i]
+		}
+	}
+	t.Fatalf("no %q message in %+v", typ, c.messages)
+	return Message{}
+}
+
+// fakeAuth принимает токен вида "jwt-<id>"
+type fakeAuth struct{}
+
+func (fakeAuth) CheckJWT(ctx context.Context, userID int64, token string) error {
+	if token != fmt.Sprintf("jwt-%d", userID) {
+		return errors.New("bad token")
+	}
+	return nil
+}
+
+type fakeBlocks struct {
+	blocked bool
+}
+
+func (b *fakeBlocks) IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+	return b.blocked, nil
+}
+
+type fakeCall struct {
+	helpRequestID, volunteerID int64
+	startedAt, endedAt         time.Time
+}
+
+type fakeHelp struct {
+	mu         sync.Mutex
+	requests   map[int64]HelpRequest
+	calls      []fakeCall
+	connecting []int64
+}
+
+func (h *fakeHelp) GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error) {
+	request, ok := h.requests[id]
+	if !ok {
+		return HelpRequest{}, ErrNotFound
+	}
+	return request, nil
+}
+
+func (h *fakeHelp) RecordCall(ctx context.Context, helpRequestID, volunteerID int64, startedAt, endedAt time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = append(h.calls, fakeCall{helpRequestID, volunteerID, startedAt, endedAt})
+	return nil
+}
+
+func (h *fakeHelp) VolunteerConnecting(ctx context.Context, helpRequestID, volunteerID int64) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.connecting = append(h.connecting, volunteerID)
+	return nil
+}
+
+func (h *fakeHelp) recorded() []fakeCall {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.calls)
+}
+
+type hubTest struct {
+	hub    *Hub
+	help   *fakeHelp
+	blocks *fakeBlocks
+	clock  *clock.Fake
+}
+
+// newHubTest - запрос 1 автора 100 принят волонтёром 7, запрос 2 ещё ждёт волонтёра
+func newHubTest() *hubTest {
+	ht := &hubTest{
+		help: &fakeHelp{requests: map[int64]HelpRequest{
+			1: {ID: 1, RequesterID: 100, VolunteerID: 7, Status: StatusAccepted},
+			2: {ID: 2, RequesterID: 100, Status: "pending"},
+		}},
+		blocks: &fakeBlocks{},
+		clock:  clock.NewFake(start),
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ht.hub = NewHub(log, fakeAuth{}, ht.blocks, ht.help, ht.clock, grace)
+	return ht
+}
+
+// login входит в комнату запроса с нового соединения
+func (ht *hubTest) login(userID, helpRequestID int64) (*Session, *fakeConn) {
+	conn := &fakeConn{}
+	s := NewSession(conn)
+	ht.hub.Handle(context.Background(), s, Message{
+		Type:          TypeLogin,
+		Name:          fmt.Sprintf("user-%d", userID),
+		UserID:        userID,
+		Token:         fmt.Sprintf("jwt-%d", userID),
+		HelpRequestID: helpRequestID,
+	})
+	return s, conn
+}
+
+func (ht *hubTest) resume(token string) (*Session, *fakeConn) {
+	conn := &fakeConn{}
+	s := NewSession(conn)
+	ht.hub.Handle(context.Background(), s, Message{Type: TypeResume, SessionToken: token})
+	return s, conn
+}
+
+func succeeded(msg Message) bool {
+	return msg.Success != nil && *msg.Success
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLoginJoinsBothParticipants(t *testing.T) {
+	ht := newHubTest()
+
+	_, volunteer := ht.login(7, 1)
+	login := volunteer.last(t, TypeLogin)
+	if !succeeded(login) || login.Role != RoleCaller || login.SessionToken == "" {
+		t.Fatalf("volunteer login = %+v, want caller with session token", login)
+	}
+	if !slices.Equal(ht.help.connecting, []int64{7}) {
+		t.Fatalf("volunteer connecting reported for %v, want [7]", ht.help.connecting)
+	}
+
+	_, requester := ht.login(100, 1)
+	if login := requester.last(t, TypeLogin); !succeeded(login) || login.Role != RoleCallee {
+		t.Fatalf("requester login = %+v, want callee", login)
+	}
+	for name, conn := range map[string]*fakeConn{"volunteer": volunteer, "requester": requester} {
+		if ready := conn.last(t, TypeReady); ready.HelpRequestID != 1 {
+			t.Errorf("%s ready = %+v, want help request 1", name, ready)
+		}
+	}
+}
+
+func TestLoginRejectsOutsiders(t *testing.T) {
+	ht := newHubTest()
+
+	tests := []struct {
+		name                  string
+		userID, helpRequestID int64
+		token                 string
+		want                  string
+	}{
+		{"bad token", 7, 1, "jwt-8", ErrUnauthorized.Error()},
+		{"no room", 7, 0, "jwt-7", ErrNoRoom.Error()},
+		{"unknown request", 7, 3, "jwt-7", ErrNotFound.Error()},
+		{"not a participant", 8, 1, "jwt-8", ErrNotMember.Error()},
+		{"not accepted", 100, 2, "jwt-100", ErrRoomClosed.Error()},
+	}
+	for _, tt := range tests {
+		conn := &fakeConn{}
+		ht.hub.Handle(context.Background(), NewSession(conn), Message{Type: TypeLogin, UserID: tt.userID, Token: tt.token, HelpRequestID: tt.helpRequestID})
+		if login := conn.last(t, TypeLogin); succeeded(login) || login.Message != tt.want {
+			t.Errorf("%s: login = %+v, want failure %q", tt.name, login, tt.want)
+		}
+	}
+	if len(ht.help.connecting) != 0 {
+		t.Fatalf("volunteer connecting reported for %v", ht.help.connecting)
+	}
+}
+
+func TestLoginOfBlockedUsersDoesNotStartCall(t *testing.T) {
+	ht := newHubTest()
+	ht.blocks.blocked = true
+
+	_, volunteer := ht.login(7, 1)
+	_, requester := ht.login(100, 1)
+	if slices.Contains(volunteer.types(), TypeReady) || slices.Contains(requester.types(), TypeReady) {
+		t.Fatalf("blocked users got ready: %v, %v", volunteer.types(), requester.types())
+	}
+}
+
+func TestRepeatedLoginReplacesConnection(t *testing.T) {
+	ht := newHubTest()
+	_, first := ht.login(7, 1)
+
+	_, second := ht.login(7, 1)
+	if !succeeded(second.last(t, TypeLogin)) || !first.closed {
+		t.Fatalf("second login = %v, first closed = %v; want success and closed", second.types(), first.closed)
+	}
+	// о переподключении автору запроса не сообщаем повторно
+	if !slices.Equal(ht.help.connecting, []int64{7}) {
+		t.Fatalf("volunteer connecting reported for %v, want [7]", ht.help.connecting)
+	}
+}
+
+func TestResumeAfterDisconnect(t *testing.T) {
+	ht := newHubTest()
+	session, volunteer := ht.login(7, 1)
+	_, requester := ht.login(100, 1)
+	token := volunteer.last(t, TypeLogin).SessionToken
+
+	ht.hub.Disconnect(session)
+	if got := requester.last(t, TypePeerDisconnected); got.Name != "user-7" {
+		t.Fatalf("peer_disconnected = %+v, want from user-7", got)
+	}
+
+	resumedSession, resumed := ht.resume(token)
+	resume := resumed.last(t, TypeResume)
+	if !succeeded(resume) || resume.Role != RoleCaller || resume.SessionToken == "" || resume.SessionToken == token {
+		t.Fatalf("resume = %+v, want caller with a new session token", resume)
+	}
+	resumed.last(t, TypeReconnected)
+	requester.last(t, TypeReconnected)
+
+	// после возвращения звонок идёт дальше: restart доходит до собеседника
+	ht.hub.Handle(context.Background(), resumedSession, Message{Type: TypeRestart})
+	requester.last(t, TypeRestart)
+
+	// токен одноразовый
+	if _, again := ht.resume(token); succeeded(again.last(t, TypeResume)) {
+		t.Fatal("old session token resumed twice")
+	}
+
+	// grace истёк уже после возвращения - комната не закрывается
+	waitFor(t, func() bool { return ht.clock.Waiters() == 1 })
+	ht.clock.Advance(grace)
+	time.Sleep(10 * time.Millisecond)
+	if slices.Contains(requester.types(), TypeCallEnded) || len(ht.help.recorded()) != 0 {
+		t.Fatalf("room closed after resume: %v, calls %+v", requester.types(), ht.help.recorded())
+	}
+}
+
+func TestGraceExpiryClosesRoomAndRecordsCall(t *testing.T) {
+	ht := newHubTest()
+	session, _ := ht.login(7, 1)
+	_, requester := ht.login(100, 1)
+
+	ht.clock.Advance(time.Minute)
+	ht.hub.Disconnect(session)
+	waitFor(t, func() bool { return ht.clock.Waiters() == 1 })
+	if len(ht.help.recorded()) != 0 {
+		t.Fatal("call recorded before grace expired")
+	}
+
+	ht.clock.Advance(grace)
+	waitFor(t, func() bool { return len(ht.help.recorded()) == 1 })
+	want := fakeCall{helpRequestID: 1, volunteerID: 7, startedAt: start, endedAt: start.Add(time.Minute + grace)}
+	if got := ht.help.recorded()[0]; got != want {
+		t.Fatalf("recorded call = %+v, want %+v", got, want)
+	}
+	requester.last(t, TypeCallEnded)
+
+	// комната закрыта, в неё не вернуться
+	if _, again := ht.login(7, 1); !succeeded(again.last(t, TypeLogin)) {
+		t.Fatal("login after close rejected, want a new room while the request is accepted")
+	}
+}
+
+func TestLeaveRecordsCall(t *testing.T) {
+	ht := newHubTest()
+	session, _ := ht.login(7, 1)
+	_, requester := ht.login(100, 1)
+
+	ht.clock.Advance(5 * time.Minute)
+	ht.hub.Handle(context.Background(), session, Message{Type: TypeLeave})
+
+	if got := requester.last(t, TypeLeave); got.Name != "user-7" {
+		t.Fatalf("leave = %+v, want from user-7", got)
+	}
+	requester.last(t, TypeCallEnded)
+	want := []fakeCall{{helpRequestID: 1, volunteerID: 7, startedAt: start, endedAt: start.Add(5 * time.Minute)}}
+	if got := ht.help.recorded(); !slices.Equal(got, want) {
+		t.Fatalf("recorded calls = %+v, want %+v", got, want)
+	}
+
+	// повторный leave из той же сессии уже вне комнаты
+	ht.hub.Handle(context.Background(), session, Message{Type: TypeLeave})
+	if got := ht.help.recorded(); len(got) != 1 {
+		t.Fatalf("recorded calls = %+v after second leave", got)
+	}
+}
+
+func TestLeaveBeforeCallStartedIsNotRecorded(t *testing.T) {
+	ht := newHubTest()
+	session, _ := ht.login(7, 1)
+
+	ht.hub.Handle(context.Background(), session, Message{Type: TypeLeave})
+	if got := ht.help.recorded(); len(got) != 0 {
+		t.Fatalf("recorded calls = %+v, want none", got)
+	}
+}
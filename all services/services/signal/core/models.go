@@ -0,0 +1,61 @@
+package core
+
+import "encoding/json"
+
+const (
+	TypeLogin     = "login"
+	TypeReady     = "ready"
+	TypeOffer     = "offer"
+	TypeAnswer    = "answer"
+	TypeCandidate = "candidate"
+	TypeLeave     = "leave"
+	TypeCallEnded = "call_ended"
+	TypeError     = "error"
+	// TypePeerDisconnected - соединение собеседника оборвалось, комната ждёт его переподключения
+	TypePeerDisconnected = "peer_disconnected"
+	// TypeResume - вход в свою комнату после обрыва соединения по sessionToken вместо JWT
+	TypeResume = "resume"
+	// TypeReconnected - оба участника снова на связи, звонящий должен прислать restart
+	TypeReconnected = "reconnected"
+	// TypeRestart - offer с ICE restart, пересылается как обычный offer
+	TypeRestart = "restart"
+)
+
+// StatusAccepted - статус запроса помощи, для которого открыта комната звонка
+const StatusAccepted = "accepted"
+
+type HelpRequest struct {
+	ID          int64
+	RequesterID int64
+	VolunteerID int64
+	Status      string
+}
+
+const (
+	RoleCaller = "caller"
+	RoleCallee = "callee"
+)
+
+// Message - сообщение протокола сигналинга, совместимое с server.js
+type Message struct {
+	Type      string          `json:"type"`
+	Name      string          `json:"name,omitempty"`
+	Target    string          `json:"target,omitempty"`
+	UserType  string          `json:"userType,omitempty"`
+	UserID    int64           `json:"userId,omitempty"`
+	Token     string          `json:"token,omitempty"`
+	Offer     json.RawMessage `json:"offer,omitempty"`
+	Answer    json.RawMessage `json:"answer,omitempty"`
+	Candidate json.RawMessage `json:"candidate,omitempty"`
+	Success   *bool           `json:"success,omitempty"`
+	Role      string          `json:"role,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	// HelpRequestID - комната звонка, в которую входит клиент при login
+	HelpRequestID int64 `json:"helpRequestId,omitempty"`
+	// SessionToken выдаётся при входе в комнату, с ним можно вернуться в неё после обрыва соединения
+	SessionToken string `json:"sessionToken,omitempty"`
+}
+
+func errorMessage(text string) Message {
+	return Message{Type: TypeError, Message: text}
+}
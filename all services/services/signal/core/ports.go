@@ -0,0 +1,34 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Conn - соединение с клиентом. Send должен быть безопасен для вызова из разных горутин.
+type Conn interface {
+	Send(msg Message) error
+	Close() error
+}
+
+type Auth interface {
+	CheckJWT(ctx context.Context, userID int64, token string) error
+}
+
+type Blocks interface {
+	IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error)
+}
+
+type Help interface {
+	// GetHelpRequest - ErrNotFound, если запроса нет
+	GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error)
+	// RecordCall сохраняет завершённый звонок, после этого запрос помощи закрыт
+	RecordCall(ctx context.Context, helpRequestID, volunteerID int64, startedAt, endedAt time.Time) error
+	// VolunteerConnecting сообщает автору запроса, что волонтёр вошёл в комнату
+	VolunteerConnecting(ctx context.Context, helpRequestID, volunteerID int64) error
+}
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
@@ -0,0 +1,40 @@
+package core
+
+import "time"
+
+// Room - комната звонка по принятому запросу помощи. Поля защищены мьютексом Hub.
+type Room struct {
+	request HelpRequest
+	// sessions - подключённые участники по id пользователя, не больше двух
+	sessions map[int64]*Session
+	// startedAt - когда оба участника впервые оказались в комнате
+	startedAt *time.Time
+	closed    bool
+}
+
+func newRoom(request HelpRequest) *Room {
+	return &Room{request: request, sessions: make(map[int64]*Session, 2)}
+}
+
+// role - звонок начинает волонтёр, автор запроса отвечает
+func (r *Room) role(userID int64) string {
+	if userID == r.request.VolunteerID {
+		return RoleCaller
+	}
+	return RoleCallee
+}
+
+func (r *Room) peer(s *Session) *Session {
+	for userID, peer := range r.sessions {
+		if userID != s.userID {
+			return peer
+		}
+	}
+	return nil
+}
+
+func (r *Room) start(now time.Time) {
+	if r.startedAt == nil {
+		r.startedAt = &now
+	}
+}
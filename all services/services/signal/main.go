@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"seeforme/pkg/clock"
+	eventspkg "seeforme/pkg/events"
+	"seeforme/pkg/kafkaretry"
+	"seeforme/signal/adapters/help"
+	"seeforme/signal/adapters/kafka"
+	"seeforme/signal/adapters/user"
+	"seeforme/signal/adapters/ws"
+	"seeforme/signal/config"
+	"seeforme/signal/core"
+)
+
+func main() {
+	var configPath string
+	flag.StringVar(&configPath, "config", "config.yaml", "server configuration file")
+	flag.Parse()
+
+	cfg := config.MustLoad(configPath)
+	log := mustMakeLogger(cfg.LogLevel)
+
+	log.Info("starting server")
+	log.Debug("debug messages are enabled")
+
+	userservice, err := user.NewClient(cfg.UserAddress, log)
+	if err != nil {
+		log.Error("failed to init user adapter", "error", err)
+		os.Exit(1)
+	}
+
+	helpservice, err := help.NewClient(cfg.HelpAddress, log)
+	if err != nil {
+		log.Error("failed to init help adapter", "error", err)
+		os.Exit(1)
+	}
+
+	hub := core.NewHub(log, userservice, userservice, helpservice, clock.Real{}, cfg.ReconnectGrace)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	consumer := kafka.NewConsumer(kafkaretry.Config{
+		Brokers: cfg.KafkaConfig.Brokers,
+		Topic:   cfg.KafkaConfig.UserEventsTopic,
+		GroupID: cfg.KafkaConfig.GroupID,
+		Delays:  cfg.KafkaConfig.RetryDelays,
+
+		MessageIDHeader: eventspkg.HeaderMessageID,
+	}, hub, log)
+	defer consumer.Close()
+	if err := consumer.CheckTopics(ctx); err != nil {
+		log.Error("failed to check kafka topics", "error", err)
+		os.Exit(1)
+	}
+	go consumer.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", ws.NewHandler(log, hub, cfg.PingInterval))
+
+	server := http.Server{
+		Addr:    cfg.Address,
+		Handler: mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		log.Debug("shutting down server")
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Error("erroneous shutdown", "error", err)
+		}
+	}()
+
+	log.Info("Signaling server is running", "address", cfg.Address)
+	if err := server.ListenAndServe(); err != nil {
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Error("server closed unexpectedly", "error", err)
+			return
+		}
+	}
+}
+
+func mustMakeLogger(logLevel string) *slog.Logger {
+	var level slog.Level
+	switch logLevel {
+	case "DEBUG":
+		level = slog.LevelDebug
+	case "INFO":
+		level = slog.LevelInfo
+	case "ERROR":
+		level = slog.LevelError
+	default:
+		panic("unknown log level: " + logLevel)
+	}
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	return slog.New(handler)
+}
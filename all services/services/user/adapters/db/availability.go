@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"seeforme/user/core"
+	"time"
+)
+
+func (d *DB) GetAvailabilityWindows(ctx context.Context, userIDs []int64) (map[int64][]core.AvailabilityWindow, error) {
+	var rows []struct {
+		UserID int64 `db:"user_id"`
+		core.AvailabilityWindow
+	}
+	query := `
+		SELECT user_id, weekday, start_minute, end_minute FROM availability_windows
+		WHERE user_id = ANY($1) ORDER BY user_id, weekday, start_minute`
+	if err := d.conn.SelectContext(ctx, &rows, query, userIDs); err != nil {
+		d.log.Error("failed to get availability windows", "error", err)
+		return nil, err
+	}
+
+	windows := make(map[int64][]core.AvailabilityWindow)
+	for _, row := range rows {
+		windows[row.UserID] = append(windows[row.UserID], row.AvailabilityWindow)
+	}
+	return windows, nil
+}
+
+func (d *DB) SetAvailabilityWindows(ctx context.Context, userID int64, windows []core.AvailabilityWindow) error {
+	tx, err := d.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM availability_windows WHERE user_id = $1`, userID); err != nil {
+		d.log.Error("failed to delete availability windows", "user", userID, "error", err)
+		return err
+	}
+
+	query := `INSERT INTO availability_windows (user_id, weekday, start_minute, end_minute) VALUES ($1, $2, $3, $4)`
+	for _, w := range windows {
+		if _, err := tx.ExecContext(ctx, query, userID, int(w.Weekday), w.Start, w.End); err != nil {
+			d.log.Error("failed to save availability window", "user", userID, "error", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (d *DB) SetDoNotDisturb(ctx context.Context, userID int64, until *time.Time) error {
+	if _, err := d.conn.ExecContext(ctx, `UPDATE users SET dnd_until = $2 WHERE id = $1`, userID, until); err != nil {
+		d.log.Error("failed to update do not disturb", "user", userID, "error", err)
+		return core.ErrUpdateUser
+	}
+
+	return nil
+}
@@ -0,0 +1,2 @@
+ALTER TABLE blind DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE volunteer DROP COLUMN IF EXISTS deleted_at;
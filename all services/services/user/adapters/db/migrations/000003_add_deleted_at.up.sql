@@ -0,0 +1,2 @@
+ALTER TABLE blind ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE volunteer ADD COLUMN deleted_at TIMESTAMPTZ;
@@ -0,0 +1,22 @@
+CREATE TABLE blind (
+	id SERIAL PRIMARY KEY,
+	email VARCHAR(255) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	deleted_at TIMESTAMPTZ
+);
+
+CREATE TABLE volunteer (
+	id SERIAL PRIMARY KEY,
+	email VARCHAR(255) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	token VARCHAR(255),
+	deleted_at TIMESTAMPTZ
+);
+
+INSERT INTO blind (email, password, deleted_at)
+SELECT email, password, deleted_at FROM users WHERE role = 'blind' ORDER BY id;
+
+INSERT INTO volunteer (email, password, deleted_at)
+SELECT email, password, deleted_at FROM users WHERE role = 'volunteer' ORDER BY id;
+
+DROP TABLE IF EXISTS users;
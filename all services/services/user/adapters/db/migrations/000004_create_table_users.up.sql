@@ -0,0 +1,26 @@
+-- Объединяем blind и volunteer в одну таблицу с ролью.
+-- id слепых сохраняются, волонтёры получают новые id после них.
+CREATE TABLE users (
+	id BIGSERIAL PRIMARY KEY,
+	email VARCHAR(255) NOT NULL,
+	password VARCHAR(255) NOT NULL,
+	role VARCHAR(16) NOT NULL DEFAULT 'blind',
+	suspended BOOLEAN NOT NULL DEFAULT FALSE,
+	password_reset_required BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	deleted_at TIMESTAMPTZ
+);
+
+INSERT INTO users (id, email, password, role, deleted_at)
+SELECT id, email, password, 'blind', deleted_at FROM blind;
+
+SELECT setval(pg_get_serial_sequence('users', 'id'), COALESCE((SELECT MAX(id) FROM users), 0) + 1, false);
+
+INSERT INTO users (email, password, role, deleted_at)
+SELECT email, password, 'volunteer', deleted_at FROM volunteer ORDER BY id;
+
+CREATE INDEX users_email_idx ON users (email);
+CREATE INDEX users_role_idx ON users (role);
+
+DROP TABLE blind;
+DROP TABLE volunteer;
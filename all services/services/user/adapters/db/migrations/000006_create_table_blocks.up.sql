@@ -0,0 +1,8 @@
+CREATE TABLE blocks (
+	blocker_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	blocked_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (blocker_id, blocked_id)
+);
+
+CREATE INDEX blocks_blocked_id_idx ON blocks (blocked_id);
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS rating_sum;
+ALTER TABLE users DROP COLUMN IF EXISTS rating_count;
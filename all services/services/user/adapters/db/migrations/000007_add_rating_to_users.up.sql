@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN rating_sum BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN rating_count BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+CREATE TABLE reports (
+	id BIGSERIAL PRIMARY KEY,
+	reporter_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	reported_user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	help_request_id BIGINT,
+	reason VARCHAR(64) NOT NULL,
+	comment TEXT NOT NULL DEFAULT '',
+	status VARCHAR(16) NOT NULL DEFAULT 'open',
+	handled_by BIGINT,
+	resolution_note TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX reports_status_idx ON reports (status, created_at);
+CREATE INDEX reports_reported_user_id_idx ON reports (reported_user_id, created_at);
@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS dnd_until;
+DROP TABLE IF EXISTS availability_windows;
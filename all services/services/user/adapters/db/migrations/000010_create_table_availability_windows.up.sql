@@ -0,0 +1,11 @@
+CREATE TABLE availability_windows (
+	user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	start_minute SMALLINT NOT NULL,
+	end_minute SMALLINT NOT NULL,
+	CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX availability_windows_user_id_idx ON availability_windows (user_id);
+
+ALTER TABLE users ADD COLUMN dnd_until TIMESTAMPTZ;
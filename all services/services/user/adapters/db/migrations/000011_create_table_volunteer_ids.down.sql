@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_email_idx;
+CREATE INDEX users_email_idx ON users (email);
+
+DROP TABLE IF EXISTS volunteer_ids;
@@ -0,0 +1,31 @@
+-- 000004 перенёс волонтёров в users по возрастанию их старого id и выдал им id подряд после слепых,
+-- но соответствие старых и новых id не сохранил. Восстанавливаем его, чтобы сервис помощи
+-- перевёл у себя id волонтёров. Перенесённые строки получили одинаковый created_at - время миграции.
+-- Волонтёров, окончательно удалённых до объединения, в users нет: если такие были,
+-- старые id после пропуска восстановятся неверно.
+CREATE TABLE volunteer_ids (
+	old_id BIGINT PRIMARY KEY,
+	new_id BIGINT NOT NULL UNIQUE
+);
+
+INSERT INTO volunteer_ids (old_id, new_id)
+SELECT ROW_NUMBER() OVER (ORDER BY u.id), u.id
+FROM users u
+WHERE u.role = 'volunteer' AND u.created_at = (SELECT MIN(created_at) FROM users);
+
+-- один email - один действующий аккаунт. Если слепой и волонтёр зарегистрированы на один адрес,
+-- миграция останавливается: какой аккаунт оставить, решают вручную.
+DO $$
+DECLARE
+	duplicates INT;
+BEGIN
+	SELECT COUNT(*) INTO duplicates FROM (
+		SELECT email FROM users WHERE deleted_at IS NULL GROUP BY email HAVING COUNT(*) > 1
+	) d;
+	IF duplicates > 0 THEN
+		RAISE EXCEPTION '% emails belong to more than one active user', duplicates;
+	END IF;
+END $$;
+
+DROP INDEX IF EXISTS users_email_idx;
+CREATE UNIQUE INDEX users_email_idx ON users (email) WHERE deleted_at IS NULL;
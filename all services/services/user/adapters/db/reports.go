@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"seeforme/user/core"
+	"strconv"
+	"time"
+)
+
+const reportColumns = `id, reporter_id, reported_user_id, help_request_id, reason, comment, status, handled_by, resolution_note, created_at, updated_at`
+
+func (d *DB) SaveReport(ctx context.Context, report core.Report) (int64, error) {
+	query := `
+		INSERT INTO reports (reporter_id, reported_user_id, help_request_id, reason, comment, status)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	var id int64
+	err := d.conn.QueryRowContext(ctx, query,
+		report.ReporterID, report.ReportedUserID, report.HelpRequestID, report.Reason, report.Comment, report.Status,
+	).Scan(&id)
+	if err != nil {
+		d.log.Error("failed to save report", "error", err)
+		return 0, core.ErrReports
+	}
+
+	return id, nil
+}
+
+func (d *DB) GetReport(ctx context.Context, id int64) (core.Report, error) {
+	var report core.Report
+	query := `SELECT ` + reportColumns + ` FROM reports WHERE id = $1`
+	if err := d.conn.GetContext(ctx, &report, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Report{}, core.ErrReportNotFound
+		}
+		d.log.Error("failed to get report", "id", id, "error", err)
+		return core.Report{}, core.ErrReports
+	}
+
+	return report, nil
+}
+
+func (d *DB) ListReports(ctx context.Context, filter core.ReportFilter) ([]core.Report, int64, error) {
+	where := `WHERE TRUE`
+	var args []interface{}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += ` AND status = $` + strconv.Itoa(len(args))
+	}
+	if filter.ReportedUserID != 0 {
+		args = append(args, filter.ReportedUserID)
+		where += ` AND reported_user_id = $` + strconv.Itoa(len(args))
+	}
+
+	var total int64
+	if err := d.conn.GetContext(ctx, &total, `SELECT COUNT(*) FROM reports `+where, args...); err != nil {
+		d.log.Error("failed to count reports", "error", err)
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `SELECT ` + reportColumns + ` FROM reports ` + where +
+		` ORDER BY created_at LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	reports := []core.Report{}
+	if err := d.conn.SelectContext(ctx, &reports, query, args...); err != nil {
+		d.log.Error("failed to list reports", "error", err)
+		return nil, 0, err
+	}
+
+	return reports, total, nil
+}
+
+func (d *DB) UpdateReportStatus(ctx context.Context, id int64, status core.ReportStatus, handledBy int64, note string) error {
+	query := `UPDATE reports SET status = $2, handled_by = $3, resolution_note = $4, updated_at = NOW() WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, status, handledBy, note); err != nil {
+		d.log.Error("failed to update report", "id", id, "error", err)
+		return core.ErrReports
+	}
+
+	return nil
+}
+
+// CountReporters считает разных пользователей, пожаловавшихся с момента since
+func (d *DB) CountReporters(ctx context.Context, reportedUserID int64, since time.Time) (int, error) {
+	var count int
+	query := `
+		SELECT COUNT(DISTINCT reporter_id) FROM reports
+		WHERE reported_user_id = $1 AND created_at >= $2 AND status <> $3`
+	if err := d.conn.GetContext(ctx, &count, query, reportedUserID, since, core.ReportDismissed); err != nil {
+		d.log.Error("failed to count reporters", "user", reportedUserID, "error", err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -2,13 +2,23 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 	"seeforme/user/core"
+	"strconv"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 )
 
+// uniqueViolation - код ошибки postgres при нарушении уникальности
+const uniqueViolation = "23505"
+
+const userColumns = `id, email, password, role, suspended, suspension_reason, suspended_until, password_reset_required, rating_sum, rating_count, languages, timezone, dnd_until, created_at`
+
 type DB struct {
 	log *slog.Logger
 	conn *sqlx.DB
@@ -28,15 +38,15 @@ func New(log *slog.Logger, address string) (*DB, error) {
 }
 
 func (d *DB) SaveUser(ctx context.Context, user core.User) (int64, error) {
-	var query string
-	if !user.Role { // Если роль false, сохраняем в таблицу blind
-		query = `INSERT INTO blind (email, password) VALUES ($1, $2) RETURNING id`
-	} else { // Если роль true, сохраняем в таблицу volunteer
-		query = `INSERT INTO volunteer (email, password) VALUES ($1, $2) RETURNING id`
-	}
+	query := `INSERT INTO users (email, password, role) VALUES ($1, $2, $3) RETURNING id`
 
 	var id int64
-	if err := d.conn.QueryRowContext(ctx, query, user.Email, user.Password).Scan(&id); err != nil {
+	if err := d.conn.QueryRowContext(ctx, query, user.Email, user.Password, user.Role).Scan(&id); err != nil {
+		// email уже занят: регистрации с одним адресом пришли одновременно
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return 0, core.ErrUserAlreadyExists
+		}
 		d.log.Error("failed to save user", "error", err)
 		return 0, core.ErrSaveUser
 	}
@@ -47,31 +57,23 @@ func (d *DB) SaveUser(ctx context.Context, user core.User) (int64, error) {
 func (d *DB) GetUserByEmail(ctx context.Context, email string) (core.User, error) {
 	var user core.User
 
-	queryBlind := `SELECT id, email, password FROM blind WHERE email = $1`
-	err := d.conn.GetContext(ctx, &user, queryBlind, email)
-	if err == nil {
-		user.Role = true
-		d.log.Debug("user found", "email", email, "role", user.Role)
-		return user, nil
-	}
-
-	queryVolunteer := `SELECT id, email, password FROM volunteer WHERE email = $1`
-	err = d.conn.GetContext(ctx, &user, queryVolunteer, email)
-	if err == nil {
-		user.Role = false
-		d.log.Debug("user found", "email", email, "role", user.Role)
-		return user, nil
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1 AND deleted_at IS NULL`
+	err := d.conn.GetContext(ctx, &user, query, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.User{}, core.ErrUserNotFound
+		}
+		d.log.Error("failed to get user", "email", email, "error", err)
+		return core.User{}, core.ErrGetUser
 	}
 
-	return core.User{}, core.ErrUserNotFound
+	d.log.Debug("user found", "email", email, "role", user.Role)
+	return user, nil
 }
 
 func (d *DB) GetUserByID(ctx context.Context, id int64) (core.User, error) {
 	var user core.User
-	query := `
-		SELECT id, email, password FROM volunteer WHERE id = $1
-		UNION
-		SELECT id, email, password FROM blind WHERE id = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1 AND deleted_at IS NULL`
 	err := d.conn.GetContext(ctx, &user, query, id)
 	if err != nil {
 		d.log.Error("failed to get user", "id", id, "error", err)
@@ -85,18 +87,176 @@ func (d *DB) GetUsersCount(ctx context.Context) (int64, int64, error) {
 	var volunteersCount, blindCount int64
 
 	// Получаем количество волонтеров
-	err := d.conn.GetContext(ctx, &volunteersCount, "SELECT COUNT(*) FROM volunteer")
+	err := d.conn.GetContext(ctx, &volunteersCount, "SELECT COUNT(*) FROM users WHERE role = $1 AND deleted_at IS NULL", core.RoleVolunteer)
 	if err != nil {
 		d.log.Error("failed to get volunteers count", "error", err)
 		return 0, 0, err
 	}
 
 	// Получаем количество слепых
-	err = d.conn.GetContext(ctx, &blindCount, "SELECT COUNT(*) FROM blind")
+	err = d.conn.GetContext(ctx, &blindCount, "SELECT COUNT(*) FROM users WHERE role = $1 AND deleted_at IS NULL", core.RoleBlind)
 	if err != nil {
 		d.log.Error("failed to get blind count", "error", err)
 		return 0, 0, err
 	}
 
 	return volunteersCount, blindCount, nil
-}
\ No newline at end of file
+}
+
+func (d *DB) SoftDeleteUser(ctx context.Context, id int64) error {
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	if _, err := d.conn.ExecContext(ctx, query, id); err != nil {
+		d.log.Error("failed to soft delete user", "id", id, "error", err)
+		return core.ErrDeleteUser
+	}
+
+	return nil
+}
+
+func (d *DB) GetUsersDeletedBefore(ctx context.Context, before time.Time) ([]core.User, error) {
+	var users []core.User
+	query := `SELECT ` + userColumns + ` FROM users WHERE deleted_at < $1`
+	if err := d.conn.SelectContext(ctx, &users, query, before); err != nil {
+		d.log.Error("failed to get deleted users", "error", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (d *DB) PurgeUser(ctx context.Context, id int64) error {
+	query := `DELETE FROM users WHERE id = $1 AND deleted_at IS NOT NULL`
+	if _, err := d.conn.ExecContext(ctx, query, id); err != nil {
+		d.log.Error("failed to purge user", "id", id, "error", err)
+		return core.ErrDeleteUser
+	}
+
+	return nil
+}
+
+func (d *DB) ListUsers(ctx context.Context, filter core.UserFilter) ([]core.User, int64, error) {
+	where := `WHERE deleted_at IS NULL`
+	var args []interface{}
+	if filter.Query != "" {
+		args = append(args, "%"+filter.Query+"%")
+		where += ` AND email ILIKE $` + strconv.Itoa(len(args))
+	}
+	if filter.Role != "" {
+		args = append(args, filter.Role)
+		where += ` AND role = $` + strconv.Itoa(len(args))
+	}
+
+	var total int64
+	if err := d.conn.GetContext(ctx, &total, `SELECT COUNT(*) FROM users `+where, args...); err != nil {
+		d.log.Error("failed to count users", "error", err)
+		return nil, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := `SELECT ` + userColumns + ` FROM users ` + where +
+		` ORDER BY id LIMIT $` + strconv.Itoa(len(args)-1) + ` OFFSET $` + strconv.Itoa(len(args))
+
+	users := []core.User{}
+	if err := d.conn.SelectContext(ctx, &users, query, args...); err != nil {
+		d.log.Error("failed to list users", "error", err)
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (d *DB) SetSuspended(ctx context.Context, id int64, suspended bool, reason string, until *time.Time) error {
+	query := `UPDATE users SET suspended = $2, suspension_reason = $3, suspended_until = $4 WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, suspended, reason, until); err != nil {
+		d.log.Error("failed to update suspended", "id", id, "error", err)
+		return core.ErrUpdateUser
+	}
+
+	return nil
+}
+
+func (d *DB) SetPassword(ctx context.Context, id int64, password []byte, resetRequired bool) error {
+	query := `UPDATE users SET password = $2, password_reset_required = $3 WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, password, resetRequired); err != nil {
+		d.log.Error("failed to update password", "id", id, "error", err)
+		return core.ErrUpdateUser
+	}
+
+	return nil
+}
+
+func (d *DB) UpdateProfile(ctx context.Context, id int64, languages string, timezone string) error {
+	query := `UPDATE users SET languages = $2, timezone = $3 WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, languages, timezone); err != nil {
+		d.log.Error("failed to update profile", "id", id, "error", err)
+		return core.ErrUpdateUser
+	}
+
+	return nil
+}
+
+func (d *DB) SetRole(ctx context.Context, id int64, role core.Role) error {
+	query := `UPDATE users SET role = $2 WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, role); err != nil {
+		d.log.Error("failed to update role", "id", id, "error", err)
+		return core.ErrUpdateUser
+	}
+
+	return nil
+}
+
+func (d *DB) SaveBlock(ctx context.Context, blockerID int64, blockedID int64) error {
+	query := `INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := d.conn.ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		d.log.Error("failed to save block", "blocker", blockerID, "blocked", blockedID, "error", err)
+		return core.ErrBlockList
+	}
+
+	return nil
+}
+
+func (d *DB) DeleteBlock(ctx context.Context, blockerID int64, blockedID int64) error {
+	query := `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`
+	if _, err := d.conn.ExecContext(ctx, query, blockerID, blockedID); err != nil {
+		d.log.Error("failed to delete block", "blocker", blockerID, "blocked", blockedID, "error", err)
+		return core.ErrBlockList
+	}
+
+	return nil
+}
+
+func (d *DB) GetBlocked(ctx context.Context, blockerID int64) ([]core.BlockedUser, error) {
+	blocked := []core.BlockedUser{}
+	query := `SELECT blocked_id, created_at FROM blocks WHERE blocker_id = $1 ORDER BY created_at`
+	if err := d.conn.SelectContext(ctx, &blocked, query, blockerID); err != nil {
+		d.log.Error("failed to get blocked users", "blocker", blockerID, "error", err)
+		return nil, err
+	}
+
+	return blocked, nil
+}
+
+func (d *DB) IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+	var blocked bool
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+		)`
+	if err := d.conn.GetContext(ctx, &blocked, query, userID, otherUserID); err != nil {
+		d.log.Error("failed to check block", "user", userID, "other", otherUserID, "error", err)
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+func (d *DB) SetRating(ctx context.Context, id int64, sum, count int64) error {
+	query := `UPDATE users SET rating_sum = $2, rating_count = $3 WHERE id = $1`
+	if _, err := d.conn.ExecContext(ctx, query, id, sum, count); err != nil {
+		d.log.Error("failed to set rating", "id", id, "error", err)
+		return core.ErrUpdateUser
+	}
+
+	return nil
+}
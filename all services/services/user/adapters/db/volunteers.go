@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"seeforme/user/core"
+)
+
+// ListVolunteers возвращает активных волонтёров, лучших по рейтингу первыми.
+// Если заданы языки, сначала идут все, кто говорит на одном из них, иначе язык автора
+// проигрывал бы рейтингу ещё до подбора. Волонтёры без оценок идут после оценённых.
+func (d *DB) ListVolunteers(ctx context.Context, filter core.VolunteerFilter) ([]core.User, []int64, error) {
+	blocked := `EXISTS (
+			SELECT 1 FROM blocks b
+			WHERE (b.blocker_id = $2 AND b.blocked_id = u.id) OR (b.blocker_id = u.id AND b.blocked_id = $2)
+		)`
+	where := `
+		WHERE deleted_at IS NULL
+			AND role = $1
+			AND (NOT suspended OR suspended_until <= NOW())
+			AND NOT (id = ANY($3))`
+	if !filter.IncludeBlocked {
+		where += ` AND NOT ` + blocked
+	}
+	if filter.AvailableOnly {
+		where += ` AND (dnd_until IS NULL OR dnd_until <= NOW())`
+	}
+	query := `SELECT ` + userColumns + `, ` + blocked + ` AS blocked FROM users u` + where + `
+		ORDER BY COALESCE(string_to_array(NULLIF(languages, ''), ',') && $6::text[], FALSE) DESC,
+			rating_sum::float / NULLIF(rating_count, 0) DESC NULLS LAST, rating_count DESC, id
+		LIMIT $4 OFFSET $5`
+
+	exclude := filter.ExcludeIDs
+	if exclude == nil {
+		exclude = []int64{}
+	}
+	languages := filter.Languages
+	if languages == nil {
+		languages = []string{}
+	}
+
+	var rows []struct {
+		core.User
+		Blocked bool `db:"blocked"`
+	}
+	if err := d.conn.SelectContext(ctx, &rows, query, core.RoleVolunteer, filter.RequesterID, exclude, filter.Limit, filter.Offset, languages); err != nil {
+		d.log.Error("failed to list volunteers", "requester", filter.RequesterID, "error", err)
+		return nil, nil, err
+	}
+
+	volunteers := make([]core.User, 0, len(rows))
+	var blockedIDs []int64
+	for _, row := range rows {
+		volunteers = append(volunteers, row.User)
+		if row.Blocked {
+			blockedIDs = append(blockedIDs, row.ID)
+		}
+	}
+
+	return volunteers, blockedIDs, nil
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userpb "seeforme/proto/user"
+	"seeforme/user/core"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toUserInfo(user core.User) *userpb.UserInfo {
+	info := &userpb.UserInfo{
+		Id:                    user.ID,
+		Email:                 user.Email,
+		Role:                  string(user.Role),
+		Suspended:             user.IsSuspended(time.Now()),
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             timestamppb.New(user.CreatedAt),
+		RatingAverage:         user.RatingAverage(),
+		RatingCount:           user.RatingCount,
+		Languages:             user.LanguageList(),
+		Timezone:              user.Timezone,
+	}
+	if info.Suspended {
+		info.SuspensionReason = user.SuspensionReason
+		if user.SuspendedUntil != nil {
+			info.SuspendedUntil = timestamppb.New(*user.SuspendedUntil)
+		}
+	}
+	return info
+}
+
+func adminError(err error, message string) error {
+	switch {
+	case errors.Is(err, core.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, core.ErrInvalidRole):
+		return status.Error(codes.InvalidArgument, "invalid role")
+	}
+	return status.Error(codes.Internal, message)
+}
+
+func (s *Server) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*emptypb.Empty, error) {
+	if req.GetNewPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bad arguments")
+	}
+
+	err := s.userService.ChangePassword(ctx, req.GetEmail(), req.GetOldPassword(), req.GetNewPassword())
+	if err != nil {
+		if errors.Is(err, core.ErrUserNotFound) || errors.Is(err, core.ErrFailedToComparePassword) {
+			return nil, status.Error(codes.PermissionDenied, "invalid credentials")
+		}
+		return nil, status.Error(codes.Internal, "failed to change password")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *userpb.ListUsersRequest) (*userpb.ListUsersResponse, error) {
+	users, total, err := s.userService.ListUsers(ctx, core.UserFilter{
+		Query:  req.GetQuery(),
+		Role:   core.Role(req.GetRole()),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, adminError(err, "failed to list users")
+	}
+
+	response := &userpb.ListUsersResponse{Total: total}
+	for _, user := range users {
+		response.Users = append(response.Users, toUserInfo(user))
+	}
+
+	return response, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.UserInfo, error) {
+	user, err := s.userService.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, adminError(err, "failed to get user")
+	}
+
+	return toUserInfo(user), nil
+}
+
+func (s *Server) SuspendUser(ctx context.Context, req *userpb.SuspendUserRequest) (*emptypb.Empty, error) {
+	var until *time.Time
+	if req.GetUntil() != nil {
+		t := req.GetUntil().AsTime()
+		until = &t
+	}
+
+	if err := s.userService.SuspendUser(ctx, req.GetUserId(), req.GetReason(), until); err != nil {
+		return nil, adminError(err, "failed to suspend user")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UnsuspendUser(ctx context.Context, req *userpb.UnsuspendUserRequest) (*emptypb.Empty, error) {
+	if err := s.userService.UnsuspendUser(ctx, req.GetUserId()); err != nil {
+		return nil, adminError(err, "failed to unsuspend user")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ForcePasswordReset(ctx context.Context, req *userpb.ForcePasswordResetRequest) (*userpb.ForcePasswordResetResponse, error) {
+	password, err := s.userService.ForcePasswordReset(ctx, req.GetUserId())
+	if err != nil {
+		return nil, adminError(err, "failed to reset password")
+	}
+
+	return &userpb.ForcePasswordResetResponse{TemporaryPassword: password}, nil
+}
+
+func (s *Server) ChangeRole(ctx context.Context, req *userpb.ChangeRoleRequest) (*emptypb.Empty, error) {
+	if err := s.userService.ChangeRole(ctx, req.GetUserId(), core.Role(req.GetRole())); err != nil {
+		return nil, adminError(err, "failed to change role")
+	}
+
+	return &emptypb.Empty{}, nil
+}
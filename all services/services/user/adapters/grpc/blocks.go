@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	userpb "seeforme/proto/user"
+	"seeforme/user/core"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) BlockUser(ctx context.Context, req *userpb.BlockUserRequest) (*emptypb.Empty, error) {
+	if err := s.userService.BlockUser(ctx, req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		if errors.Is(err, core.ErrBlockSelf) {
+			return nil, status.Error(codes.InvalidArgument, "cannot block yourself")
+		}
+		if errors.Is(err, core.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to block user")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) UnblockUser(ctx context.Context, req *userpb.UnblockUserRequest) (*emptypb.Empty, error) {
+	if err := s.userService.UnblockUser(ctx, req.GetUserId(), req.GetBlockedUserId()); err != nil {
+		return nil, status.Error(codes.Internal, "failed to unblock user")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ListBlocked(ctx context.Context, req *userpb.ListBlockedRequest) (*userpb.ListBlockedResponse, error) {
+	blocked, err := s.userService.ListBlocked(ctx, req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list blocked users")
+	}
+
+	response := &userpb.ListBlockedResponse{}
+	for _, b := range blocked {
+		response.Users = append(response.Users, &userpb.BlockedUser{
+			UserId:    b.UserID,
+			CreatedAt: timestamppb.New(b.CreatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *Server) IsBlocked(ctx context.Context, req *userpb.IsBlockedRequest) (*userpb.IsBlockedResponse, error) {
+	blocked, err := s.userService.IsBlocked(ctx, req.GetUserId(), req.GetOtherUserId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check block")
+	}
+
+	return &userpb.IsBlockedResponse{Blocked: blocked}, nil
+}
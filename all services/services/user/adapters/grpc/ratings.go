@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	userpb "seeforme/proto/user"
+	"seeforme/user/core"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *Server) SetVolunteerRating(ctx context.Context, req *userpb.SetVolunteerRatingRequest) (*emptypb.Empty, error) {
+	if err := s.userService.SetVolunteerRating(ctx, req.GetUserId(), req.GetRatingSum(), req.GetRatingCount()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrInvalidScore):
+			return nil, status.Error(codes.InvalidArgument, "invalid rating score")
+		case errors.Is(err, core.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, core.ErrNotVolunteer):
+			return nil, status.Error(codes.FailedPrecondition, "user is not a volunteer")
+		}
+		return nil, status.Error(codes.Internal, "failed to set rating")
+	}
+
+	return &emptypb.Empty{}, nil
+}
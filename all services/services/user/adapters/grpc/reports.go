@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	userpb "seeforme/proto/user"
+	"seeforme/user/core"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toReport(report core.Report) *userpb.Report {
+	response := &userpb.Report{
+		Id:             report.ID,
+		ReporterId:     report.ReporterID,
+		ReportedUserId: report.ReportedUserID,
+		Reason:         report.Reason,
+		Comment:        report.Comment,
+		Status:         string(report.Status),
+		ResolutionNote: report.ResolutionNote,
+		CreatedAt:      timestamppb.New(report.CreatedAt),
+		UpdatedAt:      timestamppb.New(report.UpdatedAt),
+	}
+	if report.HelpRequestID != nil {
+		response.HelpRequestId = *report.HelpRequestID
+	}
+	if report.HandledBy != nil {
+		response.HandledBy = *report.HandledBy
+	}
+	return response
+}
+
+func reportError(err error, message string) error {
+	switch {
+	case errors.Is(err, core.ErrBadReport):
+		return status.Error(codes.InvalidArgument, "invalid report")
+	case errors.Is(err, core.ErrReportNotFound):
+		return status.Error(codes.NotFound, "report not found")
+	case errors.Is(err, core.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, core.ErrHelpRequestNotFound):
+		return status.Error(codes.NotFound, "help request not found")
+	case errors.Is(err, core.ErrReportClosed):
+		return status.Error(codes.FailedPrecondition, "report already closed")
+	}
+	return status.Error(codes.Internal, message)
+}
+
+func (s *Server) CreateReport(ctx context.Context, req *userpb.CreateReportRequest) (*userpb.CreateReportResponse, error) {
+	report := core.Report{
+		ReporterID:     req.GetReporterId(),
+		ReportedUserID: req.GetReportedUserId(),
+		Reason:         req.GetReason(),
+		Comment:        req.GetComment(),
+	}
+	if req.GetHelpRequestId() != 0 {
+		helpRequestID := req.GetHelpRequestId()
+		report.HelpRequestID = &helpRequestID
+	}
+
+	id, err := s.userService.CreateReport(ctx, report)
+	if err != nil {
+		return nil, reportError(err, "failed to create report")
+	}
+
+	return &userpb.CreateReportResponse{Id: id}, nil
+}
+
+func (s *Server) ListReports(ctx context.Context, req *userpb.ListReportsRequest) (*userpb.ListReportsResponse, error) {
+	reports, total, err := s.userService.ListReports(ctx, core.ReportFilter{
+		Status:         core.ReportStatus(req.GetStatus()),
+		ReportedUserID: req.GetReportedUserId(),
+		Limit:          int(req.GetLimit()),
+		Offset:         int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, reportError(err, "failed to list reports")
+	}
+
+	response := &userpb.ListReportsResponse{Total: total}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, toReport(report))
+	}
+
+	return response, nil
+}
+
+func (s *Server) GetReport(ctx context.Context, req *userpb.GetReportRequest) (*userpb.Report, error) {
+	report, err := s.userService.GetReport(ctx, req.GetId())
+	if err != nil {
+		return nil, reportError(err, "failed to get report")
+	}
+
+	return toReport(report), nil
+}
+
+func (s *Server) UpdateReportStatus(ctx context.Context, req *userpb.UpdateReportStatusRequest) (*emptypb.Empty, error) {
+	err := s.userService.UpdateReportStatus(ctx, req.GetId(), core.ReportStatus(req.GetStatus()), req.GetAdminId(), req.GetNote())
+	if err != nil {
+		return nil, reportError(err, "failed to update report")
+	}
+
+	return &emptypb.Empty{}, nil
+}
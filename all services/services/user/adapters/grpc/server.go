@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	userpb "seeforme/proto/user"
@@ -26,7 +27,10 @@ func NewServer(service core.UserService) *Server {
 func (s *Server) Register(ctx context.Context, req *userpb.RegisterRequest) (*userpb.RegisterResponse, error) {
 	email := req.GetEmail()
 	password := req.GetPassword()
-	role := req.GetRole()
+	role := core.RoleBlind
+	if req.GetRole() {
+		role = core.RoleVolunteer
+	}
 
 	userID, err := s.userService.Register(ctx, email, password, role)
 	if err != nil {
@@ -48,10 +52,16 @@ func (s *Server) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.L
 		if errors.Is(err, core.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "invalid credentials")
 		}
+		if errors.Is(err, core.ErrPasswordResetRequired) {
+			return nil, status.Error(codes.FailedPrecondition, "password reset required")
+		}
+		if errors.Is(err, core.ErrUserSuspended) {
+			return nil, status.Error(codes.PermissionDenied, "user suspended")
+		}
 		return nil, status.Error(codes.Internal, "failed to login user")
 	}
 
-	return &userpb.LoginResponse{Token: token, Role: role}, nil
+	return &userpb.LoginResponse{Token: token, Role: role == core.RoleVolunteer}, nil
 }
 
 func (s *Server) CheckJWT(ctx context.Context, req *userpb.CheckJWTRequest) (*emptypb.Empty, error) {
@@ -63,6 +73,9 @@ func (s *Server) CheckJWT(ctx context.Context, req *userpb.CheckJWTRequest) (*em
 		if errors.Is(err, core.ErrInvalidCredentials) {
 			return nil, status.Error(codes.PermissionDenied, "invalid credentials")
 		}
+		if errors.Is(err, core.ErrUserSuspended) {
+			return nil, status.Error(codes.PermissionDenied, "user suspended")
+		}
 		if errors.Is(err, core.ErrUserNotFound) {
 			return nil, status.Error(codes.NotFound, "user not found")
 		}
@@ -85,4 +98,32 @@ func (s *Server) GetStatistics(ctx context.Context, req *userpb.GetStatisticsReq
 		VolunteersCount: volunteersCount,
 		BlindCount: blindCount,
 	}, nil
-}
\ No newline at end of file
+}
+
+func (s *Server) DeleteAccount(ctx context.Context, req *userpb.DeleteAccountRequest) (*emptypb.Empty, error) {
+	if err := s.userService.DeleteAccount(ctx, req.GetUserId()); err != nil {
+		if errors.Is(err, core.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete account")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ExportMyData(ctx context.Context, req *userpb.ExportMyDataRequest) (*userpb.ExportMyDataResponse, error) {
+	userData, err := s.userService.ExportMyData(ctx, req.GetUserId())
+	if err != nil {
+		if errors.Is(err, core.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to export user data")
+	}
+
+	data, err := json.Marshal(userData)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to export user data")
+	}
+
+	return &userpb.ExportMyDataResponse{Data: data}, nil
+}
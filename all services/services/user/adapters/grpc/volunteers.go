@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userpb "seeforme/proto/user"
+	"seeforme/user/core"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) ListVolunteers(ctx context.Context, req *userpb.ListVolunteersRequest) (*userpb.ListVolunteersResponse, error) {
+	list, err := s.userService.ListVolunteers(ctx, core.VolunteerFilter{
+		RequesterID:    req.GetRequesterId(),
+		ExcludeIDs:     req.GetExcludeIds(),
+		Limit:          int(req.GetLimit()),
+		IncludeBlocked: req.GetIncludeBlocked(),
+		AvailableOnly:  req.GetAvailableOnly(),
+		Urgent:         req.GetUrgent(),
+		Languages:      req.GetLanguages(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to list volunteers")
+	}
+
+	response := &userpb.ListVolunteersResponse{
+		BlockedIds:      list.Blocked,
+		OffScheduleIds:  list.OffSchedule,
+		DoNotDisturbIds: list.DoNotDisturb,
+	}
+	for _, volunteer := range list.Volunteers {
+		response.Volunteers = append(response.Volunteers, toUserInfo(volunteer))
+	}
+
+	return response, nil
+}
+
+func (s *Server) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*emptypb.Empty, error) {
+	if err := s.userService.UpdateProfile(ctx, req.GetUserId(), req.GetLanguages(), req.GetTimezone()); err != nil {
+		switch {
+		case errors.Is(err, core.ErrInvalidProfile):
+			return nil, status.Error(codes.InvalidArgument, "invalid profile")
+		case errors.Is(err, core.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, "failed to update profile")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func availabilityError(err error, message string) error {
+	switch {
+	case errors.Is(err, core.ErrInvalidAvailability):
+		return status.Error(codes.InvalidArgument, "invalid availability")
+	case errors.Is(err, core.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	}
+	return status.Error(codes.Internal, message)
+}
+
+func (s *Server) GetAvailability(ctx context.Context, req *userpb.GetAvailabilityRequest) (*userpb.Availability, error) {
+	availability, err := s.userService.GetAvailability(ctx, req.GetUserId())
+	if err != nil {
+		return nil, availabilityError(err, "failed to get availability")
+	}
+
+	response := &userpb.Availability{}
+	for _, w := range availability.Windows {
+		response.Windows = append(response.Windows, &userpb.AvailabilityWindow{
+			Weekday:     int32(w.Weekday),
+			StartMinute: int32(w.Start),
+			EndMinute:   int32(w.End),
+		})
+	}
+	if availability.DNDUntil != nil {
+		response.DoNotDisturbUntil = timestamppb.New(*availability.DNDUntil)
+	}
+
+	return response, nil
+}
+
+func (s *Server) SetAvailability(ctx context.Context, req *userpb.SetAvailabilityRequest) (*emptypb.Empty, error) {
+	windows := make([]core.AvailabilityWindow, 0, len(req.GetWindows()))
+	for _, w := range req.GetWindows() {
+		windows = append(windows, core.AvailabilityWindow{
+			Weekday: time.Weekday(w.GetWeekday()),
+			Start:   int(w.GetStartMinute()),
+			End:     int(w.GetEndMinute()),
+		})
+	}
+
+	if err := s.userService.SetAvailability(ctx, req.GetUserId(), windows); err != nil {
+		return nil, availabilityError(err, "failed to set availability")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) SetDoNotDisturb(ctx context.Context, req *userpb.SetDoNotDisturbRequest) (*emptypb.Empty, error) {
+	var until *time.Time
+	if req.GetUntil() != nil {
+		t := req.GetUntil().AsTime()
+		until = &t
+	}
+
+	if err := s.userService.SetDoNotDisturb(ctx, req.GetUserId(), until); err != nil {
+		return nil, availabilityError(err, "failed to set do not disturb")
+	}
+
+	return &emptypb.Empty{}, nil
+}
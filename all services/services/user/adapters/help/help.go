@@ -0,0 +1,108 @@
+package help
+
+import (
+	"context"
+	"log/slog"
+
+	helppb "seeforme/proto/help"
+	"seeforme/user/core"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client helppb.HelpClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: helppb.NewHelpClient(conn),
+	}, nil
+}
+
+func toHelpRequest(r *helppb.HelpRequest) core.HelpRequest {
+	return core.HelpRequest{
+		ID:          r.GetId(),
+		RequesterID: r.GetRequesterId(),
+		VolunteerID: r.GetVolunteerId(),
+		Question:    r.GetQuestion(),
+		Status:      r.GetStatus(),
+		CreatedAt:   r.GetCreatedAt().AsTime(),
+	}
+}
+
+func (c *Client) GetUserHistory(ctx context.Context, userID int64) ([]core.HelpRequest, []core.Call, []core.Rating, error) {
+	response, err := c.client.GetUserHistory(ctx, &helppb.GetUserHistoryRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to get user history", "error", err)
+		return nil, nil, nil, err
+	}
+
+	requests := make([]core.HelpRequest, 0, len(response.GetHelpRequests()))
+	for _, r := range response.GetHelpRequests() {
+		requests = append(requests, toHelpRequest(r))
+	}
+
+	calls := make([]core.Call, 0, len(response.GetCalls()))
+	for _, c := range response.GetCalls() {
+		call := core.Call{
+			ID:            c.GetId(),
+			HelpRequestID: c.GetHelpRequestId(),
+			BlindID:       c.GetBlindId(),
+			VolunteerID:   c.GetVolunteerId(),
+			StartedAt:     c.GetStartedAt().AsTime(),
+		}
+		if c.GetEndedAt() != nil {
+			endedAt := c.GetEndedAt().AsTime()
+			call.EndedAt = &endedAt
+		}
+		calls = append(calls, call)
+	}
+
+	ratings := make([]core.Rating, 0, len(response.GetRatings()))
+	for _, r := range response.GetRatings() {
+		ratings = append(ratings, core.Rating{
+			ID:            r.GetId(),
+			HelpRequestID: r.GetHelpRequestId(),
+			RaterID:       r.GetRaterId(),
+			RateeID:       r.GetRateeId(),
+			Score:         int(r.GetScore()),
+			Tags:          r.GetTags(),
+			Comment:       r.GetComment(),
+			CreatedAt:     r.GetCreatedAt().AsTime(),
+		})
+	}
+
+	return requests, calls, ratings, nil
+}
+
+func (c *Client) GetHelpRequest(ctx context.Context, id int64) (core.HelpRequest, error) {
+	response, err := c.client.GetHelpRequest(ctx, &helppb.GetHelpRequestRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return core.HelpRequest{}, core.ErrHelpRequestNotFound
+		}
+		c.log.Error("failed to get help request", "error", err)
+		return core.HelpRequest{}, err
+	}
+
+	return toHelpRequest(response), nil
+}
+
+func (c *Client) DeleteUserData(ctx context.Context, userID int64) error {
+	_, err := c.client.DeleteUserData(ctx, &helppb.DeleteUserDataRequest{UserId: userID})
+	if err != nil {
+		c.log.Error("failed to delete user data", "error", err)
+		return err
+	}
+	return nil
+}
@@ -58,9 +58,12 @@ func (j *JWT) VerifyToken(tokenString string, user core.User) bool {
             return false
         }
 
-        if user.ID != int64((*claims)["id"].(float64)) || 
-           user.Email != (*claims)["email"].(string) || 
-           user.Role != (*claims)["role"].(bool) {
+        sub, _ := (*claims)["sub"].(float64)
+        email, _ := (*claims)["email"].(string)
+        role, _ := (*claims)["role"].(string)
+        if user.ID != int64(sub) ||
+           user.Email != email ||
+           string(user.Role) != role {
             return false
         }
         return true
@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"context"
+	"log/slog"
+	"seeforme/pkg/events"
+	eventspb "seeforme/proto/events"
+	"seeforme/user/core"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Client struct {
+	writer *kafka.Writer
+	log    *slog.Logger
+}
+
+func NewClient(brokers []string, topic string, log *slog.Logger) (*Client, error) {
+	var addresses []string
+	for _, broker := range brokers {
+		if !strings.Contains(broker, ":") {
+			broker = broker + ":9092"
+		}
+		addresses = append(addresses, broker)
+	}
+
+	log.Debug("connecting to kafka brokers", "addresses", addresses)
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(addresses...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchTimeout: 10 * time.Millisecond,
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	return &Client{
+		writer: writer,
+		log:    log,
+	}, nil
+}
+
+func (c *Client) UserSuspended(ctx context.Context, user core.User) error {
+	event := &eventspb.UserSuspended{
+		UserId: user.ID,
+		Reason: user.SuspensionReason,
+	}
+	if user.SuspendedUntil != nil {
+		event.Until = timestamppb.New(*user.SuspendedUntil)
+	}
+	envelope, data, err := events.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// ключ - id пользователя, чтобы события одного пользователя шли по порядку
+	key := strconv.FormatInt(user.ID, 10)
+	message := kafka.Message{
+		Key:     []byte(key),
+		Value:   data,
+		Headers: []kafka.Header{{Key: events.HeaderMessageID, Value: []byte(envelope.GetId())}},
+	}
+	if err := c.writer.WriteMessages(ctx, message); err != nil {
+		c.log.Error("failed to write message to kafka", "error", err)
+		return err
+	}
+
+	c.log.Debug("user event sent to kafka", "type", envelope.GetType(), "id", envelope.GetId(), "user", user.ID)
+	return nil
+}
+
+func (c *Client) Close() error {
+	return c.writer.Close()
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	notifypb "seeforme/proto/notify"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	log    *slog.Logger
+	client notifypb.NotifyClient
+}
+
+func NewClient(address string, log *slog.Logger) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		log:    log,
+		client: notifypb.NewNotifyClient(conn),
+	}, nil
+}
+
+func (c *Client) DeleteUserData(ctx context.Context, userID int64) error {
+	if _, err := c.client.DeleteUserData(ctx, &notifypb.DeleteUserDataRequest{UserId: userID}); err != nil {
+		c.log.Error("failed to delete notify data", "user", userID, "error", err)
+		return err
+	}
+	return nil
+}
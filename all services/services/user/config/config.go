@@ -2,6 +2,7 @@ package config
 
 import (
 	"log"
+	"strings"
 	"time"
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -10,11 +11,38 @@ type JWT struct {
 	TTL    time.Duration `yaml:"ttl" env:"JWT_TTL" env-default:"24h"`
 }
 
+type Kafka struct {
+	Brokers         []string `yaml:"brokers" env:"KAFKA_BROKERS" env-default:"localhost:9092"`
+	UserEventsTopic string   `yaml:"user_events_topic" env:"KAFKA_USER_EVENTS_TOPIC" env-default:"user-events"`
+}
+
+type Admin struct {
+	Email    string `yaml:"email" env:"ADMIN_EMAIL"`
+	Password string `yaml:"password" env:"ADMIN_PASSWORD"`
+}
+
+type Deletion struct {
+	GracePeriod   time.Duration `yaml:"grace_period" env:"DELETION_GRACE_PERIOD" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"DELETION_PURGE_INTERVAL" env-default:"1h"`
+}
+
+type Moderation struct {
+	ReportThreshold int           `yaml:"report_threshold" env:"MODERATION_REPORT_THRESHOLD" env-default:"3"`
+	ReportWindow    time.Duration `yaml:"report_window" env:"MODERATION_REPORT_WINDOW" env-default:"168h"`
+	SuspendFor      time.Duration `yaml:"suspend_for" env:"MODERATION_SUSPEND_FOR" env-default:"72h"`
+}
+
 type Config struct {
 	LogLevel string `yaml:"log_level" env:"LOG_LEVEL" env-default:"DEBUG"`
 	Address      string `yaml:"user_address" env:"USER_ADDRESS" env-default:"localhost:80"`
 	DBAddress    string `yaml:"db_address" env:"DB_ADDRESS" env-default:"localhost:82"`
+	HelpAddress  string `yaml:"help_address" env:"HELP_ADDRESS" env-default:"localhost:83"`
+	NotifyAddress string `yaml:"notify_address" env:"NOTIFY_ADDRESS" env-default:"localhost:84"`
 	JWT JWT `yaml:"jwt"`
+	Deletion Deletion `yaml:"deletion"`
+	Admin Admin `yaml:"admin"`
+	Kafka Kafka `yaml:"kafka"`
+	Moderation Moderation `yaml:"moderation"`
 }
 
 func MustLoad(configPath string) Config {
@@ -24,5 +52,10 @@ func MustLoad(configPath string) Config {
 			log.Fatalf("cannot read config %q: %s", configPath, err)
 		}
 	}
+
+	if len(cfg.Kafka.Brokers) == 1 && strings.Contains(cfg.Kafka.Brokers[0], ",") {
+		cfg.Kafka.Brokers = strings.Split(cfg.Kafka.Brokers[0], ",")
+	}
+
 	return cfg
 }
\ No newline at end of file
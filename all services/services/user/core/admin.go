@@ -0,0 +1,189 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+func (s *Userservice) ChangePassword(ctx context.Context, email string, oldPassword string, newPassword string) error {
+	user, err := s.db.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			s.log.Error("user not found")
+			return ErrUserNotFound
+		}
+		s.log.Error("failed to get user")
+		return ErrGetUser
+	}
+
+	if err := bcrypt.CompareHashAndPassword(user.Password, []byte(oldPassword)); err != nil {
+		s.log.Error("failed to compare password")
+		return ErrFailedToComparePassword
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.MinCost)
+	if err != nil {
+		s.log.Error("failed to hash password")
+		return err
+	}
+
+	if err := s.db.SetPassword(ctx, user.ID, passwordHash, false); err != nil {
+		s.log.Error("failed to change password", "id", user.ID, "error", err)
+		return ErrUpdateUser
+	}
+
+	s.log.Info("password changed", "id", user.ID)
+
+	return nil
+}
+
+func (s *Userservice) ListUsers(ctx context.Context, filter UserFilter) ([]User, int64, error) {
+	if filter.Role != "" && !filter.Role.Valid() {
+		return nil, 0, ErrInvalidRole
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	users, total, err := s.db.ListUsers(ctx, filter)
+	if err != nil {
+		s.log.Error("failed to list users", "error", err)
+		return nil, 0, ErrGetUser
+	}
+
+	return users, total, nil
+}
+
+func (s *Userservice) GetUser(ctx context.Context, userID int64) (User, error) {
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return User{}, ErrUserNotFound
+		}
+		s.log.Error("failed to get user", "id", userID, "error", err)
+		return User{}, ErrGetUser
+	}
+
+	return user, nil
+}
+
+// SuspendUser блокирует пользователя до until (nil - бессрочно) и сообщает
+// другим сервисам, чтобы они завершили его сессии и звонки
+func (s *Userservice) SuspendUser(ctx context.Context, userID int64, reason string, until *time.Time) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.SetSuspended(ctx, userID, true, reason, until); err != nil {
+		s.log.Error("failed to suspend user", "id", userID, "error", err)
+		return ErrUpdateUser
+	}
+
+	s.log.Info("user suspended", "id", userID, "reason", reason, "until", until)
+
+	user.Suspended = true
+	user.SuspensionReason = reason
+	user.SuspendedUntil = until
+	if err := s.events.UserSuspended(ctx, user); err != nil {
+		s.log.Error("failed to publish suspension event", "id", userID, "error", err)
+	}
+
+	return nil
+}
+
+func (s *Userservice) UnsuspendUser(ctx context.Context, userID int64) error {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.db.SetSuspended(ctx, userID, false, "", nil); err != nil {
+		s.log.Error("failed to unsuspend user", "id", userID, "error", err)
+		return ErrUpdateUser
+	}
+
+	s.log.Info("user unsuspended", "id", userID)
+
+	return nil
+}
+
+// ForcePasswordReset заменяет пароль временным и требует сменить его при следующем входе.
+// Временный пароль возвращается администратору, чтобы он передал его пользователю.
+func (s *Userservice) ForcePasswordReset(ctx context.Context, userID int64) (string, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		s.log.Error("failed to generate password", "error", err)
+		return "", ErrUpdateUser
+	}
+	temporaryPassword := base64.RawURLEncoding.EncodeToString(buf)
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(temporaryPassword), bcrypt.MinCost)
+	if err != nil {
+		s.log.Error("failed to hash password")
+		return "", err
+	}
+
+	if err := s.db.SetPassword(ctx, userID, passwordHash, true); err != nil {
+		s.log.Error("failed to reset password", "id", userID, "error", err)
+		return "", ErrUpdateUser
+	}
+
+	s.log.Info("password reset forced", "id", userID)
+
+	return temporaryPassword, nil
+}
+
+func (s *Userservice) ChangeRole(ctx context.Context, userID int64, role Role) error {
+	if !role.Valid() {
+		return ErrInvalidRole
+	}
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.db.SetRole(ctx, userID, role); err != nil {
+		s.log.Error("failed to change role", "id", userID, "error", err)
+		return ErrUpdateUser
+	}
+
+	s.log.Info("role changed", "id", userID, "role", role)
+
+	return nil
+}
+
+// EnsureAdmin создаёт администратора из конфига, если его ещё нет
+func (s *Userservice) EnsureAdmin(ctx context.Context, email string, password string) error {
+	user, err := s.db.GetUserByEmail(ctx, email)
+	if err == nil {
+		if user.Role != RoleAdmin {
+			return s.ChangeRole(ctx, user.ID, RoleAdmin)
+		}
+		return nil
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		return ErrGetUser
+	}
+
+	_, err = s.Register(ctx, email, password, RoleAdmin)
+	return err
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// adminDB добавляет к fakeDB то, что нужно входу и управлению пользователями
+type adminDB struct {
+	*fakeDB
+
+	filter UserFilter
+}
+
+func (d *adminDB) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	for _, user := range d.users {
+		if user.Email == email {
+			return *user, nil
+		}
+	}
+	return User{}, ErrUserNotFound
+}
+
+func (d *adminDB) SaveUser(ctx context.Context, user User) (int64, error) {
+	user.ID = int64(len(d.users) + 1000)
+	d.users[user.ID] = &user
+	return user.ID, nil
+}
+
+func (d *adminDB) SetPassword(ctx context.Context, id int64, password []byte, resetRequired bool) error {
+	d.users[id].Password = password
+	d.users[id].PasswordResetRequired = resetRequired
+	return nil
+}
+
+func (d *adminDB) SetRole(ctx context.Context, id int64, role Role) error {
+	d.users[id].Role = role
+	return nil
+}
+
+func (d *adminDB) ListUsers(ctx context.Context, filter UserFilter) ([]User, int64, error) {
+	d.filter = filter
+	return nil, 0, nil
+}
+
+// fakeJWT выдаёт токен "token-<email>"
+type fakeJWT struct{}
+
+func (fakeJWT) GenerateToken(user User) (string, error) {
+	return "token-" + user.Email, nil
+}
+
+func (fakeJWT) VerifyToken(tokenString string, user User) bool {
+	return tokenString == "token-"+user.Email
+}
+
+type adminTest struct {
+	service *Userservice
+	db      *adminDB
+	events  *fakeEvents
+}
+
+// newAdminTest - волонтёр 7 с паролем "secret"
+func newAdminTest(t *testing.T) *adminTest {
+	t.Helper()
+	password, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	at := &adminTest{
+		db:     &adminDB{fakeDB: newFakeDB(User{ID: 7, Email: "v7@example.com", Password: password, Role: RoleVolunteer})},
+		events: &fakeEvents{},
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	at.service = NewUserService(log, at.db, fakeJWT{}, nil, nil, at.events, 0, ModerationPolicy{})
+	return at
+}
+
+func TestForcePasswordResetRequiresNewPassword(t *testing.T) {
+	at := newAdminTest(t)
+
+	temporary, err := at.service.ForcePasswordReset(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("ForcePasswordReset() error = %v", err)
+	}
+	if _, _, err := at.service.Login(context.Background(), "v7@example.com", "secret"); !errors.Is(err, ErrFailedToComparePassword) {
+		t.Fatalf("Login(old password) error = %v, want %v", err, ErrFailedToComparePassword)
+	}
+	if _, _, err := at.service.Login(context.Background(), "v7@example.com", temporary); !errors.Is(err, ErrPasswordResetRequired) {
+		t.Fatalf("Login(temporary password) error = %v, want %v", err, ErrPasswordResetRequired)
+	}
+	// выданные раньше токены тоже перестают действовать
+	if ok, err := at.service.CheckJWT(context.Background(), 7, "token-v7@example.com"); ok || !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("CheckJWT() = %v, %v, want %v", ok, err, ErrInvalidCredentials)
+	}
+
+	if err := at.service.ChangePassword(context.Background(), "v7@example.com", temporary, "new-secret"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	if _, token, err := at.service.Login(context.Background(), "v7@example.com", "new-secret"); err != nil || token == "" {
+		t.Fatalf("Login(new password) = %q, %v", token, err)
+	}
+}
+
+func TestSuspendedUserCannotSignIn(t *testing.T) {
+	at := newAdminTest(t)
+	until := time.Now().Add(time.Hour)
+
+	if err := at.service.SuspendUser(context.Background(), 7, "spam", &until); err != nil {
+		t.Fatalf("SuspendUser() error = %v", err)
+	}
+	if len(at.events.suspended) != 1 || at.events.suspended[0].SuspensionReason != "spam" {
+		t.Fatalf("suspension events = %+v, want one with the reason", at.events.suspended)
+	}
+	if _, _, err := at.service.Login(context.Background(), "v7@example.com", "secret"); !errors.Is(err, ErrUserSuspended) {
+		t.Fatalf("Login() error = %v, want %v", err, ErrUserSuspended)
+	}
+	if _, err := at.service.CheckJWT(context.Background(), 7, "token-v7@example.com"); !errors.Is(err, ErrUserSuspended) {
+		t.Fatalf("CheckJWT() error = %v, want %v", err, ErrUserSuspended)
+	}
+
+	if err := at.service.UnsuspendUser(context.Background(), 7); err != nil {
+		t.Fatalf("UnsuspendUser() error = %v", err)
+	}
+	if _, _, err := at.service.Login(context.Background(), "v7@example.com", "secret"); err != nil {
+		t.Fatalf("Login() after unsuspend error = %v", err)
+	}
+}
+
+func TestExpiredSuspensionAllowsSignIn(t *testing.T) {
+	at := newAdminTest(t)
+	until := time.Now().Add(-time.Minute)
+
+	if err := at.service.SuspendUser(context.Background(), 7, "spam", &until); err != nil {
+		t.Fatalf("SuspendUser() error = %v", err)
+	}
+	if ok, err := at.service.CheckJWT(context.Background(), 7, "token-v7@example.com"); !ok || err != nil {
+		t.Fatalf("CheckJWT() = %v, %v after suspension ended", ok, err)
+	}
+}
+
+func TestChangeRole(t *testing.T) {
+	at := newAdminTest(t)
+
+	if err := at.service.ChangeRole(context.Background(), 7, "root"); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("ChangeRole(root) error = %v, want %v", err, ErrInvalidRole)
+	}
+	if err := at.service.ChangeRole(context.Background(), 999, RoleAdmin); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("ChangeRole(unknown user) error = %v, want %v", err, ErrUserNotFound)
+	}
+	if err := at.service.ChangeRole(context.Background(), 7, RoleAdmin); err != nil || at.db.users[7].Role != RoleAdmin {
+		t.Errorf("ChangeRole(admin) error = %v, role = %s", err, at.db.users[7].Role)
+	}
+}
+
+func TestEnsureAdmin(t *testing.T) {
+	at := newAdminTest(t)
+
+	// существующий пользователь повышается до администратора, пароль не меняется
+	if err := at.service.EnsureAdmin(context.Background(), "v7@example.com", "other"); err != nil {
+		t.Fatalf("EnsureAdmin(existing) error = %v", err)
+	}
+	if role, _, err := at.service.Login(context.Background(), "v7@example.com", "secret"); err != nil || role != RoleAdmin {
+		t.Fatalf("Login() = %s, %v, want admin with the old password", role, err)
+	}
+
+	for range 2 {
+		if err := at.service.EnsureAdmin(context.Background(), "admin@example.com", "admin-secret"); err != nil {
+			t.Fatalf("EnsureAdmin(new) error = %v", err)
+		}
+	}
+	if len(at.db.users) != 2 {
+		t.Fatalf("%d users, want the admin created once", len(at.db.users))
+	}
+	if role, _, err := at.service.Login(context.Background(), "admin@example.com", "admin-secret"); err != nil || role != RoleAdmin {
+		t.Fatalf("Login(admin) = %s, %v", role, err)
+	}
+}
+
+func TestListUsersLimits(t *testing.T) {
+	at := newAdminTest(t)
+
+	if _, _, err := at.service.ListUsers(context.Background(), UserFilter{Role: "root"}); !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("ListUsers(root) error = %v, want %v", err, ErrInvalidRole)
+	}
+	for _, tt := range []struct {
+		filter UserFilter
+		want   UserFilter
+	}{
+		{UserFilter{}, UserFilter{Limit: defaultListLimit}},
+		{UserFilter{Limit: 1000, Offset: -5}, UserFilter{Limit: maxListLimit}},
+		{UserFilter{Role: RoleBlind, Limit: 10, Offset: 20}, UserFilter{Role: RoleBlind, Limit: 10, Offset: 20}},
+	} {
+		if _, _, err := at.service.ListUsers(context.Background(), tt.filter); err != nil {
+			t.Fatalf("ListUsers(%+v) error = %v", tt.filter, err)
+		}
+		if at.db.filter != tt.want {
+			t.Errorf("ListUsers(%+v) queried %+v, want %+v", tt.filter, at.db.filter, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import "context"
+
+func (s *Userservice) BlockUser(ctx context.Context, userID int64, blockedUserID int64) error {
+	if userID == blockedUserID {
+		return ErrBlockSelf
+	}
+	if _, err := s.GetUser(ctx, blockedUserID); err != nil {
+		return err
+	}
+
+	if err := s.db.SaveBlock(ctx, userID, blockedUserID); err != nil {
+		s.log.Error("failed to block user", "user", userID, "blocked", blockedUserID, "error", err)
+		return ErrBlockList
+	}
+
+	s.log.Info("user blocked", "user", userID, "blocked", blockedUserID)
+
+	return nil
+}
+
+func (s *Userservice) UnblockUser(ctx context.Context, userID int64, blockedUserID int64) error {
+	if err := s.db.DeleteBlock(ctx, userID, blockedUserID); err != nil {
+		s.log.Error("failed to unblock user", "user", userID, "blocked", blockedUserID, "error", err)
+		return ErrBlockList
+	}
+
+	s.log.Info("user unblocked", "user", userID, "blocked", blockedUserID)
+
+	return nil
+}
+
+func (s *Userservice) ListBlocked(ctx context.Context, userID int64) ([]BlockedUser, error) {
+	blocked, err := s.db.GetBlocked(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to list blocked users", "user", userID, "error", err)
+		return nil, ErrGetUser
+	}
+
+	return blocked, nil
+}
+
+// IsBlocked проверяет блокировку в обе стороны: такие пользователи не должны попадать в один звонок
+func (s *Userservice) IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+	blocked, err := s.db.IsBlocked(ctx, userID, otherUserID)
+	if err != nil {
+		s.log.Error("failed to check block", "user", userID, "other", otherUserID, "error", err)
+		return false, ErrGetUser
+	}
+
+	return blocked, nil
+}
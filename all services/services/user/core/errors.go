@@ -10,4 +10,21 @@ var (
 	ErrGetUser            		= errors.New("failed to get user")
 	ErrFailedToComparePassword 	= errors.New("failed to compare password")
 	ErrFailedGenerateToken 		= errors.New("failed to generate token")
+	ErrDeleteUser         		= errors.New("failed to delete user")
+	ErrExportData         		= errors.New("failed to export user data")
+	ErrUpdateUser         		= errors.New("failed to update user")
+	ErrInvalidRole        		= errors.New("invalid role")
+	ErrPasswordResetRequired 	= errors.New("password reset required")
+	ErrUserSuspended      		= errors.New("user suspended")
+	ErrBlockSelf          		= errors.New("cannot block yourself")
+	ErrBlockList          		= errors.New("failed to update block list")
+	ErrInvalidScore       		= errors.New("invalid rating score")
+	ErrNotVolunteer       		= errors.New("user is not a volunteer")
+	ErrBadReport          		= errors.New("invalid report")
+	ErrReportNotFound     		= errors.New("report not found")
+	ErrReportClosed       		= errors.New("report already closed")
+	ErrReports            		= errors.New("failed to process report")
+	ErrHelpRequestNotFound 		= errors.New("help request not found")
+	ErrInvalidProfile     		= errors.New("invalid profile")
+	ErrInvalidAvailability		= errors.New("invalid availability")
 )
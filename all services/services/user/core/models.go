@@ -1,8 +1,243 @@
 package core
 
+import (
+	"strings"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleBlind     Role = "blind"
+	RoleVolunteer Role = "volunteer"
+	RoleAdmin     Role = "admin"
+)
+
+func (r Role) Valid() bool {
+	switch r {
+	case RoleBlind, RoleVolunteer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID       int64
-	Email    string
-	Password []byte
-	Role     bool
-}
\ No newline at end of file
+	ID                    int64      `db:"id"`
+	Email                 string     `db:"email"`
+	Password              []byte     `db:"password"`
+	Role                  Role       `db:"role"`
+	Suspended             bool       `db:"suspended"`
+	SuspensionReason      string     `db:"suspension_reason"`
+	SuspendedUntil        *time.Time `db:"suspended_until"`
+	PasswordResetRequired bool       `db:"password_reset_required"`
+	RatingSum             int64      `db:"rating_sum"`
+	RatingCount           int64      `db:"rating_count"`
+	Languages             string     `db:"languages"` // через запятую, см. LanguageList
+	Timezone              string     `db:"timezone"`
+	DNDUntil              *time.Time `db:"dnd_until"`
+	CreatedAt             time.Time  `db:"created_at"`
+}
+
+// DoNotDisturb - пользователь попросил не присылать запросы до DNDUntil
+func (u User) DoNotDisturb(now time.Time) bool {
+	return u.DNDUntil != nil && now.Before(*u.DNDUntil)
+}
+
+const (
+	MinutesPerDay = 24 * 60
+	MaxWindows    = 50
+)
+
+// AvailabilityWindow - еженедельное окно в часовом поясе пользователя, минуты [Start, End).
+// Окно через полночь задаётся двумя окнами.
+type AvailabilityWindow struct {
+	Weekday time.Weekday `db:"weekday" json:"weekday"`
+	Start   int          `db:"start_minute" json:"start"`
+	End     int          `db:"end_minute" json:"end"`
+}
+
+func (w AvailabilityWindow) Valid() bool {
+	return w.Weekday >= time.Sunday && w.Weekday <= time.Saturday && w.Start >= 0 && w.Start < w.End && w.End <= MinutesPerDay
+}
+
+// Available - попадает ли момент now в одно из окон. Без окон пользователь доступен всегда.
+func Available(windows []AvailabilityWindow, location *time.Location, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	for _, w := range windows {
+		if w.Weekday == local.Weekday() && minute >= w.Start && minute < w.End {
+			return true
+		}
+	}
+	return false
+}
+
+type Availability struct {
+	Windows  []AvailabilityWindow
+	DNDUntil *time.Time
+}
+
+const MaxLanguages = 5
+
+func (u User) LanguageList() []string {
+	if u.Languages == "" {
+		return nil
+	}
+	return strings.Split(u.Languages, ",")
+}
+
+const (
+	MinRatingScore = 1
+	MaxRatingScore = 5
+)
+
+// IsSuspended учитывает срок блокировки: после SuspendedUntil пользователь снова активен
+func (u User) IsSuspended(now time.Time) bool {
+	return u.Suspended && (u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil))
+}
+
+// RatingAverage - средняя оценка волонтёра после звонков, 0 если оценок ещё нет
+func (u User) RatingAverage() float64 {
+	if u.RatingCount == 0 {
+		return 0
+	}
+	return float64(u.RatingSum) / float64(u.RatingCount)
+}
+
+type BlockedUser struct {
+	UserID    int64     `db:"blocked_id" json:"userId"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// UserFilter - параметры поиска пользователей в админке
+type UserFilter struct {
+	Query  string
+	Role   Role
+	Limit  int
+	Offset int
+}
+
+// VolunteerFilter - кандидаты для рассылки запроса помощи
+type VolunteerFilter struct {
+	RequesterID int64
+	ExcludeIDs  []int64
+	Limit       int
+	// IncludeBlocked - не отбрасывать волонтёров, у которых есть блокировка с автором
+	IncludeBlocked bool
+	// AvailableOnly - пропускать тех, кто включил «не беспокоить» или сейчас вне окон доступности.
+	// Для срочного запроса (Urgent) окна не учитываются.
+	AvailableOnly bool
+	Urgent        bool
+	// Languages - языки автора запроса: волонтёры, говорящие на одном из них, идут первыми
+	Languages []string
+	// Offset - сколько подходящих под запрос волонтёров пропустить, для постраничного чтения
+	Offset int
+}
+
+// VolunteerList - кандидаты и причины, по которым часть из них сейчас не стоит беспокоить
+type VolunteerList struct {
+	Volunteers   []User
+	Blocked      []int64
+	OffSchedule  []int64
+	DoNotDisturb []int64
+}
+
+type Profile struct {
+	ID        int64     `json:"id"`
+	Email     string    `json:"email"`
+	Role      Role      `json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type HelpRequest struct {
+	ID          int64     `json:"id"`
+	RequesterID int64     `json:"requesterId"`
+	VolunteerID int64     `json:"volunteerId,omitempty"`
+	Question    string    `json:"question"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Call struct {
+	ID            int64      `json:"id"`
+	HelpRequestID int64      `json:"helpRequestId"`
+	BlindID       int64      `json:"blindId"`
+	VolunteerID   int64      `json:"volunteerId,omitempty"`
+	StartedAt     time.Time  `json:"startedAt"`
+	EndedAt       *time.Time `json:"endedAt,omitempty"`
+}
+
+type Rating struct {
+	ID            int64     `json:"id"`
+	HelpRequestID int64     `json:"helpRequestId"`
+	RaterID       int64     `json:"raterId"`
+	RateeID       int64     `json:"rateeId"`
+	Score         int       `json:"score"`
+	Tags          []string  `json:"tags"`
+	Comment       string    `json:"comment,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// UserData - всё, что хранится о пользователе, для выгрузки по его запросу
+type UserData struct {
+	ExportedAt   time.Time     `json:"exportedAt"`
+	Profile      Profile       `json:"profile"`
+	HelpRequests []HelpRequest `json:"helpRequests"`
+	Calls        []Call        `json:"calls"`
+	Ratings      []Rating      `json:"ratings"`
+	Blocked      []BlockedUser `json:"blocked"`
+}
+
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportTriaged   ReportStatus = "triaged"
+	ReportActioned  ReportStatus = "actioned"
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+func (s ReportStatus) Valid() bool {
+	switch s {
+	case ReportOpen, ReportTriaged, ReportActioned, ReportDismissed:
+		return true
+	}
+	return false
+}
+
+// Closed - по закрытой жалобе решение уже принято, менять статус нельзя
+func (s ReportStatus) Closed() bool {
+	return s == ReportActioned || s == ReportDismissed
+}
+
+type Report struct {
+	ID             int64        `db:"id"`
+	ReporterID     int64        `db:"reporter_id"`
+	ReportedUserID int64        `db:"reported_user_id"`
+	HelpRequestID  *int64       `db:"help_request_id"`
+	Reason         string       `db:"reason"`
+	Comment        string       `db:"comment"`
+	Status         ReportStatus `db:"status"`
+	HandledBy      *int64       `db:"handled_by"`
+	ResolutionNote string       `db:"resolution_note"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+}
+
+type ReportFilter struct {
+	Status         ReportStatus
+	ReportedUserID int64
+	Limit          int
+	Offset         int
+}
+
+// ModerationPolicy - когда жалобы приводят к автоматической блокировке:
+// Threshold разных пользователей пожаловались за Window, блокировка на SuspendFor
+type ModerationPolicy struct {
+	Threshold  int
+	Window     time.Duration
+	SuspendFor time.Duration
+}
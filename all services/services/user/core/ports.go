@@ -1,12 +1,38 @@
 package core
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type DB interface {
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
 	SaveUser(ctx context.Context, user User) (int64, error)
 	GetUsersCount(ctx context.Context) (int64, int64, error) // returns (volunteers_count, blind_count, error)
+	SoftDeleteUser(ctx context.Context, id int64) error
+	GetUsersDeletedBefore(ctx context.Context, before time.Time) ([]User, error)
+	PurgeUser(ctx context.Context, id int64) error
+	ListUsers(ctx context.Context, filter UserFilter) ([]User, int64, error)
+	SetSuspended(ctx context.Context, id int64, suspended bool, reason string, until *time.Time) error
+	SetPassword(ctx context.Context, id int64, password []byte, resetRequired bool) error
+	SetRole(ctx context.Context, id int64, role Role) error
+	SaveBlock(ctx context.Context, blockerID int64, blockedID int64) error
+	DeleteBlock(ctx context.Context, blockerID int64, blockedID int64) error
+	GetBlocked(ctx context.Context, blockerID int64) ([]BlockedUser, error)
+	IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error)
+	SetRating(ctx context.Context, id int64, sum, count int64) error
+	SaveReport(ctx context.Context, report Report) (int64, error)
+	GetReport(ctx context.Context, id int64) (Report, error)
+	ListReports(ctx context.Context, filter ReportFilter) ([]Report, int64, error)
+	UpdateReportStatus(ctx context.Context, id int64, status ReportStatus, handledBy int64, note string) error
+	CountReporters(ctx context.Context, reportedUserID int64, since time.Time) (int, error)
+	// ListVolunteers возвращает и id тех из них, у кого есть блокировка с автором запроса
+	ListVolunteers(ctx context.Context, filter VolunteerFilter) ([]User, []int64, error)
+	UpdateProfile(ctx context.Context, id int64, languages string, timezone string) error
+	GetAvailabilityWindows(ctx context.Context, userIDs []int64) (map[int64][]AvailabilityWindow, error)
+	SetAvailabilityWindows(ctx context.Context, userID int64, windows []AvailabilityWindow) error
+	SetDoNotDisturb(ctx context.Context, userID int64, until *time.Time) error
 }
 
 type JWT interface {
@@ -14,9 +40,47 @@ type JWT interface {
 	VerifyToken(tokenString string, user User) bool
 }
 
+type Events interface {
+	UserSuspended(ctx context.Context, user User) error
+}
+
+type Help interface {
+	GetUserHistory(ctx context.Context, userID int64) ([]HelpRequest, []Call, []Rating, error)
+	GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error)
+	DeleteUserData(ctx context.Context, userID int64) error
+}
+
+type Notify interface {
+	DeleteUserData(ctx context.Context, userID int64) error
+}
+
 type UserService interface {
-	Register(ctx context.Context, email string, password string, role bool) (int64, error)
-	Login(ctx context.Context, email string, password string) (bool, string, error)
+	Register(ctx context.Context, email string, password string, role Role) (int64, error)
+	Login(ctx context.Context, email string, password string) (Role, string, error)
 	CheckJWT(ctx context.Context, userID int64, token string) (bool, error)
 	GetStatistics(ctx context.Context) (int64, int64, error) // returns (volunteers_count, blind_count, error)
+	DeleteAccount(ctx context.Context, userID int64) error
+	ExportMyData(ctx context.Context, userID int64) (UserData, error)
+	ChangePassword(ctx context.Context, email string, oldPassword string, newPassword string) error
+	BlockUser(ctx context.Context, userID int64, blockedUserID int64) error
+	UnblockUser(ctx context.Context, userID int64, blockedUserID int64) error
+	ListBlocked(ctx context.Context, userID int64) ([]BlockedUser, error)
+	IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error)
+	SetVolunteerRating(ctx context.Context, userID int64, sum, count int64) error
+	CreateReport(ctx context.Context, report Report) (int64, error)
+	ListVolunteers(ctx context.Context, filter VolunteerFilter) (VolunteerList, error)
+	UpdateProfile(ctx context.Context, userID int64, languages []string, timezone string) error
+	GetAvailability(ctx context.Context, userID int64) (Availability, error)
+	SetAvailability(ctx context.Context, userID int64, windows []AvailabilityWindow) error
+	SetDoNotDisturb(ctx context.Context, userID int64, until *time.Time) error
+	ListReports(ctx context.Context, filter ReportFilter) ([]Report, int64, error)
+	GetReport(ctx context.Context, id int64) (Report, error)
+	UpdateReportStatus(ctx context.Context, id int64, status ReportStatus, adminID int64, note string) error
+	ListUsers(ctx context.Context, filter UserFilter) ([]User, int64, error)
+	GetUser(ctx context.Context, userID int64) (User, error)
+	SuspendUser(ctx context.Context, userID int64, reason string, until *time.Time) error
+	UnsuspendUser(ctx context.Context, userID int64) error
+	ForcePasswordReset(ctx context.Context, userID int64) (string, error)
+	ChangeRole(ctx context.Context, userID int64, role Role) error
+	EnsureAdmin(ctx context.Context, email string, password string) error
 }
\ No newline at end of file
@@ -0,0 +1,28 @@
+package core
+
+import "context"
+
+// SetVolunteerRating записывает итог по всем оценкам волонтёра. Итог считает сервис помощи
+// по сохранённым оценкам, поэтому повтор вызова безопасен.
+func (s *Userservice) SetVolunteerRating(ctx context.Context, userID int64, sum, count int64) error {
+	if count < 0 || sum < count*MinRatingScore || sum > count*MaxRatingScore {
+		return ErrInvalidScore
+	}
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != RoleVolunteer {
+		return ErrNotVolunteer
+	}
+
+	if err := s.db.SetRating(ctx, userID, sum, count); err != nil {
+		s.log.Error("failed to set rating", "user", userID, "error", err)
+		return ErrUpdateUser
+	}
+
+	s.log.Info("volunteer rating updated", "user", userID, "sum", sum, "count", count)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	maxReasonLength  = 64
+	maxCommentLength = 2000
+)
+
+// CreateReport принимает жалобу на пользователя. Если жалоба привязана к запросу помощи,
+// жаловаться может только участник звонка, а обвиняемым по умолчанию считается другая сторона.
+func (s *Userservice) CreateReport(ctx context.Context, report Report) (int64, error) {
+	if report.Reason == "" || len(report.Reason) > maxReasonLength || len(report.Comment) > maxCommentLength {
+		return 0, ErrBadReport
+	}
+
+	if report.HelpRequestID != nil {
+		request, err := s.help.GetHelpRequest(ctx, *report.HelpRequestID)
+		if err != nil {
+			if errors.Is(err, ErrHelpRequestNotFound) {
+				return 0, ErrHelpRequestNotFound
+			}
+			s.log.Error("failed to get help request", "id", *report.HelpRequestID, "error", err)
+			return 0, ErrReports
+		}
+
+		var other int64
+		switch report.ReporterID {
+		case request.RequesterID:
+			other = request.VolunteerID
+		case request.VolunteerID:
+			other = request.RequesterID
+		default:
+			return 0, ErrBadReport
+		}
+		if report.ReportedUserID == 0 {
+			report.ReportedUserID = other
+		}
+		if other == 0 || report.ReportedUserID != other {
+			return 0, ErrBadReport
+		}
+	}
+
+	if report.ReportedUserID == 0 || report.ReportedUserID == report.ReporterID {
+		return 0, ErrBadReport
+	}
+	if _, err := s.GetUser(ctx, report.ReportedUserID); err != nil {
+		return 0, err
+	}
+
+	report.Status = ReportOpen
+	id, err := s.db.SaveReport(ctx, report)
+	if err != nil {
+		s.log.Error("failed to save report", "reporter", report.ReporterID, "error", err)
+		return 0, ErrReports
+	}
+
+	s.log.Info("report created", "id", id, "reporter", report.ReporterID, "reported", report.ReportedUserID)
+
+	s.autoSuspend(ctx, report.ReportedUserID)
+
+	return id, nil
+}
+
+// autoSuspend временно блокирует пользователя, на которого пожаловались
+// moderation.Threshold разных пользователей. Отклонённые жалобы не учитываются.
+func (s *Userservice) autoSuspend(ctx context.Context, userID int64) {
+	if s.moderation.Threshold <= 0 {
+		return
+	}
+
+	reporters, err := s.db.CountReporters(ctx, userID, time.Now().Add(-s.moderation.Window))
+	if err != nil {
+		s.log.Error("failed to count reporters", "user", userID, "error", err)
+		return
+	}
+	if reporters < s.moderation.Threshold {
+		return
+	}
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil || user.IsSuspended(time.Now()) {
+		return
+	}
+
+	until := time.Now().Add(s.moderation.SuspendFor)
+	reason := fmt.Sprintf("automatic suspension: reported by %d users", reporters)
+	if err := s.SuspendUser(ctx, userID, reason, &until); err != nil {
+		s.log.Error("failed to suspend reported user", "user", userID, "error", err)
+	}
+}
+
+func (s *Userservice) ListReports(ctx context.Context, filter ReportFilter) ([]Report, int64, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, 0, ErrBadReport
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	reports, total, err := s.db.ListReports(ctx, filter)
+	if err != nil {
+		s.log.Error("failed to list reports", "error", err)
+		return nil, 0, ErrReports
+	}
+
+	return reports, total, nil
+}
+
+func (s *Userservice) GetReport(ctx context.Context, id int64) (Report, error) {
+	report, err := s.db.GetReport(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrReportNotFound) {
+			return Report{}, ErrReportNotFound
+		}
+		s.log.Error("failed to get report", "id", id, "error", err)
+		return Report{}, ErrReports
+	}
+
+	return report, nil
+}
+
+// UpdateReportStatus двигает жалобу по очереди модерации: open -> triaged -> actioned/dismissed.
+// Блокировку по жалобе администратор выставляет отдельно через SuspendUser.
+func (s *Userservice) UpdateReportStatus(ctx context.Context, id int64, status ReportStatus, adminID int64, note string) error {
+	if !status.Valid() || status == ReportOpen || len(note) > maxCommentLength {
+		return ErrBadReport
+	}
+
+	report, err := s.GetReport(ctx, id)
+	if err != nil {
+		return err
+	}
+	if report.Status.Closed() {
+		return ErrReportClosed
+	}
+
+	if err := s.db.UpdateReportStatus(ctx, id, status, adminID, note); err != nil {
+		s.log.Error("failed to update report", "id", id, "error", err)
+		return ErrReports
+	}
+
+	s.log.Info("report updated", "id", id, "status", status, "admin", adminID)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeDB хранит пользователей и жалобы в памяти; остальные методы DB не вызываются
+type fakeDB struct {
+	DB
+
+	users   map[int64]*User
+	reports []Report
+}
+
+func newFakeDB(users ...User) *fakeDB {
+	db := &fakeDB{users: map[int64]*User{}}
+	for _, user := range users {
+		db.users[user.ID] = &user
+	}
+	return db
+}
+
+func (d *fakeDB) GetUserByID(ctx context.Context, id int64) (User, error) {
+	user, ok := d.users[id]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return *user, nil
+}
+
+func (d *fakeDB) SetSuspended(ctx context.Context, id int64, suspended bool, reason string, until *time.Time) error {
+	user := d.users[id]
+	user.Suspended = suspended
+	user.SuspensionReason = reason
+	user.SuspendedUntil = until
+	return nil
+}
+
+func (d *fakeDB) SaveReport(ctx context.Context, report Report) (int64, error) {
+	report.ID = int64(len(d.reports) + 1)
+	report.CreatedAt = time.Now()
+	d.reports = append(d.reports, report)
+	return report.ID, nil
+}
+
+// CountReporters повторяет запрос из adapters/db: отклонённые жалобы не считаются
+func (d *fakeDB) CountReporters(ctx context.Context, reportedUserID int64, since time.Time) (int, error) {
+	var reporters []int64
+	for _, report := range d.reports {
+		if report.ReportedUserID == reportedUserID && !report.CreatedAt.Before(since) && report.Status != ReportDismissed &&
+			!slices.Contains(reporters, report.ReporterID) {
+			reporters = append(reporters, report.ReporterID)
+		}
+	}
+	return len(reporters), nil
+}
+
+type fakeEvents struct {
+	suspended []User
+}
+
+func (e *fakeEvents) UserSuspended(ctx context.Context, user User) error {
+	e.suspended = append(e.suspended, user)
+	return nil
+}
+
+// fakeHelp - запрос 1 автора 100 принят волонтёром 7
+type fakeHelp struct {
+	Help
+}
+
+func (fakeHelp) GetHelpRequest(ctx context.Context, id int64) (HelpRequest, error) {
+	if id != 1 {
+		return HelpRequest{}, ErrHelpRequestNotFound
+	}
+	return HelpRequest{ID: 1, RequesterID: 100, VolunteerID: 7, Status: "completed"}, nil
+}
+
+var moderation = ModerationPolicy{Threshold: 3, Window: 24 * time.Hour, SuspendFor: 72 * time.Hour}
+
+type reportTest struct {
+	service *Userservice
+	db      *fakeDB
+	events  *fakeEvents
+}
+
+// newReportTest - волонтёр 7, автор запросов 100 и ещё пользователи 101..104
+func newReportTest(policy ModerationPolicy) *reportTest {
+	rt := &reportTest{
+		db:     newFakeDB(User{ID: 7, Role: RoleVolunteer}, User{ID: 100}, User{ID: 101}, User{ID: 102}, User{ID: 103}, User{ID: 104}),
+		events: &fakeEvents{},
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	rt.service = NewUserService(log, rt.db, nil, fakeHelp{}, nil, rt.events, 0, policy)
+	return rt
+}
+
+func (rt *reportTest) report(t *testing.T, reporterID, reportedUserID int64) {
+	t.Helper()
+	if _, err := rt.service.CreateReport(context.Background(), Report{ReporterID: reporterID, ReportedUserID: reportedUserID, Reason: "rude"}); err != nil {
+		t.Fatalf("CreateReport(%d -> %d) error = %v", reporterID, reportedUserID, err)
+	}
+}
+
+func TestReportsFromThresholdUsersSuspend(t *testing.T) {
+	rt := newReportTest(moderation)
+
+	rt.report(t, 100, 7)
+	rt.report(t, 101, 7)
+	if rt.db.users[7].Suspended {
+		t.Fatal("suspended after two reporters")
+	}
+
+	before := time.Now()
+	rt.report(t, 102, 7)
+	user := rt.db.users[7]
+	if !user.IsSuspended(time.Now()) || user.SuspensionReason != "automatic suspension: reported by 3 users" {
+		t.Fatalf("user = %+v, want automatic suspension", user)
+	}
+	if until := user.SuspendedUntil.Sub(before); until < moderation.SuspendFor || until > moderation.SuspendFor+time.Minute {
+		t.Fatalf("suspended for %v, want %v", until, moderation.SuspendFor)
+	}
+	if len(rt.events.suspended) != 1 || rt.events.suspended[0].ID != 7 {
+		t.Fatalf("suspension events = %+v, want one for user 7", rt.events.suspended)
+	}
+
+	// уже заблокированного пользователя повторно не блокируем
+	rt.report(t, 103, 7)
+	if len(rt.events.suspended) != 1 {
+		t.Fatalf("suspension events = %+v, want still one", rt.events.suspended)
+	}
+}
+
+func TestReportsNotCountedTowardsSuspension(t *testing.T) {
+	rt := newReportTest(moderation)
+	// старая жалоба вне окна и отклонённая модератором
+	rt.db.reports = append(rt.db.reports,
+		Report{ReporterID: 103, ReportedUserID: 7, Status: ReportOpen, CreatedAt: time.Now().Add(-2 * moderation.Window)},
+		Report{ReporterID: 104, ReportedUserID: 7, Status: ReportDismissed, CreatedAt: time.Now()},
+	)
+
+	rt.report(t, 100, 7)
+	rt.report(t, 100, 7)
+	rt.report(t, 101, 7)
+	if rt.db.users[7].Suspended || len(rt.events.suspended) != 0 {
+		t.Fatalf("user = %+v suspended by repeated, stale and dismissed reports", rt.db.users[7])
+	}
+}
+
+func TestAutoSuspendDisabledWithoutThreshold(t *testing.T) {
+	rt := newReportTest(ModerationPolicy{})
+
+	for _, reporter := range []int64{100, 101, 102, 103} {
+		rt.report(t, reporter, 7)
+	}
+	if rt.db.users[7].Suspended {
+		t.Fatal("suspended with moderation threshold disabled")
+	}
+}
+
+func TestReportAboutHelpRequest(t *testing.T) {
+	rt := newReportTest(moderation)
+	helpRequestID := int64(1)
+
+	// обвиняемый по умолчанию - другая сторона звонка
+	if _, err := rt.service.CreateReport(context.Background(), Report{ReporterID: 100, HelpRequestID: &helpRequestID, Reason: "rude"}); err != nil {
+		t.Fatalf("CreateReport() error = %v", err)
+	}
+	if got := rt.db.reports[0]; got.ReportedUserID != 7 || got.Status != ReportOpen {
+		t.Fatalf("report = %+v, want open report on volunteer 7", got)
+	}
+
+	unknown := int64(2)
+	tests := []struct {
+		name   string
+		report Report
+		want   error
+	}{
+		{"not a participant", Report{ReporterID: 101, HelpRequestID: &helpRequestID, Reason: "rude"}, ErrBadReport},
+		{"someone else reported", Report{ReporterID: 100, ReportedUserID: 101, HelpRequestID: &helpRequestID, Reason: "rude"}, ErrBadReport},
+		{"unknown request", Report{ReporterID: 100, HelpRequestID: &unknown, Reason: "rude"}, ErrHelpRequestNotFound},
+		{"no reason", Report{ReporterID: 100, ReportedUserID: 7}, ErrBadReport},
+		{"self", Report{ReporterID: 7, ReportedUserID: 7, Reason: "rude"}, ErrBadReport},
+		{"unknown user", Report{ReporterID: 100, ReportedUserID: 999, Reason: "rude"}, ErrUserNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := rt.service.CreateReport(context.Background(), tt.report); !errors.Is(err, tt.want) {
+			t.Errorf("%s: CreateReport() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if len(rt.db.reports) != 1 {
+		t.Fatalf("saved %d reports, want 1", len(rt.db.reports))
+	}
+}
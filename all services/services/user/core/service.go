@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -12,13 +13,18 @@ type Userservice struct {
 	log *slog.Logger
 	db DB
 	jwt JWT
+	help Help
+	notify Notify
+	events Events
+	deletionGrace time.Duration
+	moderation ModerationPolicy
 }
 
-func NewUserService(log *slog.Logger, db DB, jwt JWT) *Userservice {
-	return &Userservice{log: log, db: db, jwt: jwt}
+func NewUserService(log *slog.Logger, db DB, jwt JWT, help Help, notify Notify, events Events, deletionGrace time.Duration, moderation ModerationPolicy) *Userservice {
+	return &Userservice{log: log, db: db, jwt: jwt, help: help, notify: notify, events: events, deletionGrace: deletionGrace, moderation: moderation}
 }
 
-func (s *Userservice) Register(ctx context.Context, email string, password string, role bool) (int64, error) {
+func (s *Userservice) Register(ctx context.Context, email string, password string, role Role) (int64, error) {
 	_, err := s.db.GetUserByEmail(ctx, email)
 	if !errors.Is(err, ErrUserNotFound) {
 		s.log.Error("user already exists")
@@ -37,6 +43,9 @@ func (s *Userservice) Register(ctx context.Context, email string, password strin
 		Role: role,
 	})
 	if err != nil {
+		if errors.Is(err, ErrUserAlreadyExists) {
+			return 0, ErrUserAlreadyExists
+		}
 		s.log.Error("failed to save user")
 		return 0, ErrSaveUser
 	}
@@ -46,15 +55,15 @@ func (s *Userservice) Register(ctx context.Context, email string, password strin
 	return userID, nil
 }
 
-func (s *Userservice) Login(ctx context.Context, email string, password string) (bool, string, error) {
+func (s *Userservice) Login(ctx context.Context, email string, password string) (Role, string, error) {
 	user, err := s.db.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, ErrUserNotFound) {
 			s.log.Error("user not found")
-			return false, "", ErrUserNotFound
+			return "", "", ErrUserNotFound
 		}
 		s.log.Error("failed to get user")
-		return false, "", ErrGetUser
+		return "", "", ErrGetUser
 	}
 
 	if err = bcrypt.CompareHashAndPassword(user.Password, []byte(password)); err != nil {
@@ -62,12 +71,22 @@ func (s *Userservice) Login(ctx context.Context, email string, password string)
 		return user.Role, "", ErrFailedToComparePassword
 	}
 
+	if user.IsSuspended(time.Now()) {
+		s.log.Error("user suspended", "id", user.ID)
+		return user.Role, "", ErrUserSuspended
+	}
+
+	if user.PasswordResetRequired {
+		s.log.Error("password reset required", "id", user.ID)
+		return user.Role, "", ErrPasswordResetRequired
+	}
+
 	s.log.Info("user logged in", "id", user.ID)
 
 	token, err := s.jwt.GenerateToken(user) 
 	if err != nil {
 		s.log.Error("failed to generate token")
-		return false, "", ErrFailedGenerateToken
+		return "", "", ErrFailedGenerateToken
 	}
 
 	return user.Role, token, nil
@@ -84,7 +103,12 @@ func (s *Userservice) CheckJWT(ctx context.Context, userID int64, token string)
 		return false, ErrGetUser
 	}
 
-	success := s.jwt.VerifyToken(token, user)
+	if user.IsSuspended(time.Now()) {
+		s.log.Error("user suspended", "id", user.ID)
+		return false, ErrUserSuspended
+	}
+
+	success := s.jwt.VerifyToken(token, user) && !user.PasswordResetRequired
 	if !success {
 		s.log.Error("failed to verify token")
 		return false, ErrInvalidCredentials
@@ -102,5 +126,103 @@ func (s *Userservice) GetStatistics(ctx context.Context) (int64, int64, error) {
 	return volunteersCount, blindCount, nil
 }
 
+func (s *Userservice) DeleteAccount(ctx context.Context, userID int64) error {
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			s.log.Error("user not found")
+			return ErrUserNotFound
+		}
+		s.log.Error("failed to get user")
+		return ErrGetUser
+	}
+
+	if err := s.db.SoftDeleteUser(ctx, user.ID); err != nil {
+		s.log.Error("failed to delete user", "id", userID, "error", err)
+		return ErrDeleteUser
+	}
+
+	s.log.Info("user marked as deleted", "id", userID, "purge_after", s.deletionGrace)
+
+	return nil
+}
+
+func (s *Userservice) ExportMyData(ctx context.Context, userID int64) (UserData, error) {
+	user, err := s.db.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			s.log.Error("user not found")
+			return UserData{}, ErrUserNotFound
+		}
+		s.log.Error("failed to get user")
+		return UserData{}, ErrGetUser
+	}
+
+	requests, calls, ratings, err := s.help.GetUserHistory(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get help history", "id", userID, "error", err)
+		return UserData{}, ErrExportData
+	}
+
+	blocked, err := s.db.GetBlocked(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get blocked users", "id", userID, "error", err)
+		return UserData{}, ErrExportData
+	}
+
+	return UserData{
+		ExportedAt: time.Now().UTC(),
+		Profile: Profile{
+			ID:        user.ID,
+			Email:     user.Email,
+			Role:      user.Role,
+			CreatedAt: user.CreatedAt,
+		},
+		HelpRequests: requests,
+		Calls:        calls,
+		Ratings:      ratings,
+		Blocked:      blocked,
+	}, nil
+}
+
+// PurgeDeletedUsers окончательно удаляет пользователей, у которых истёк срок
+// на восстановление, вместе с их историей в сервисе помощи и устройствами для уведомлений
+func (s *Userservice) PurgeDeletedUsers(ctx context.Context) error {
+	users, err := s.db.GetUsersDeletedBefore(ctx, time.Now().Add(-s.deletionGrace))
+	if err != nil {
+		s.log.Error("failed to get deleted users", "error", err)
+		return ErrGetUser
+	}
+
+	for _, user := range users {
+		if err := s.help.DeleteUserData(ctx, user.ID); err != nil {
+			s.log.Error("failed to delete help data", "id", user.ID, "error", err)
+			continue
+		}
+		if err := s.notify.DeleteUserData(ctx, user.ID); err != nil {
+			s.log.Error("failed to delete notify data", "id", user.ID, "error", err)
+			continue
+		}
+		if err := s.db.PurgeUser(ctx, user.ID); err != nil {
+			s.log.Error("failed to purge user", "id", user.ID, "error", err)
+			continue
+		}
+		s.log.Info("user purged", "id", user.ID)
+	}
+
+	return nil
+}
 
+func (s *Userservice) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.PurgeDeletedUsers(ctx)
+		}
+	}
+}
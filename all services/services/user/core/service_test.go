@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+const deletionGrace = 30 * 24 * time.Hour
+
+// accountDB добавляет к fakeDB удаление аккаунтов и список блокировок
+type accountDB struct {
+	*fakeDB
+
+	deletedAt map[int64]time.Time
+	purged    []int64
+	blocked   map[int64][]BlockedUser
+}
+
+func (d *accountDB) SoftDeleteUser(ctx context.Context, id int64) error {
+	d.deletedAt[id] = time.Now()
+	return nil
+}
+
+func (d *accountDB) GetUsersDeletedBefore(ctx context.Context, before time.Time) ([]User, error) {
+	var users []User
+	for id, deletedAt := range d.deletedAt {
+		if deletedAt.Before(before) {
+			users = append(users, *d.users[id])
+		}
+	}
+	return users, nil
+}
+
+func (d *accountDB) PurgeUser(ctx context.Context, id int64) error {
+	delete(d.deletedAt, id)
+	delete(d.users, id)
+	d.purged = append(d.purged, id)
+	return nil
+}
+
+func (d *accountDB) GetBlocked(ctx context.Context, blockerID int64) ([]BlockedUser, error) {
+	return d.blocked[blockerID], nil
+}
+
+// historyHelp отдаёт историю пользователя и запоминает, чьи данные удалены; failing - пользователи, на которых сервис падает
+type historyHelp struct {
+	Help
+
+	requests []HelpRequest
+	calls    []Call
+	ratings  []Rating
+	failing  map[int64]bool
+	deleted  []int64
+}
+
+func (h *historyHelp) GetUserHistory(ctx context.Context, userID int64) ([]HelpRequest, []Call, []Rating, error) {
+	if h.failing[userID] {
+		return nil, nil, nil, errors.New("help is down")
+	}
+	return h.requests, h.calls, h.ratings, nil
+}
+
+func (h *historyHelp) DeleteUserData(ctx context.Context, userID int64) error {
+	if h.failing[userID] {
+		return errors.New("help is down")
+	}
+	h.deleted = append(h.deleted, userID)
+	return nil
+}
+
+type fakeNotify struct {
+	failing map[int64]bool
+	deleted []int64
+}
+
+func (n *fakeNotify) DeleteUserData(ctx context.Context, userID int64) error {
+	if n.failing[userID] {
+		return errors.New("notify is down")
+	}
+	n.deleted = append(n.deleted, userID)
+	return nil
+}
+
+type accountTest struct {
+	service *Userservice
+	db      *accountDB
+	help    *historyHelp
+	notify  *fakeNotify
+}
+
+// newAccountTest - волонтёр 7 и пользователи 100..102
+func newAccountTest() *accountTest {
+	at := &accountTest{
+		db: &accountDB{
+			fakeDB:    newFakeDB(User{ID: 7, Email: "v7@example.com", Role: RoleVolunteer}, User{ID: 100}, User{ID: 101}, User{ID: 102}),
+			deletedAt: map[int64]time.Time{},
+			blocked:   map[int64][]BlockedUser{},
+		},
+		help:   &historyHelp{failing: map[int64]bool{}},
+		notify: &fakeNotify{failing: map[int64]bool{}},
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	at.service = NewUserService(log, at.db, nil, at.help, at.notify, &fakeEvents{}, deletionGrace, ModerationPolicy{})
+	return at
+}
+
+func TestExportMyDataIncludesHistoryAndBlocks(t *testing.T) {
+	at := newAccountTest()
+	at.help.requests = []HelpRequest{{ID: 1, RequesterID: 100, VolunteerID: 7}}
+	at.help.calls = []Call{{ID: 1, HelpRequestID: 1, BlindID: 100, VolunteerID: 7}}
+	at.help.ratings = []Rating{{ID: 1, HelpRequestID: 1, RaterID: 100, RateeID: 7, Score: 5}}
+	at.db.blocked[7] = []BlockedUser{{UserID: 101}}
+
+	data, err := at.service.ExportMyData(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("ExportMyData() error = %v", err)
+	}
+	if data.Profile.ID != 7 || data.Profile.Email != "v7@example.com" || data.Profile.Role != RoleVolunteer {
+		t.Errorf("profile = %+v", data.Profile)
+	}
+	if len(data.HelpRequests) != 1 || len(data.Calls) != 1 || len(data.Ratings) != 1 || len(data.Blocked) != 1 {
+		t.Errorf("data = %+v, want the help history and blocks", data)
+	}
+}
+
+func TestExportMyDataErrors(t *testing.T) {
+	at := newAccountTest()
+	at.help.failing[100] = true
+
+	if _, err := at.service.ExportMyData(context.Background(), 999); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("ExportMyData(unknown) error = %v, want %v", err, ErrUserNotFound)
+	}
+	if _, err := at.service.ExportMyData(context.Background(), 100); !errors.Is(err, ErrExportData) {
+		t.Errorf("ExportMyData() with help down error = %v, want %v", err, ErrExportData)
+	}
+}
+
+func TestPurgeDeletedUsersAfterGrace(t *testing.T) {
+	at := newAccountTest()
+	if err := at.service.DeleteAccount(context.Background(), 100); err != nil {
+		t.Fatalf("DeleteAccount() error = %v", err)
+	}
+	at.db.deletedAt[101] = time.Now().Add(-deletionGrace - time.Hour)
+
+	if err := at.service.PurgeDeletedUsers(context.Background()); err != nil {
+		t.Fatalf("PurgeDeletedUsers() error = %v", err)
+	}
+	// 100 ещё может восстановить аккаунт
+	if !slices.Equal(at.db.purged, []int64{101}) || !slices.Equal(at.help.deleted, []int64{101}) || !slices.Equal(at.notify.deleted, []int64{101}) {
+		t.Fatalf("purged %v, help data deleted for %v, notify for %v; want only 101", at.db.purged, at.help.deleted, at.notify.deleted)
+	}
+	if _, ok := at.db.deletedAt[100]; !ok {
+		t.Fatal("user 100 purged before grace")
+	}
+}
+
+func TestPurgeKeepsUserWhenServiceFails(t *testing.T) {
+	at := newAccountTest()
+	expired := time.Now().Add(-deletionGrace - time.Hour)
+	at.db.deletedAt[100] = expired
+	at.db.deletedAt[101] = expired
+	at.db.deletedAt[102] = expired
+	at.help.failing[100] = true
+	at.notify.failing[101] = true
+
+	if err := at.service.PurgeDeletedUsers(context.Background()); err != nil {
+		t.Fatalf("PurgeDeletedUsers() error = %v", err)
+	}
+	// пока данные в других сервисах не удалены, пользователь остаётся и попадёт в следующий проход
+	if !slices.Equal(at.db.purged, []int64{102}) {
+		t.Fatalf("purged %v, want [102]", at.db.purged)
+	}
+	for _, id := range []int64{100, 101} {
+		if _, ok := at.db.deletedAt[id]; !ok {
+			t.Errorf("user %d dropped from the purge queue", id)
+		}
+	}
+}
+
+func TestDeleteAccountOfUnknownUser(t *testing.T) {
+	at := newAccountTest()
+
+	if err := at.service.DeleteAccount(context.Background(), 999); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("DeleteAccount() error = %v, want %v", err, ErrUserNotFound)
+	}
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}$`)
+
+// maxVolunteerPages - сколько страниц просматривает ListVolunteers с AvailableOnly,
+// пропуская тех, кто вне окон доступности
+const maxVolunteerPages = 10
+
+// ListVolunteers - кандидаты, которым сервис help рассылает запрос.
+// С AvailableOnly тех, кого сейчас нельзя беспокоить, в ответе нет: список добирается
+// следующими страницами, чтобы недоступные волонтёры не занимали места более низких по рейтингу.
+func (s *Userservice) ListVolunteers(ctx context.Context, filter VolunteerFilter) (VolunteerList, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	var list VolunteerList
+	now := time.Now()
+	for page := 0; page < maxVolunteerPages; page++ {
+		volunteers, blocked, err := s.db.ListVolunteers(ctx, filter)
+		if err != nil {
+			s.log.Error("failed to list volunteers", "requester", filter.RequesterID, "error", err)
+			return VolunteerList{}, ErrGetUser
+		}
+
+		ids := make([]int64, 0, len(volunteers))
+		for _, volunteer := range volunteers {
+			ids = append(ids, volunteer.ID)
+		}
+		windows, err := s.db.GetAvailabilityWindows(ctx, ids)
+		if err != nil {
+			s.log.Error("failed to get availability windows", "error", err)
+			return VolunteerList{}, ErrGetUser
+		}
+
+		blockedIDs := make(map[int64]bool, len(blocked))
+		for _, id := range blocked {
+			blockedIDs[id] = true
+		}
+		for _, volunteer := range volunteers {
+			if len(list.Volunteers) == filter.Limit {
+				break
+			}
+			doNotDisturb := volunteer.DoNotDisturb(now)
+			offSchedule := !Available(windows[volunteer.ID], userLocation(volunteer), now)
+			if filter.AvailableOnly && (doNotDisturb || offSchedule && !filter.Urgent) {
+				continue
+			}
+
+			list.Volunteers = append(list.Volunteers, volunteer)
+			if blockedIDs[volunteer.ID] {
+				list.Blocked = append(list.Blocked, volunteer.ID)
+			}
+			if doNotDisturb {
+				list.DoNotDisturb = append(list.DoNotDisturb, volunteer.ID)
+			}
+			if offSchedule {
+				list.OffSchedule = append(list.OffSchedule, volunteer.ID)
+			}
+		}
+
+		if !filter.AvailableOnly || len(list.Volunteers) == filter.Limit || len(volunteers) < filter.Limit {
+			break
+		}
+		filter.Offset += len(volunteers)
+	}
+
+	return list, nil
+}
+
+// userLocation - часовой пояс пользователя, UTC если он не задан
+func userLocation(user User) *time.Location {
+	if user.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func (s *Userservice) GetAvailability(ctx context.Context, userID int64) (Availability, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return Availability{}, err
+	}
+
+	windows, err := s.db.GetAvailabilityWindows(ctx, []int64{userID})
+	if err != nil {
+		s.log.Error("failed to get availability windows", "user", userID, "error", err)
+		return Availability{}, ErrGetUser
+	}
+
+	availability := Availability{Windows: windows[userID]}
+	if user.DoNotDisturb(time.Now()) {
+		availability.DNDUntil = user.DNDUntil
+	}
+	return availability, nil
+}
+
+func (s *Userservice) SetAvailability(ctx context.Context, userID int64, windows []AvailabilityWindow) error {
+	if len(windows) > MaxWindows {
+		return ErrInvalidAvailability
+	}
+	for _, w := range windows {
+		if !w.Valid() {
+			return ErrInvalidAvailability
+		}
+	}
+
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.db.SetAvailabilityWindows(ctx, userID, windows); err != nil {
+		s.log.Error("failed to set availability windows", "user", userID, "error", err)
+		return ErrUpdateUser
+	}
+
+	return nil
+}
+
+// SetDoNotDisturb включает «не беспокоить» до until, nil выключает
+func (s *Userservice) SetDoNotDisturb(ctx context.Context, userID int64, until *time.Time) error {
+	if until != nil && !until.After(time.Now()) {
+		return ErrInvalidAvailability
+	}
+
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.db.SetDoNotDisturb(ctx, userID, until)
+}
+
+// UpdateProfile сохраняет языки и часовой пояс пользователя. Пустой timezone сбрасывает его.
+func (s *Userservice) UpdateProfile(ctx context.Context, userID int64, languages []string, timezone string) error {
+	if len(languages) > MaxLanguages {
+		return ErrInvalidProfile
+	}
+	seen := make(map[string]bool, len(languages))
+	unique := make([]string, 0, len(languages))
+	for _, language := range languages {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if !languagePattern.MatchString(language) {
+			return ErrInvalidProfile
+		}
+		if !seen[language] {
+			seen[language] = true
+			unique = append(unique, language)
+		}
+	}
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return ErrInvalidProfile
+		}
+	}
+
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.db.UpdateProfile(ctx, userID, strings.Join(unique, ","), timezone); err != nil {
+		s.log.Error("failed to update profile", "user", userID, "error", err)
+		return ErrUpdateUser
+	}
+
+	return nil
+}
@@ -8,9 +8,13 @@ import (
 	"os"
 	"os/signal"
 	"seeforme/user/adapters/db"
+	"seeforme/user/adapters/help"
 	"seeforme/user/adapters/jwt"
+	"seeforme/user/adapters/kafka"
+	"seeforme/user/adapters/notify"
 	"seeforme/user/config"
 	"seeforme/user/core"
+	_ "time/tzdata" // в образе alpine нет базы часовых поясов
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -43,7 +47,38 @@ func main() {
 
 	jwt := jwt.New(cfg.JWT.Secret, cfg.JWT.TTL, log)
 
-	userService := core.NewUserService(log, storage, jwt)
+	helpClient, err := help.NewClient(cfg.HelpAddress, log)
+	if err != nil {
+		log.Error("failed to init help adapter", "error", err)
+		return
+	}
+
+	notifyClient, err := notify.NewClient(cfg.NotifyAddress, log)
+	if err != nil {
+		log.Error("failed to init notify adapter", "error", err)
+		return
+	}
+
+	kafkaClient, err := kafka.NewClient(cfg.Kafka.Brokers, cfg.Kafka.UserEventsTopic, log)
+	if err != nil {
+		log.Error("failed to init kafka client", "error", err)
+		return
+	}
+	defer kafkaClient.Close()
+
+	moderation := core.ModerationPolicy{
+		Threshold:  cfg.Moderation.ReportThreshold,
+		Window:     cfg.Moderation.ReportWindow,
+		SuspendFor: cfg.Moderation.SuspendFor,
+	}
+	userService := core.NewUserService(log, storage, jwt, helpClient, notifyClient, kafkaClient, cfg.Deletion.GracePeriod, moderation)
+
+	if cfg.Admin.Email != "" {
+		if err := userService.EnsureAdmin(context.Background(), cfg.Admin.Email, cfg.Admin.Password); err != nil {
+			log.Error("failed to create admin", "error", err)
+			return
+		}
+	}
 
 	listener, err := net.Listen("tcp", cfg.Address)
 	if err != nil {
@@ -64,6 +99,8 @@ func main() {
 		s.GracefulStop()
 	}()
 
+	go userService.RunPurger(ctx, cfg.Deletion.PurgeInterval)
+
 	if err := s.Serve(listener); err != nil {
 		log.Error("failed to serve", "erorr", err)
 		return